@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/evaizee/seat-arrangements/backend/di"
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/spf13/cobra"
+)
+
+// checkCmd represents the check command
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check seat layout data for inconsistencies",
+	Long: `Check that the cabins, seat rows, seats and prices behind the seat map are
+consistent, for one segment or the whole database. With --fix, issues that can
+be repaired without losing data (row seat codes, seat segments and available
+blank or aisle slots) are fixed in place. Exits with status 1 when unresolved
+issues remain.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		segmentID, _ := cmd.Flags().GetString("segment")
+		format, _ := cmd.Flags().GetString("format")
+		fix, _ := cmd.Flags().GetBool("fix")
+
+		if format != "text" && format != "json" {
+			log.Fatalf("Unsupported format: %s", format)
+		}
+
+		container := di.NewContainer()
+
+		report, err := container.LayoutService.Check(segmentID, fix)
+		if err != nil {
+			log.Fatalf("Check failed: %v", err)
+		}
+
+		if format == "json" {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(report); err != nil {
+				log.Fatalf("Failed to encode report: %v", err)
+			}
+		} else {
+			printLayoutReport(report)
+		}
+
+		if report.Unresolved() > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(checkCmd)
+
+	checkCmd.Flags().String("segment", "", "Only check the cabins of this segment (flight) ID")
+	checkCmd.Flags().String("format", "text", "Output format: text or json")
+	checkCmd.Flags().Bool("fix", false, "Fix issues that can be repaired safely")
+}
+
+// printLayoutReport writes a human-readable layout report to stdout
+func printLayoutReport(report *models.LayoutReport) {
+	fmt.Printf("Checked %d cabins, %d rows and %d seats\n",
+		report.CabinsChecked, report.RowsChecked, report.SeatsChecked)
+
+	if len(report.Issues) == 0 {
+		fmt.Println("No issues found")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STATUS\tISSUE\tCABIN\tROW\tSEAT\tDETAILS")
+	for _, issue := range report.Issues {
+		status := "open"
+		if issue.Fixed {
+			status = "fixed"
+		} else if issue.Fixable {
+			status = "fixable"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			status, issue.Code, orDash(issue.CabinID), orDash(issue.RowID), orDash(issue.SeatID), issue.Message)
+	}
+	w.Flush()
+
+	fmt.Printf("%d issues, %d unresolved\n", len(report.Issues), report.Unresolved())
+}
+
+// orDash returns s, or "-" when s is empty
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/evaizee/seat-arrangements/backend/security"
+	"github.com/spf13/cobra"
+)
+
+// keysCmd represents the keys command
+var keysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Manage JWT signing keys",
+	Long:  `Manage the asymmetric keys used to sign and verify JWTs.`,
+}
+
+// keysGenerateCmd represents the keys generate command
+var keysGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate a new JWT key pair",
+	Long: `Generate a new RS256 or ES256 key pair as PEM files. Add the private key
+to jwt.keys and point jwt.signing_key_id at it to start signing with it.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		algorithm, _ := cmd.Flags().GetString("alg")
+		keyID, _ := cmd.Flags().GetString("id")
+		outDir, _ := cmd.Flags().GetString("out")
+
+		if keyID == "" {
+			log.Fatalf("--id is required")
+		}
+
+		var privateKey crypto.Signer
+		var err error
+		switch algorithm {
+		case security.AlgorithmRS256:
+			privateKey, err = rsa.GenerateKey(rand.Reader, 2048)
+		case security.AlgorithmES256:
+			privateKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		default:
+			log.Fatalf("Unsupported algorithm: %s", algorithm)
+		}
+		if err != nil {
+			log.Fatalf("Failed to generate key: %v", err)
+		}
+
+		privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+		if err != nil {
+			log.Fatalf("Failed to encode private key: %v", err)
+		}
+
+		publicDER, err := x509.MarshalPKIXPublicKey(privateKey.Public())
+		if err != nil {
+			log.Fatalf("Failed to encode public key: %v", err)
+		}
+
+		if err := os.MkdirAll(outDir, 0o700); err != nil {
+			log.Fatalf("Failed to create output directory: %v", err)
+		}
+
+		privatePath := filepath.Join(outDir, fmt.Sprintf("jwt-%s.pem", keyID))
+		publicPath := filepath.Join(outDir, fmt.Sprintf("jwt-%s.pub.pem", keyID))
+
+		if err := writePEM(privatePath, "PRIVATE KEY", privateDER, 0o600); err != nil {
+			log.Fatalf("Failed to write private key: %v", err)
+		}
+		if err := writePEM(publicPath, "PUBLIC KEY", publicDER, 0o644); err != nil {
+			log.Fatalf("Failed to write public key: %v", err)
+		}
+
+		fmt.Println("Private key written to", privatePath)
+		fmt.Println("Public key written to", publicPath)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(keysCmd)
+	keysCmd.AddCommand(keysGenerateCmd)
+
+	keysGenerateCmd.Flags().String("alg", security.AlgorithmRS256, "Key algorithm (RS256 or ES256)")
+	keysGenerateCmd.Flags().String("id", "", "Key ID used as the kid header")
+	keysGenerateCmd.Flags().String("out", "./config/keys", "Directory to write the PEM files to")
+}
+
+// writePEM writes a single PEM block to path, refusing to overwrite existing files
+func writePEM(path, blockType string, der []byte, perm os.FileMode) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return pem.Encode(file, &pem.Block{Type: blockType, Bytes: der})
+}
@@ -52,11 +52,11 @@ func initConfig() {
 
 	viper.AutomaticEnv() // read in environment variables that match
 
-	// If a config file is found, read it in.
+	// If a config file is found, read it in. Report on stderr so command output stays clean
 	if err := viper.ReadInConfig(); err == nil {
-		fmt.Println("Using config file:", viper.ConfigFileUsed())
+		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 	} else {
-		fmt.Println("Warning: config file not found:", err)
+		fmt.Fprintln(os.Stderr, "Warning: config file not found:", err)
 	}
 }
 
@@ -2,9 +2,11 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/evaizee/seat-arrangements/backend/di"
 	"github.com/evaizee/seat-arrangements/backend/routes"
+	"github.com/evaizee/seat-arrangements/backend/services"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/recover"
@@ -72,6 +74,9 @@ func serve() {
 	// Setup routes
 	routes.SetupRoutes(app, container)
 
+	// Put seats of expired holds back on sale
+	go releaseExpiredHolds(container.BookingService)
+
 	// Get host and port from config
 	host := viper.GetString("server.host")
 	port := viper.GetInt("server.port")
@@ -83,4 +88,23 @@ func serve() {
 	if err := app.Listen(serverAddr); err != nil {
 		zap.L().Fatal("Error starting server", zap.Error(err))
 	}
-}
\ No newline at end of file
+}
+
+// releaseExpiredHolds periodically cancels seat holds that expired without
+// being paid for, every booking.hold.release_interval
+func releaseExpiredHolds(bookingService services.BookingService) {
+	interval := viper.GetDuration("booking.hold.release_interval")
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		released, err := bookingService.ReleaseExpiredHolds()
+		if err == nil && released > 0 {
+			zap.L().Info("Released expired seat holds", zap.Int("count", released))
+		}
+	}
+}
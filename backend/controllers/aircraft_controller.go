@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"github.com/evaizee/seat-arrangements/backend/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+// AircraftController handles HTTP requests related to aircraft
+type AircraftController struct {
+	aircraftService services.AircraftService
+	cabinService    services.CabinService
+}
+
+// NewAircraftController creates a new AircraftController
+func NewAircraftController(aircraftService services.AircraftService, cabinService services.CabinService) *AircraftController {
+	return &AircraftController{
+		aircraftService: aircraftService,
+		cabinService:    cabinService,
+	}
+}
+
+// aircraftRequest is the body of the aircraft create and update endpoints
+type aircraftRequest struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// GetAll handles GET /api/admin/aircraft
+func (c *AircraftController) GetAll(ctx *fiber.Ctx) error {
+	aircraft, err := c.aircraftService.GetAll()
+	if err != nil {
+		return handleLayoutError(ctx, err, "Failed to get aircraft")
+	}
+
+	return ctx.JSON(aircraft)
+}
+
+// GetByID handles GET /api/admin/aircraft/:id
+func (c *AircraftController) GetByID(ctx *fiber.Ctx) error {
+	aircraft, err := c.aircraftService.GetByID(ctx.Params("id"))
+	if err != nil {
+		return handleLayoutError(ctx, err, "Failed to get aircraft")
+	}
+
+	return ctx.JSON(aircraft)
+}
+
+// Create handles POST /api/admin/aircraft
+func (c *AircraftController) Create(ctx *fiber.Ctx) error {
+	var req aircraftRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	aircraft, err := c.aircraftService.CreateAircraft(req.Code, req.Name)
+	if err != nil {
+		return handleLayoutError(ctx, err, "Failed to create aircraft")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(aircraft)
+}
+
+// Update handles PUT /api/admin/aircraft/:id
+func (c *AircraftController) Update(ctx *fiber.Ctx) error {
+	var req aircraftRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	aircraft, err := c.aircraftService.UpdateAircraft(ctx.Params("id"), req.Code, req.Name)
+	if err != nil {
+		return handleLayoutError(ctx, err, "Failed to update aircraft")
+	}
+
+	return ctx.JSON(aircraft)
+}
+
+// Delete handles DELETE /api/admin/aircraft/:id
+func (c *AircraftController) Delete(ctx *fiber.Ctx) error {
+	if err := c.aircraftService.DeleteAircraft(ctx.Params("id")); err != nil {
+		return handleLayoutError(ctx, err, "Failed to delete aircraft")
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+// GetCabins handles GET /api/admin/aircraft/:id/cabins
+func (c *AircraftController) GetCabins(ctx *fiber.Ctx) error {
+	cabins, err := c.cabinService.GetByAircraftID(ctx.Params("id"))
+	if err != nil {
+		return handleLayoutError(ctx, err, "Failed to get cabins")
+	}
+
+	return ctx.JSON(cabins)
+}
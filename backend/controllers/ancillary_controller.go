@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/evaizee/seat-arrangements/backend/middleware"
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/services"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// AncillaryController handles HTTP requests related to ancillary products, bundles and offers
+type AncillaryController struct {
+	ancillaryService services.AncillaryService
+	seatService      services.SeatService
+}
+
+// NewAncillaryController creates a new AncillaryController
+func NewAncillaryController(ancillaryService services.AncillaryService, seatService services.SeatService) *AncillaryController {
+	return &AncillaryController{
+		ancillaryService: ancillaryService,
+		seatService:      seatService,
+	}
+}
+
+// productRequest is the body of POST /api/revenue/ancillaries
+type productRequest struct {
+	Code        string      `json:"code"`
+	Name        string      `json:"name"`
+	Category    string      `json:"category"`
+	Description string      `json:"description"`
+	Price       json.Number `json:"price"`
+	Currency    string      `json:"currency"`
+}
+
+// createBundleRequest is the body of POST /api/revenue/bundles
+type createBundleRequest struct {
+	Code                string      `json:"code"`
+	Name                string      `json:"name"`
+	Description         string      `json:"description"`
+	SeatCharacteristics []string    `json:"seat_characteristics"`
+	DiscountPercent     json.Number `json:"discount_percent"`
+	ProductIDs          []string    `json:"product_ids"`
+}
+
+// GetCatalogue handles GET /api/ancillaries
+func (c *AncillaryController) GetCatalogue(ctx *fiber.Ctx) error {
+	products, err := c.ancillaryService.GetProducts()
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to get ancillary products")
+	}
+
+	bundles, err := c.ancillaryService.GetBundles()
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to get bundles")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"products": products,
+		"bundles":  bundles,
+	})
+}
+
+// GetOffers handles GET /api/offers
+func (c *AncillaryController) GetOffers(ctx *fiber.Ctx) error {
+	flightID := ctx.Query("flightId")
+	if flightID == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Flight ID is required",
+		})
+	}
+
+	offers, err := c.seatService.GetBundleOffers(flightID, ctx.Query("passengerId"), ctx.Query("currency"))
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to get bundle offers")
+	}
+
+	return ctx.JSON(offers)
+}
+
+// CreateProduct handles POST /api/revenue/ancillaries
+func (c *AncillaryController) CreateProduct(ctx *fiber.Ctx) error {
+	var req productRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	price, err := models.ParseMoney(req.Price.String(), req.Currency)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	product := models.NewAncillaryProduct(req.Code, req.Name, req.Category, req.Description, price)
+	product, err = c.ancillaryService.CreateProduct(product)
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to create ancillary product")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(product)
+}
+
+// CreateBundle handles POST /api/revenue/bundles
+func (c *AncillaryController) CreateBundle(ctx *fiber.Ctx) error {
+	var req createBundleRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	bundle, err := c.ancillaryService.CreateBundle(
+		req.Code,
+		req.Name,
+		req.Description,
+		req.SeatCharacteristics,
+		req.DiscountPercent.String(),
+		req.ProductIDs,
+	)
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to create bundle")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(bundle)
+}
+
+// handleError maps service errors to HTTP responses, logging unexpected ones
+func (c *AncillaryController) handleError(ctx *fiber.Ctx, err error, msg string) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrInvalidAncillary),
+		errors.Is(err, services.ErrInvalidCurrency),
+		errors.Is(err, services.ErrInvalidDeck):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, services.ErrProductNotFound),
+		errors.Is(err, services.ErrFlightNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, services.ErrAncillaryExists):
+		status = fiber.StatusConflict
+	case errors.Is(err, services.ErrRateNotFound):
+		status = fiber.StatusUnprocessableEntity
+	}
+
+	if status == fiber.StatusInternalServerError {
+		zap.L().Error(msg, zap.Error(err), zap.String("user_id", middleware.CurrentUserID(ctx)))
+		return ctx.Status(status).JSON(fiber.Map{
+			"error": true,
+			"msg":   msg,
+		})
+	}
+
+	return ctx.Status(status).JSON(fiber.Map{
+		"error": true,
+		"msg":   err.Error(),
+	})
+}
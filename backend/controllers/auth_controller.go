@@ -0,0 +1,262 @@
+package controllers
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/evaizee/seat-arrangements/backend/middleware"
+	"github.com/evaizee/seat-arrangements/backend/security"
+	"github.com/evaizee/seat-arrangements/backend/services"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// AuthController handles HTTP requests related to authentication
+type AuthController struct {
+	authService services.AuthService
+	keySet      *security.KeySet
+}
+
+// NewAuthController creates a new AuthController
+func NewAuthController(authService services.AuthService, keySet *security.KeySet) *AuthController {
+	return &AuthController{
+		authService: authService,
+		keySet:      keySet,
+	}
+}
+
+// registerRequest is the body of POST /api/auth/register
+type registerRequest struct {
+	Email     string `json:"email"`
+	Password  string `json:"password"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+// loginRequest is the body of POST /api/auth/login
+type loginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// forgotPasswordRequest is the body of POST /api/auth/password/forgot
+type forgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+// resetPasswordRequest is the body of POST /api/auth/password/reset
+type resetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// verifyEmailRequest is the body of POST /api/auth/email/verify
+type verifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+// Register handles POST /api/auth/register
+func (c *AuthController) Register(ctx *fiber.Ctx) error {
+	var req registerRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	if req.Email == "" || req.Password == "" || req.FirstName == "" || req.LastName == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Email, password, first name and last name are required",
+		})
+	}
+
+	user, err := c.authService.Register(req.Email, req.Password, req.FirstName, req.LastName)
+	if err != nil {
+		if errors.Is(err, services.ErrWeakPassword) {
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": true,
+				"msg":   err.Error(),
+			})
+		}
+		if errors.Is(err, services.ErrEmailAlreadyExists) {
+			return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": true,
+				"msg":   "Email already registered",
+			})
+		}
+		zap.L().Error("Failed to register user", zap.Error(err))
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Failed to register user",
+		})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(user)
+}
+
+// Login handles POST /api/auth/login
+func (c *AuthController) Login(ctx *fiber.Ctx) error {
+	var req loginRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	token, err := c.authService.Login(req.Email, req.Password, ctx.IP())
+	if err != nil {
+		var lockedErr *services.AccountLockedError
+		if errors.As(err, &lockedErr) {
+			retryAfter := int(math.Ceil(time.Until(lockedErr.Until).Seconds()))
+			ctx.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfter))
+			return ctx.Status(fiber.StatusLocked).JSON(fiber.Map{
+				"error":        true,
+				"msg":          "Account temporarily locked due to too many failed login attempts",
+				"locked_until": lockedErr.Until,
+			})
+		}
+		if errors.Is(err, services.ErrInvalidCredentials) {
+			return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": true,
+				"msg":   "Invalid email or password",
+			})
+		}
+		zap.L().Error("Failed to log in user", zap.Error(err))
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Failed to log in",
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"token": token,
+	})
+}
+
+// ForgotPassword handles POST /api/auth/password/forgot
+func (c *AuthController) ForgotPassword(ctx *fiber.Ctx) error {
+	var req forgotPasswordRequest
+	if err := ctx.BodyParser(&req); err != nil || req.Email == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Email is required",
+		})
+	}
+
+	if err := c.authService.RequestPasswordReset(req.Email); err != nil {
+		zap.L().Error("Failed to request password reset", zap.Error(err))
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Failed to request password reset",
+		})
+	}
+
+	// Same response whether or not the email exists
+	return ctx.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"msg": "If the email is registered, a reset link has been sent",
+	})
+}
+
+// ResetPassword handles POST /api/auth/password/reset
+func (c *AuthController) ResetPassword(ctx *fiber.Ctx) error {
+	var req resetPasswordRequest
+	if err := ctx.BodyParser(&req); err != nil || req.Token == "" || req.Password == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Token and password are required",
+		})
+	}
+
+	if err := c.authService.ResetPassword(req.Token, req.Password); err != nil {
+		switch {
+		case errors.Is(err, services.ErrWeakPassword):
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": true,
+				"msg":   err.Error(),
+			})
+		case errors.Is(err, services.ErrInvalidToken), errors.Is(err, services.ErrUserNotFound):
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": true,
+				"msg":   "Invalid or expired token",
+			})
+		}
+		zap.L().Error("Failed to reset password", zap.Error(err))
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Failed to reset password",
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"msg": "Password has been reset",
+	})
+}
+
+// VerifyEmail handles POST /api/auth/email/verify
+func (c *AuthController) VerifyEmail(ctx *fiber.Ctx) error {
+	var req verifyEmailRequest
+	if err := ctx.BodyParser(&req); err != nil || req.Token == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Token is required",
+		})
+	}
+
+	if err := c.authService.VerifyEmail(req.Token); err != nil {
+		if errors.Is(err, services.ErrInvalidToken) || errors.Is(err, services.ErrUserNotFound) {
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": true,
+				"msg":   "Invalid or expired token",
+			})
+		}
+		zap.L().Error("Failed to verify email", zap.Error(err))
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Failed to verify email",
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"msg": "Email verified",
+	})
+}
+
+// ResendVerification handles POST /api/auth/email/verification
+func (c *AuthController) ResendVerification(ctx *fiber.Ctx) error {
+	userID := middleware.CurrentUserID(ctx)
+
+	if err := c.authService.RequestEmailVerification(userID); err != nil {
+		switch {
+		case errors.Is(err, services.ErrAlreadyVerified):
+			return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": true,
+				"msg":   "Email already verified",
+			})
+		case errors.Is(err, services.ErrUserNotFound):
+			return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": true,
+				"msg":   "User not found",
+			})
+		}
+		zap.L().Error("Failed to send verification email", zap.Error(err), zap.String("user_id", userID))
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Failed to send verification email",
+		})
+	}
+
+	return ctx.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"msg": "Verification email sent",
+	})
+}
+
+// JWKS handles GET /.well-known/jwks.json
+func (c *AuthController) JWKS(ctx *fiber.Ctx) error {
+	ctx.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return ctx.JSON(c.keySet.JWKS())
+}
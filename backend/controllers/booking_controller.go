@@ -0,0 +1,472 @@
+package controllers
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/evaizee/seat-arrangements/backend/middleware"
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/services"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// BookingController handles HTTP requests related to bookings
+type BookingController struct {
+	bookingService services.BookingService
+}
+
+// NewBookingController creates a new BookingController
+func NewBookingController(bookingService services.BookingService) *BookingController {
+	return &BookingController{
+		bookingService: bookingService,
+	}
+}
+
+// createBookingRequest is the body of POST /api/bookings
+type createBookingRequest struct {
+	FlightID      string   `json:"flight_id"`
+	SeatIDs       []string `json:"seat_ids"`
+	Currency      string   `json:"currency"`
+	PromoCode     string   `json:"promo_code"`
+	PaymentMethod string   `json:"payment_method"`
+}
+
+// holdRequest is the body of POST /api/bookings/holds
+type holdRequest struct {
+	FlightID  string   `json:"flight_id"`
+	SeatIDs   []string `json:"seat_ids"`
+	Currency  string   `json:"currency"`
+	PromoCode string   `json:"promo_code"`
+}
+
+// confirmHoldRequest is the body of POST /api/bookings/:id/confirm
+type confirmHoldRequest struct {
+	PaymentMethod string `json:"payment_method"`
+}
+
+// ancillaryRequest is the body of POST /api/bookings/:id/ancillaries
+type ancillaryRequest struct {
+	BookingSeatID string `json:"booking_seat_id"`
+	ProductID     string `json:"product_id"`
+}
+
+// bundleRequest is the body of POST /api/bookings/:id/bundles
+type bundleRequest struct {
+	BookingSeatID string `json:"booking_seat_id"`
+	BundleID      string `json:"bundle_id"`
+}
+
+// changeSeatRequest is the body of PUT /api/bookings/:id/seats/:seatId
+type changeSeatRequest struct {
+	SeatID        string `json:"seat_id"`
+	PaymentMethod string `json:"payment_method"`
+}
+
+// swapSeatsRequest is the body of POST /api/agent/bookings/swap
+type swapSeatsRequest struct {
+	FirstBookingSeatID  string `json:"first_booking_seat_id"`
+	SecondBookingSeatID string `json:"second_booking_seat_id"`
+	FirstPaymentMethod  string `json:"first_payment_method"`
+	SecondPaymentMethod string `json:"second_payment_method"`
+}
+
+// updateStatusRequest is the body of PUT /api/agent/bookings/:id/status
+type updateStatusRequest struct {
+	Status models.BookingStatus `json:"status"`
+	Reason string               `json:"reason"`
+}
+
+// Create handles POST /api/bookings
+func (c *BookingController) Create(ctx *fiber.Ctx) error {
+	var req createBookingRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	if req.FlightID == "" || len(req.SeatIDs) == 0 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Flight ID and at least one seat ID are required",
+		})
+	}
+
+	booking, err := c.bookingService.CreateBooking(
+		middleware.CurrentUserID(ctx),
+		req.FlightID,
+		req.SeatIDs,
+		strings.ToUpper(strings.TrimSpace(req.Currency)),
+		req.PromoCode,
+		req.PaymentMethod,
+	)
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to create booking")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(booking)
+}
+
+// Hold handles POST /api/bookings/holds
+func (c *BookingController) Hold(ctx *fiber.Ctx) error {
+	var req holdRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	if req.FlightID == "" || len(req.SeatIDs) == 0 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Flight ID and at least one seat ID are required",
+		})
+	}
+
+	booking, err := c.bookingService.HoldSeats(
+		middleware.CurrentUserID(ctx),
+		req.FlightID,
+		req.SeatIDs,
+		strings.ToUpper(strings.TrimSpace(req.Currency)),
+		req.PromoCode,
+	)
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to hold seats")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(booking)
+}
+
+// Confirm handles POST /api/bookings/:id/confirm
+func (c *BookingController) Confirm(ctx *fiber.Ctx) error {
+	var req confirmHoldRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	bookingID := ctx.Params("id")
+	if err := c.authorize(ctx, bookingID); err != nil {
+		return c.handleError(ctx, err, "Failed to confirm booking")
+	}
+
+	booking, err := c.bookingService.ConfirmHold(bookingID, middleware.CurrentUserID(ctx), req.PaymentMethod)
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to confirm booking")
+	}
+
+	return ctx.JSON(booking)
+}
+
+// GetMine handles GET /api/bookings
+func (c *BookingController) GetMine(ctx *fiber.Ctx) error {
+	bookings, err := c.bookingService.GetByUserID(middleware.CurrentUserID(ctx))
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to get bookings")
+	}
+
+	return ctx.JSON(bookings)
+}
+
+// GetByID handles GET /api/bookings/:id
+func (c *BookingController) GetByID(ctx *fiber.Ctx) error {
+	booking, err := c.bookingService.GetByID(ctx.Params("id"))
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to get booking")
+	}
+
+	if !middleware.CanAccessUser(ctx, booking.Booking.UserID) {
+		return c.handleError(ctx, services.ErrBookingNotFound, "")
+	}
+
+	return ctx.JSON(booking)
+}
+
+// Cancel handles POST /api/bookings/:id/cancel
+func (c *BookingController) Cancel(ctx *fiber.Ctx) error {
+	if err := c.authorize(ctx, ctx.Params("id")); err != nil {
+		return c.handleError(ctx, err, "Failed to cancel booking")
+	}
+
+	refund, err := c.bookingService.CancelBooking(ctx.Params("id"), middleware.CurrentUserID(ctx))
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to cancel booking")
+	}
+
+	return ctx.JSON(refund)
+}
+
+// GetHistory handles GET /api/bookings/:id/history
+func (c *BookingController) GetHistory(ctx *fiber.Ctx) error {
+	bookingID := ctx.Params("id")
+	if err := c.authorize(ctx, bookingID); err != nil {
+		return c.handleError(ctx, err, "Failed to get booking history")
+	}
+
+	history, err := c.bookingService.GetStatusHistory(bookingID)
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to get booking history")
+	}
+
+	return ctx.JSON(history)
+}
+
+// UpdateStatus handles PUT /api/agent/bookings/:id/status
+func (c *BookingController) UpdateStatus(ctx *fiber.Ctx) error {
+	var req updateStatusRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	booking, err := c.bookingService.UpdateStatus(ctx.Params("id"), req.Status, middleware.CurrentUserID(ctx), req.Reason)
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to update booking status")
+	}
+
+	return ctx.JSON(booking)
+}
+
+// GetRefund handles GET /api/bookings/:id/refund
+func (c *BookingController) GetRefund(ctx *fiber.Ctx) error {
+	bookingID := ctx.Params("id")
+	if err := c.authorize(ctx, bookingID); err != nil {
+		return c.handleError(ctx, err, "Failed to get refund")
+	}
+
+	refund, err := c.bookingService.GetRefund(bookingID)
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to get refund")
+	}
+
+	return ctx.JSON(refund)
+}
+
+// ChangeSeat handles PUT /api/bookings/:id/seats/:seatId, where seatId is the booking seat to move
+func (c *BookingController) ChangeSeat(ctx *fiber.Ctx) error {
+	var req changeSeatRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	if req.SeatID == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Seat ID is required",
+		})
+	}
+
+	bookingID := ctx.Params("id")
+	if err := c.authorize(ctx, bookingID); err != nil {
+		return c.handleError(ctx, err, "Failed to change seat")
+	}
+
+	change, err := c.bookingService.ChangeSeat(bookingID, ctx.Params("seatId"), req.SeatID, middleware.CurrentUserID(ctx), req.PaymentMethod)
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to change seat")
+	}
+
+	return ctx.JSON(change)
+}
+
+// AddAncillary handles POST /api/bookings/:id/ancillaries
+func (c *BookingController) AddAncillary(ctx *fiber.Ctx) error {
+	var req ancillaryRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	if req.BookingSeatID == "" || req.ProductID == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Booking seat ID and product ID are required",
+		})
+	}
+
+	bookingID := ctx.Params("id")
+	if err := c.authorize(ctx, bookingID); err != nil {
+		return c.handleError(ctx, err, "Failed to add ancillary")
+	}
+
+	booking, err := c.bookingService.AddAncillary(bookingID, req.BookingSeatID, req.ProductID)
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to add ancillary")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(booking)
+}
+
+// AddBundle handles POST /api/bookings/:id/bundles
+func (c *BookingController) AddBundle(ctx *fiber.Ctx) error {
+	var req bundleRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	if req.BookingSeatID == "" || req.BundleID == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Booking seat ID and bundle ID are required",
+		})
+	}
+
+	bookingID := ctx.Params("id")
+	if err := c.authorize(ctx, bookingID); err != nil {
+		return c.handleError(ctx, err, "Failed to add bundle")
+	}
+
+	booking, err := c.bookingService.AddBundle(bookingID, req.BookingSeatID, req.BundleID)
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to add bundle")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(booking)
+}
+
+// GetChanges handles GET /api/bookings/:id/changes
+func (c *BookingController) GetChanges(ctx *fiber.Ctx) error {
+	bookingID := ctx.Params("id")
+	if err := c.authorize(ctx, bookingID); err != nil {
+		return c.handleError(ctx, err, "Failed to get seat changes")
+	}
+
+	changes, err := c.bookingService.GetSeatChanges(bookingID)
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to get seat changes")
+	}
+
+	return ctx.JSON(changes)
+}
+
+// SwapSeats handles POST /api/agent/bookings/swap
+func (c *BookingController) SwapSeats(ctx *fiber.Ctx) error {
+	var req swapSeatsRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	if req.FirstBookingSeatID == "" || req.SecondBookingSeatID == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Both booking seat IDs are required",
+		})
+	}
+
+	changes, err := c.bookingService.SwapSeats(
+		req.FirstBookingSeatID,
+		req.SecondBookingSeatID,
+		req.FirstPaymentMethod,
+		req.SecondPaymentMethod,
+		middleware.CurrentUserID(ctx),
+	)
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to swap seats")
+	}
+
+	return ctx.JSON(changes)
+}
+
+// authorize checks that the authenticated user may act on a booking. Bookings
+// of other users are reported as not found so their IDs are not disclosed.
+func (c *BookingController) authorize(ctx *fiber.Ctx, bookingID string) error {
+	if middleware.IsStaff(ctx) {
+		return nil
+	}
+
+	booking, err := c.bookingService.GetByID(bookingID)
+	if err != nil {
+		return err
+	}
+
+	if !middleware.CanAccessUser(ctx, booking.Booking.UserID) {
+		return services.ErrBookingNotFound
+	}
+
+	return nil
+}
+
+// handleError maps booking service errors to HTTP responses; a check-in blocked
+// by incomplete passenger data lists the error of each field
+func (c *BookingController) handleError(ctx *fiber.Ctx, err error, msg string) error {
+	var validation *services.ValidationError
+	if errors.As(err, &validation) {
+		return ctx.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"error":  true,
+			"msg":    validation.Err.Error(),
+			"errors": validation.Fields,
+		})
+	}
+
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrBookingNotFound),
+		errors.Is(err, services.ErrBookingSeatMissing),
+		errors.Is(err, services.ErrFlightNotFound),
+		errors.Is(err, services.ErrSeatNotFound),
+		errors.Is(err, services.ErrRefundNotFound),
+		errors.Is(err, services.ErrPromotionNotFound),
+		errors.Is(err, services.ErrProductNotFound),
+		errors.Is(err, services.ErrBundleNotFound),
+		errors.Is(err, services.ErrRowNotFound),
+		errors.Is(err, services.ErrCabinNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, services.ErrInvalidSeatChange),
+		errors.Is(err, services.ErrInvalidStatus),
+		errors.Is(err, services.ErrInvalidBooking),
+		errors.Is(err, services.ErrPaymentRequired),
+		errors.Is(err, services.ErrInvalidCurrency):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, services.ErrRateNotFound),
+		errors.Is(err, services.ErrPromotionNotActive),
+		errors.Is(err, services.ErrPromotionNotUsable),
+		errors.Is(err, services.ErrPromotionExhausted),
+		errors.Is(err, services.ErrBundleNotOffered):
+		status = fiber.StatusUnprocessableEntity
+	case errors.Is(err, services.ErrPaymentDeclined):
+		status = fiber.StatusPaymentRequired
+	case errors.Is(err, services.ErrPaymentFailed):
+		status = fiber.StatusBadGateway
+	case errors.Is(err, services.ErrBookingNotActive),
+		errors.Is(err, services.ErrSeatUnavailable),
+		errors.Is(err, services.ErrConcurrentUpdate),
+		errors.Is(err, services.ErrInvalidTransition),
+		errors.Is(err, services.ErrFlightDeparted),
+		errors.Is(err, services.ErrBookingNotHeld),
+		errors.Is(err, services.ErrInvalidLayout):
+		status = fiber.StatusConflict
+	case errors.Is(err, services.ErrHoldExpired):
+		status = fiber.StatusGone
+	}
+
+	if status == fiber.StatusInternalServerError {
+		zap.L().Error(msg, zap.Error(err), zap.String("user_id", middleware.CurrentUserID(ctx)))
+		return ctx.Status(status).JSON(fiber.Map{
+			"error": true,
+			"msg":   msg,
+		})
+	}
+
+	return ctx.Status(status).JSON(fiber.Map{
+		"error": true,
+		"msg":   err.Error(),
+	})
+}
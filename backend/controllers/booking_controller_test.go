@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+// stubBookingService returns a booking owned by its user and fails AddBundle with err
+type stubBookingService struct {
+	services.BookingService
+	userID string
+	err    error
+}
+
+func (s *stubBookingService) GetByID(id string) (*models.BookingWithDetails, error) {
+	return &models.BookingWithDetails{Booking: &models.Booking{ID: id, UserID: s.userID}}, nil
+}
+
+func (s *stubBookingService) AddBundle(bookingID, bookingSeatID, bundleID string) (*models.BookingWithDetails, error) {
+	return nil, s.err
+}
+
+func TestAddBundleErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"row not found", services.ErrRowNotFound, fiber.StatusNotFound},
+		{"cabin not found", services.ErrCabinNotFound, fiber.StatusNotFound},
+		{"invalid layout", fmt.Errorf("%w: cabin c1: layout has no seat columns", services.ErrInvalidLayout), fiber.StatusConflict},
+		{"bundle not offered", services.ErrBundleNotOffered, fiber.StatusUnprocessableEntity},
+		{"unexpected", fmt.Errorf("connection refused"), fiber.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := NewBookingController(&stubBookingService{userID: "user-1", err: tt.err})
+
+			app := fiber.New()
+			app.Post("/bookings/:id/bundles", func(ctx *fiber.Ctx) error {
+				ctx.Locals("user_id", "user-1")
+				ctx.Locals("role", models.RolePassenger)
+				return ctx.Next()
+			}, controller.AddBundle)
+
+			body := strings.NewReader(`{"booking_seat_id":"seat-1","bundle_id":"bundle-1"}`)
+			req := httptest.NewRequest("POST", "/bookings/booking-1/bundles", body)
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+
+			var payload map[string]interface{}
+			if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+				t.Fatal(err)
+			}
+			if payload["error"] != true {
+				t.Errorf("body = %v, want an error", payload)
+			}
+		})
+	}
+}
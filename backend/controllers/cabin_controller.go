@@ -0,0 +1,254 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/services"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// CabinController handles HTTP requests related to cabins and their seat rows
+type CabinController struct {
+	cabinService services.CabinService
+	seatService  services.SeatService
+}
+
+// NewCabinController creates a new CabinController
+func NewCabinController(cabinService services.CabinService, seatService services.SeatService) *CabinController {
+	return &CabinController{
+		cabinService: cabinService,
+		seatService:  seatService,
+	}
+}
+
+// cabinRequest is the body of the cabin create and update endpoints
+type cabinRequest struct {
+	AircraftID  string `json:"aircraft_id"`
+	SegmentID   string `json:"segment_id"`
+	Deck        string `json:"deck"`
+	FirstRow    int    `json:"first_row"`
+	LastRow     int    `json:"last_row"`
+	SeatColumns string `json:"seat_columns"`
+}
+
+// rowRequest is the body of the row create and update endpoints
+type rowRequest struct {
+	RowNumber int    `json:"row_number"`
+	SeatCodes string `json:"seat_codes"`
+}
+
+// facilityRequest is the body of the facility create endpoint
+type facilityRequest struct {
+	Type      string `json:"type"`
+	RowNumber int    `json:"row_number"`
+	Position  string `json:"position"`
+	Columns   string `json:"columns"`
+}
+
+// GetByID handles GET /api/admin/cabins/:id
+func (c *CabinController) GetByID(ctx *fiber.Ctx) error {
+	cabin, err := c.cabinService.GetWithSeats(ctx.Params("id"))
+	if err != nil {
+		return handleLayoutError(ctx, err, "Failed to get cabin")
+	}
+
+	return ctx.JSON(cabin)
+}
+
+// Create handles POST /api/admin/cabins
+func (c *CabinController) Create(ctx *fiber.Ctx) error {
+	var req cabinRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	cabin, err := c.cabinService.CreateCabin(req.toCabin(""))
+	if err != nil {
+		return handleLayoutError(ctx, err, "Failed to create cabin")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(cabin)
+}
+
+// Update handles PUT /api/admin/cabins/:id
+func (c *CabinController) Update(ctx *fiber.Ctx) error {
+	var req cabinRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	cabin, err := c.cabinService.UpdateCabin(req.toCabin(ctx.Params("id")))
+	if err != nil {
+		return handleLayoutError(ctx, err, "Failed to update cabin")
+	}
+
+	return ctx.JSON(cabin)
+}
+
+// Delete handles DELETE /api/admin/cabins/:id
+func (c *CabinController) Delete(ctx *fiber.Ctx) error {
+	if err := c.cabinService.DeleteCabin(ctx.Params("id")); err != nil {
+		return handleLayoutError(ctx, err, "Failed to delete cabin")
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+// GetRows handles GET /api/admin/cabins/:id/rows
+func (c *CabinController) GetRows(ctx *fiber.Ctx) error {
+	cabinID := ctx.Params("id")
+	if _, err := c.cabinService.GetByID(cabinID); err != nil {
+		return handleLayoutError(ctx, err, "Failed to get rows")
+	}
+
+	rows, err := c.cabinService.GetRows(cabinID)
+	if err != nil {
+		return handleLayoutError(ctx, err, "Failed to get rows")
+	}
+
+	return ctx.JSON(rows)
+}
+
+// CreateRow handles POST /api/admin/cabins/:id/rows
+func (c *CabinController) CreateRow(ctx *fiber.Ctx) error {
+	var req rowRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	row, err := c.cabinService.CreateRow(ctx.Params("id"), req.RowNumber, req.SeatCodes)
+	if err != nil {
+		return handleLayoutError(ctx, err, "Failed to create row")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(row)
+}
+
+// UpdateRow handles PUT /api/admin/rows/:id
+func (c *CabinController) UpdateRow(ctx *fiber.Ctx) error {
+	var req rowRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	row, err := c.cabinService.UpdateRow(ctx.Params("id"), req.RowNumber, req.SeatCodes)
+	if err != nil {
+		return handleLayoutError(ctx, err, "Failed to update row")
+	}
+
+	return ctx.JSON(row)
+}
+
+// DeleteRow handles DELETE /api/admin/rows/:id
+func (c *CabinController) DeleteRow(ctx *fiber.Ctx) error {
+	if err := c.cabinService.DeleteRow(ctx.Params("id")); err != nil {
+		return handleLayoutError(ctx, err, "Failed to delete row")
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+// GetRowSeats handles GET /api/admin/rows/:id/seats
+func (c *CabinController) GetRowSeats(ctx *fiber.Ctx) error {
+	seats, err := c.seatService.GetByRowID(ctx.Params("id"))
+	if err != nil {
+		return handleLayoutError(ctx, err, "Failed to get seats")
+	}
+
+	return ctx.JSON(seats)
+}
+
+// GetFacilities handles GET /api/admin/cabins/:id/facilities
+func (c *CabinController) GetFacilities(ctx *fiber.Ctx) error {
+	facilities, err := c.cabinService.GetFacilities(ctx.Params("id"))
+	if err != nil {
+		return handleLayoutError(ctx, err, "Failed to get facilities")
+	}
+
+	return ctx.JSON(facilities)
+}
+
+// AddFacility handles POST /api/admin/cabins/:id/facilities
+func (c *CabinController) AddFacility(ctx *fiber.Ctx) error {
+	var req facilityRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	facility, err := c.cabinService.AddFacility(ctx.Params("id"), req.Type, req.RowNumber, req.Position, req.Columns)
+	if err != nil {
+		return handleLayoutError(ctx, err, "Failed to add facility")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(facility)
+}
+
+// DeleteFacility handles DELETE /api/admin/facilities/:id
+func (c *CabinController) DeleteFacility(ctx *fiber.Ctx) error {
+	if err := c.cabinService.DeleteFacility(ctx.Params("id")); err != nil {
+		return handleLayoutError(ctx, err, "Failed to delete facility")
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+// toCabin converts the request into a cabin model
+func (r cabinRequest) toCabin(id string) *models.Cabin {
+	return &models.Cabin{
+		ID:          id,
+		AircraftID:  r.AircraftID,
+		SegmentID:   r.SegmentID,
+		Deck:        r.Deck,
+		FirstRow:    r.FirstRow,
+		LastRow:     r.LastRow,
+		SeatColumns: r.SeatColumns,
+	}
+}
+
+// handleLayoutError maps aircraft, cabin, row and seat service errors to HTTP responses
+func handleLayoutError(ctx *fiber.Ctx, err error, msg string) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrAircraftNotFound),
+		errors.Is(err, services.ErrCabinNotFound),
+		errors.Is(err, services.ErrRowNotFound),
+		errors.Is(err, services.ErrSeatNotFound),
+		errors.Is(err, services.ErrFacilityNotFound),
+		errors.Is(err, services.ErrFlightNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, services.ErrInvalidLayout):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, services.ErrInUse):
+		status = fiber.StatusConflict
+	}
+
+	if status == fiber.StatusInternalServerError {
+		zap.L().Error(msg, zap.Error(err), zap.String("path", ctx.Path()))
+		return ctx.Status(status).JSON(fiber.Map{
+			"error": true,
+			"msg":   msg,
+		})
+	}
+
+	return ctx.Status(status).JSON(fiber.Map{
+		"error": true,
+		"msg":   err.Error(),
+	})
+}
@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/evaizee/seat-arrangements/backend/middleware"
+	"github.com/evaizee/seat-arrangements/backend/services"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// CurrencyController handles HTTP requests related to exchange rates
+type CurrencyController struct {
+	currencyService services.CurrencyService
+}
+
+// NewCurrencyController creates a new CurrencyController
+func NewCurrencyController(currencyService services.CurrencyService) *CurrencyController {
+	return &CurrencyController{
+		currencyService: currencyService,
+	}
+}
+
+// exchangeRateRequest is the body of POST /api/revenue/exchange-rates. The
+// rate is a decimal string so it is stored exactly; effective_from defaults to now.
+type exchangeRateRequest struct {
+	BaseCurrency  string     `json:"base_currency"`
+	QuoteCurrency string     `json:"quote_currency"`
+	Rate          string     `json:"rate"`
+	EffectiveFrom *time.Time `json:"effective_from"`
+}
+
+// GetRates handles GET /api/revenue/exchange-rates
+func (c *CurrencyController) GetRates(ctx *fiber.Ctx) error {
+	rates, err := c.currencyService.GetRates()
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to get exchange rates")
+	}
+
+	return ctx.JSON(rates)
+}
+
+// CreateRate handles POST /api/revenue/exchange-rates
+func (c *CurrencyController) CreateRate(ctx *fiber.Ctx) error {
+	var req exchangeRateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	effectiveFrom := time.Now()
+	if req.EffectiveFrom != nil {
+		effectiveFrom = *req.EffectiveFrom
+	}
+
+	rate, err := c.currencyService.CreateRate(req.BaseCurrency, req.QuoteCurrency, req.Rate, effectiveFrom)
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to create exchange rate")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(rate)
+}
+
+// handleError maps service errors to HTTP responses, logging unexpected ones
+func (c *CurrencyController) handleError(ctx *fiber.Ctx, err error, msg string) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrInvalidCurrency),
+		errors.Is(err, services.ErrInvalidRate):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, services.ErrRateExists):
+		status = fiber.StatusConflict
+	}
+
+	if status == fiber.StatusInternalServerError {
+		zap.L().Error(msg, zap.Error(err), zap.String("user_id", middleware.CurrentUserID(ctx)))
+		return ctx.Status(status).JSON(fiber.Map{
+			"error": true,
+			"msg":   msg,
+		})
+	}
+
+	return ctx.Status(status).JSON(fiber.Map{
+		"error": true,
+		"msg":   err.Error(),
+	})
+}
@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/services"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// dateLayout is the format of date query parameters
+const dateLayout = "2006-01-02"
+
+// FlightController handles HTTP requests related to flights
+type FlightController struct {
+	flightService services.FlightService
+}
+
+// NewFlightController creates a new FlightController
+func NewFlightController(flightService services.FlightService) *FlightController {
+	return &FlightController{
+		flightService: flightService,
+	}
+}
+
+// Search handles GET /api/flights
+//
+// Query parameters: origin, destination, dateFrom, dateTo (YYYY-MM-DD, inclusive),
+// airline, flightNumber, page and pageSize.
+func (c *FlightController) Search(ctx *fiber.Ctx) error {
+	filter := models.FlightFilter{
+		Origin:       strings.TrimSpace(ctx.Query("origin")),
+		Destination:  strings.TrimSpace(ctx.Query("destination")),
+		AirlineCode:  strings.TrimSpace(ctx.Query("airline")),
+		FlightNumber: strings.TrimSpace(ctx.Query("flightNumber")),
+	}
+
+	if dateFrom := ctx.Query("dateFrom"); dateFrom != "" {
+		from, err := time.Parse(dateLayout, dateFrom)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": true,
+				"msg":   "dateFrom must be in YYYY-MM-DD format",
+			})
+		}
+		filter.DepartureFrom = &from
+	}
+
+	if dateTo := ctx.Query("dateTo"); dateTo != "" {
+		to, err := time.Parse(dateLayout, dateTo)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": true,
+				"msg":   "dateTo must be in YYYY-MM-DD format",
+			})
+		}
+		// Include the whole of the last day
+		to = to.AddDate(0, 0, 1)
+		filter.DepartureTo = &to
+	}
+
+	if filter.DepartureFrom != nil && filter.DepartureTo != nil && !filter.DepartureFrom.Before(*filter.DepartureTo) {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "dateFrom must not be after dateTo",
+		})
+	}
+
+	page := ctx.QueryInt("page", 1)
+	pageSize := ctx.QueryInt("pageSize", 0)
+	if page < 1 || pageSize < 0 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "page and pageSize must be positive",
+		})
+	}
+
+	result, err := c.flightService.Search(filter, page, pageSize)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Failed to search flights",
+		})
+	}
+
+	return ctx.JSON(result)
+}
+
+// GetByID handles GET /api/flights/:id
+func (c *FlightController) GetByID(ctx *fiber.Ctx) error {
+	flightID := ctx.Params("id")
+
+	flight, err := c.flightService.GetWithDetails(flightID)
+	if err != nil {
+		if errors.Is(err, services.ErrFlightNotFound) {
+			return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": true,
+				"msg":   "Flight not found",
+			})
+		}
+		zap.L().Error("Failed to get flight", zap.Error(err), zap.String("flight_id", flightID))
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Failed to get flight",
+		})
+	}
+
+	return ctx.JSON(flight)
+}
@@ -0,0 +1,355 @@
+package controllers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/evaizee/seat-arrangements/backend/middleware"
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/services"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// PassengerController handles HTTP requests related to passengers and their frequent flyer numbers
+type PassengerController struct {
+	passengerService     services.PassengerService
+	frequentFlyerService services.FrequentFlyerService
+}
+
+// NewPassengerController creates a new PassengerController
+func NewPassengerController(passengerService services.PassengerService, frequentFlyerService services.FrequentFlyerService) *PassengerController {
+	return &PassengerController{
+		passengerService:     passengerService,
+		frequentFlyerService: frequentFlyerService,
+	}
+}
+
+// passengerRequest is the body of the passenger create and update endpoints.
+// Dates are formatted as YYYY-MM-DD and blank optional fields are cleared.
+type passengerRequest struct {
+	SegmentID           string `json:"segment_id"`
+	PassengerIndex      int    `json:"passenger_index"`
+	PassengerNameNumber string `json:"passenger_name_number"`
+	FirstName           string `json:"first_name"`
+	LastName            string `json:"last_name"`
+	DateOfBirth         string `json:"date_of_birth"`
+	Gender              string `json:"gender"`
+	Type                string `json:"type"`
+	Email               string `json:"email"`
+	Phone               string `json:"phone"`
+	Street              string `json:"street"`
+	City                string `json:"city"`
+	Country             string `json:"country"`
+	Postcode            string `json:"postcode"`
+	AddressType         string `json:"address_type"`
+	DocumentType        string `json:"document_type"`
+	DocumentNumber      string `json:"document_number"`
+	DocumentExpiry      string `json:"document_expiry"`
+	IssuingCountry      string `json:"issuing_country"`
+	CountryOfBirth      string `json:"country_of_birth"`
+	Nationality         string `json:"nationality"`
+}
+
+// frequentFlyerRequest is the body of POST /api/passengers/:id/frequent-flyers
+type frequentFlyerRequest struct {
+	Airline   string `json:"airline"`
+	Number    string `json:"number"`
+	TierLevel string `json:"tier_level"`
+}
+
+// Create handles POST /api/passengers. Passengers created by a passenger account
+// are managed by it; those created by agents are not linked to any account.
+func (c *PassengerController) Create(ctx *fiber.Ctx) error {
+	var req passengerRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	passenger := &models.Passenger{}
+	if err := req.apply(passenger); err != nil {
+		return c.handleError(ctx, services.NewValidationError(services.ErrInvalidPassenger, err), "Failed to create passenger")
+	}
+
+	if !middleware.IsStaff(ctx) {
+		userID := middleware.CurrentUserID(ctx)
+		passenger.UserID = &userID
+	}
+
+	passenger, err := c.passengerService.CreatePassenger(passenger)
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to create passenger")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(passenger)
+}
+
+// GetBySegment handles GET /api/passengers?segmentId=
+func (c *PassengerController) GetBySegment(ctx *fiber.Ctx) error {
+	segmentID := ctx.Query("segmentId")
+	if segmentID == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Segment ID is required",
+		})
+	}
+
+	passengers, err := c.passengerService.GetBySegmentID(segmentID)
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to get passengers")
+	}
+
+	return ctx.JSON(passengers)
+}
+
+// GetByID handles GET /api/passengers/:id
+func (c *PassengerController) GetByID(ctx *fiber.Ctx) error {
+	passenger, err := c.getPassenger(ctx, ctx.Params("id"))
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to get passenger")
+	}
+
+	return ctx.JSON(passenger)
+}
+
+// Update handles PUT /api/passengers/:id. Fields missing from the body keep their current values.
+func (c *PassengerController) Update(ctx *fiber.Ctx) error {
+	passenger, err := c.getPassenger(ctx, ctx.Params("id"))
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to update passenger")
+	}
+
+	req := newPassengerRequest(passenger)
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	if err := req.apply(passenger); err != nil {
+		return c.handleError(ctx, services.NewValidationError(services.ErrInvalidPassenger, err), "Failed to update passenger")
+	}
+
+	if err := c.passengerService.UpdatePassenger(passenger); err != nil {
+		return c.handleError(ctx, err, "Failed to update passenger")
+	}
+
+	return ctx.JSON(passenger)
+}
+
+// GetAPIS handles GET /api/passengers/:id/apis, listing the advance passenger
+// information the passenger's route requires that is missing or invalid
+func (c *PassengerController) GetAPIS(ctx *fiber.Ctx) error {
+	if _, err := c.getPassenger(ctx, ctx.Params("id")); err != nil {
+		return c.handleError(ctx, err, "Failed to check passenger data")
+	}
+
+	check, err := c.passengerService.CheckAPIS(ctx.Params("id"))
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to check passenger data")
+	}
+
+	return ctx.JSON(check)
+}
+
+// GetFrequentFlyers handles GET /api/passengers/:id/frequent-flyers
+func (c *PassengerController) GetFrequentFlyers(ctx *fiber.Ctx) error {
+	details, err := c.passengerService.GetWithFrequentFlyers(ctx.Params("id"))
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to get frequent flyers")
+	}
+
+	if !canAccessPassenger(ctx, &details.Passenger) {
+		return c.handleError(ctx, services.ErrPassengerNotFound, "Failed to get frequent flyers")
+	}
+
+	return ctx.JSON(details.FrequentFlyers)
+}
+
+// AddFrequentFlyer handles POST /api/passengers/:id/frequent-flyers
+func (c *PassengerController) AddFrequentFlyer(ctx *fiber.Ctx) error {
+	var req frequentFlyerRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	passenger, err := c.getPassenger(ctx, ctx.Params("id"))
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to add frequent flyer")
+	}
+
+	frequentFlyer := models.NewFrequentFlyer(passenger.ID, req.Airline, req.Number)
+	if req.TierLevel != "" {
+		frequentFlyer.TierLevel = &req.TierLevel
+	}
+
+	frequentFlyer, err = c.frequentFlyerService.CreateFrequentFlyer(frequentFlyer)
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to add frequent flyer")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(frequentFlyer)
+}
+
+// getPassenger retrieves a passenger the authenticated user may access.
+// Passengers of other accounts are reported as not found.
+func (c *PassengerController) getPassenger(ctx *fiber.Ctx, id string) (*models.Passenger, error) {
+	passenger, err := c.passengerService.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canAccessPassenger(ctx, passenger) {
+		return nil, services.ErrPassengerNotFound
+	}
+
+	return passenger, nil
+}
+
+// canAccessPassenger reports whether the authenticated user manages the passenger or is staff
+func canAccessPassenger(ctx *fiber.Ctx, passenger *models.Passenger) bool {
+	ownerID := ""
+	if passenger.UserID != nil {
+		ownerID = *passenger.UserID
+	}
+	return middleware.CanAccessUser(ctx, ownerID)
+}
+
+// newPassengerRequest returns the request that would leave a passenger unchanged
+func newPassengerRequest(passenger *models.Passenger) passengerRequest {
+	return passengerRequest{
+		SegmentID:           passenger.SegmentID,
+		PassengerIndex:      passenger.PassengerIndex,
+		PassengerNameNumber: passenger.PassengerNameNumber,
+		FirstName:           passenger.FirstName,
+		LastName:            passenger.LastName,
+		DateOfBirth:         formatDate(passenger.DateOfBirth),
+		Gender:              valueOf(passenger.Gender),
+		Type:                valueOf(passenger.Type),
+		Email:               valueOf(passenger.Email),
+		Phone:               valueOf(passenger.Phone),
+		Street:              valueOf(passenger.Street),
+		City:                valueOf(passenger.City),
+		Country:             valueOf(passenger.Country),
+		Postcode:            valueOf(passenger.Postcode),
+		AddressType:         valueOf(passenger.AddressType),
+		DocumentType:        valueOf(passenger.DocumentType),
+		DocumentNumber:      valueOf(passenger.DocumentNumber),
+		DocumentExpiry:      formatDate(passenger.DocumentExpiry),
+		IssuingCountry:      valueOf(passenger.IssuingCountry),
+		CountryOfBirth:      valueOf(passenger.CountryOfBirth),
+		Nationality:         valueOf(passenger.Nationality),
+	}
+}
+
+// apply copies the request onto a passenger, returning ValidationErrors for malformed dates
+func (r passengerRequest) apply(passenger *models.Passenger) error {
+	var errs models.ValidationErrors
+
+	passenger.SegmentID = r.SegmentID
+	passenger.PassengerIndex = r.PassengerIndex
+	passenger.PassengerNameNumber = r.PassengerNameNumber
+	passenger.FirstName = r.FirstName
+	passenger.LastName = r.LastName
+	passenger.DateOfBirth = parseDate(&errs, "date_of_birth", r.DateOfBirth)
+	passenger.Gender = optional(r.Gender)
+	passenger.Type = optional(r.Type)
+	passenger.Email = optional(r.Email)
+	passenger.Phone = optional(r.Phone)
+	passenger.Street = optional(r.Street)
+	passenger.City = optional(r.City)
+	passenger.Country = optional(r.Country)
+	passenger.Postcode = optional(r.Postcode)
+	passenger.AddressType = optional(r.AddressType)
+	passenger.DocumentType = optional(r.DocumentType)
+	passenger.DocumentNumber = optional(r.DocumentNumber)
+	passenger.DocumentExpiry = parseDate(&errs, "document_expiry", r.DocumentExpiry)
+	passenger.IssuingCountry = optional(r.IssuingCountry)
+	passenger.CountryOfBirth = optional(r.CountryOfBirth)
+	passenger.Nationality = optional(r.Nationality)
+
+	return errs.Err()
+}
+
+// parseDate parses an optional YYYY-MM-DD date, recording a field error if it is malformed
+func parseDate(errs *models.ValidationErrors, field, value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		errs.Add(field, "must be a date formatted as YYYY-MM-DD")
+		return nil
+	}
+	return &date
+}
+
+// formatDate formats an optional date as YYYY-MM-DD
+func formatDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format(dateLayout)
+}
+
+// optional returns nil for a blank value
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// valueOf returns the value of an optional string, or "" when it is unset
+func valueOf(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// handleError maps passenger service errors to HTTP responses; validation
+// failures list the error of each invalid field
+func (c *PassengerController) handleError(ctx *fiber.Ctx, err error, msg string) error {
+	var validation *services.ValidationError
+	if errors.As(err, &validation) {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":  true,
+			"msg":    validation.Err.Error(),
+			"errors": validation.Fields,
+		})
+	}
+
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrPassengerNotFound), errors.Is(err, services.ErrFlightNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, services.ErrInvalidPassenger):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, services.ErrFlyerNumberExists):
+		status = fiber.StatusConflict
+	}
+
+	if status == fiber.StatusInternalServerError {
+		zap.L().Error(msg, zap.Error(err),
+			zap.String("user_id", middleware.CurrentUserID(ctx)),
+			zap.String("passenger_id", ctx.Params("id")))
+		return ctx.Status(status).JSON(fiber.Map{
+			"error": true,
+			"msg":   msg,
+		})
+	}
+
+	return ctx.Status(status).JSON(fiber.Map{
+		"error": true,
+		"msg":   err.Error(),
+	})
+}
@@ -0,0 +1,180 @@
+package controllers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/evaizee/seat-arrangements/backend/middleware"
+	"github.com/evaizee/seat-arrangements/backend/services"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// PNRController handles HTTP requests related to passenger name records
+type PNRController struct {
+	pnrService       services.PNRService
+	passengerService services.PassengerService
+	bookingService   services.BookingService
+}
+
+// NewPNRController creates a new PNRController
+func NewPNRController(
+	pnrService services.PNRService,
+	passengerService services.PassengerService,
+	bookingService services.BookingService,
+) *PNRController {
+	return &PNRController{
+		pnrService:       pnrService,
+		passengerService: passengerService,
+		bookingService:   bookingService,
+	}
+}
+
+// createPNRRequest is the body of POST /api/pnrs
+type createPNRRequest struct {
+	ItineraryID  string `json:"itinerary_id"`
+	PassengerIDs []int  `json:"passenger_ids"`
+}
+
+// linkBookingRequest is the body of POST /api/bookings/:id/pnr. The record
+// locator and a passenger's last name identify the reservation, as they do
+// when it is looked up.
+type linkBookingRequest struct {
+	RecordLocator string `json:"record_locator"`
+	LastName      string `json:"last_name"`
+	Assignments   []struct {
+		BookingSeatID string `json:"booking_seat_id"`
+		PassengerID   int    `json:"passenger_id"`
+	} `json:"assignments"`
+}
+
+// Create handles POST /api/pnrs. Reservations created by a passenger account
+// belong to it and may only hold the passengers it manages.
+func (c *PNRController) Create(ctx *fiber.Ctx) error {
+	var req createPNRRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	if req.ItineraryID == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Itinerary ID is required",
+		})
+	}
+
+	for _, passengerID := range req.PassengerIDs {
+		passenger, err := c.passengerService.GetByID(strconv.Itoa(passengerID))
+		if err != nil {
+			return c.handleError(ctx, err, "Failed to create reservation")
+		}
+
+		if !canAccessPassenger(ctx, passenger) {
+			return c.handleError(ctx, services.ErrPassengerNotFound, "Failed to create reservation")
+		}
+	}
+
+	userID := ""
+	if !middleware.IsStaff(ctx) {
+		userID = middleware.CurrentUserID(ctx)
+	}
+
+	pnr, err := c.pnrService.CreatePNR(userID, req.ItineraryID, req.PassengerIDs)
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to create reservation")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(pnr)
+}
+
+// GetByRecordLocator handles GET /api/pnrs/:locator?lastName=, the manage
+// booking lookup. It needs no account, only the record locator and the last
+// name of a passenger on the reservation.
+func (c *PNRController) GetByRecordLocator(ctx *fiber.Ctx) error {
+	lastName := ctx.Query("lastName")
+	if lastName == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Last name is required",
+		})
+	}
+
+	pnr, err := c.pnrService.GetByRecordLocator(ctx.Params("locator"), lastName)
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to get reservation")
+	}
+
+	return ctx.JSON(pnr)
+}
+
+// LinkBooking handles POST /api/bookings/:id/pnr
+func (c *PNRController) LinkBooking(ctx *fiber.Ctx) error {
+	var req linkBookingRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	assignments := make(map[string]int, len(req.Assignments))
+	for _, assignment := range req.Assignments {
+		if _, ok := assignments[assignment.BookingSeatID]; ok {
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": true,
+				"msg":   "Each booking seat may be assigned only once",
+			})
+		}
+		assignments[assignment.BookingSeatID] = assignment.PassengerID
+	}
+
+	booking, err := c.bookingService.GetByID(ctx.Params("id"))
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to link booking")
+	}
+
+	if !middleware.CanAccessUser(ctx, booking.Booking.UserID) {
+		return c.handleError(ctx, services.ErrBookingNotFound, "Failed to link booking")
+	}
+
+	pnr, err := c.pnrService.LinkBooking(booking.Booking.ID, req.RecordLocator, req.LastName, assignments)
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to link booking")
+	}
+
+	return ctx.JSON(pnr)
+}
+
+// handleError maps PNR service errors to HTTP responses
+func (c *PNRController) handleError(ctx *fiber.Ctx, err error, msg string) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrPNRNotFound),
+		errors.Is(err, services.ErrItineraryNotFound),
+		errors.Is(err, services.ErrPassengerNotFound),
+		errors.Is(err, services.ErrBookingNotFound),
+		errors.Is(err, services.ErrBookingSeatMissing):
+		status = fiber.StatusNotFound
+	case errors.Is(err, services.ErrInvalidPNR):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, services.ErrPassengerInPNR),
+		errors.Is(err, services.ErrConcurrentUpdate):
+		status = fiber.StatusConflict
+	}
+
+	if status == fiber.StatusInternalServerError {
+		zap.L().Error(msg, zap.Error(err), zap.String("user_id", middleware.CurrentUserID(ctx)))
+		return ctx.Status(status).JSON(fiber.Map{
+			"error": true,
+			"msg":   msg,
+		})
+	}
+
+	return ctx.Status(status).JSON(fiber.Map{
+		"error": true,
+		"msg":   err.Error(),
+	})
+}
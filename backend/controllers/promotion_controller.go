@@ -0,0 +1,160 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/evaizee/seat-arrangements/backend/middleware"
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/services"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// PromotionController handles HTTP requests related to promotional codes
+type PromotionController struct {
+	promotionService services.PromotionService
+}
+
+// NewPromotionController creates a new PromotionController
+func NewPromotionController(promotionService services.PromotionService) *PromotionController {
+	return &PromotionController{
+		promotionService: promotionService,
+	}
+}
+
+// promotionRequest is the body of POST /api/revenue/promotions. Percent
+// discounts set percent, amount discounts set amount and currency;
+// valid_from defaults to now.
+type promotionRequest struct {
+	Code            string      `json:"code"`
+	Description     string      `json:"description"`
+	DiscountType    string      `json:"discount_type"`
+	Percent         json.Number `json:"percent"`
+	Amount          json.Number `json:"amount"`
+	Currency        string      `json:"currency"`
+	Characteristics []string    `json:"characteristics"`
+	Routes          []string    `json:"routes"`
+	ValidFrom       *time.Time  `json:"valid_from"`
+	ValidTo         *time.Time  `json:"valid_to"`
+	UsageLimit      *int        `json:"usage_limit"`
+}
+
+// promotionStatusRequest is the body of PATCH /api/revenue/promotions/:code
+type promotionStatusRequest struct {
+	Active *bool `json:"active"`
+}
+
+// GetAll handles GET /api/revenue/promotions
+func (c *PromotionController) GetAll(ctx *fiber.Ctx) error {
+	promotions, err := c.promotionService.GetAll()
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to get promotions")
+	}
+
+	return ctx.JSON(promotions)
+}
+
+// Create handles POST /api/revenue/promotions
+func (c *PromotionController) Create(ctx *fiber.Ctx) error {
+	var req promotionRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	promotion, err := req.toPromotion()
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	promotion, err = c.promotionService.CreatePromotion(promotion)
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to create promotion")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(promotion)
+}
+
+// SetActive handles PATCH /api/revenue/promotions/:code
+func (c *PromotionController) SetActive(ctx *fiber.Ctx) error {
+	var req promotionStatusRequest
+	if err := ctx.BodyParser(&req); err != nil || req.Active == nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "active is required",
+		})
+	}
+
+	promotion, err := c.promotionService.SetActive(ctx.Params("code"), *req.Active)
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to update promotion")
+	}
+
+	return ctx.JSON(promotion)
+}
+
+// toPromotion converts the request into a new promotion
+func (r promotionRequest) toPromotion() (*models.Promotion, error) {
+	promotion := models.NewPromotion()
+	promotion.Code = r.Code
+	promotion.Description = r.Description
+	promotion.DiscountType = r.DiscountType
+	promotion.Percent = r.Percent.String()
+	promotion.ValidTo = r.ValidTo
+	promotion.UsageLimit = r.UsageLimit
+
+	if r.Characteristics != nil {
+		promotion.Characteristics = r.Characteristics
+	}
+	if r.Routes != nil {
+		promotion.Routes = r.Routes
+	}
+
+	promotion.ValidFrom = promotion.CreatedAt
+	if r.ValidFrom != nil {
+		promotion.ValidFrom = *r.ValidFrom
+	}
+
+	if r.Amount != "" {
+		amount, err := models.ParseMoney(r.Amount.String(), r.Currency)
+		if err != nil {
+			return nil, err
+		}
+		promotion.Amount = &amount
+	}
+
+	return promotion, nil
+}
+
+// handleError maps service errors to HTTP responses, logging unexpected ones
+func (c *PromotionController) handleError(ctx *fiber.Ctx, err error, msg string) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrInvalidPromotion):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, services.ErrPromotionNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, services.ErrPromotionExists):
+		status = fiber.StatusConflict
+	}
+
+	if status == fiber.StatusInternalServerError {
+		zap.L().Error(msg, zap.Error(err), zap.String("user_id", middleware.CurrentUserID(ctx)))
+		return ctx.Status(status).JSON(fiber.Map{
+			"error": true,
+			"msg":   msg,
+		})
+	}
+
+	return ctx.Status(status).JSON(fiber.Map{
+		"error": true,
+		"msg":   err.Error(),
+	})
+}
@@ -1,6 +1,10 @@
 package controllers
 
 import (
+	"encoding/json"
+	"errors"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
 	"github.com/evaizee/seat-arrangements/backend/services"
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
@@ -23,6 +27,9 @@ func (c *SeatController) GetSeatMap(ctx *fiber.Ctx) error {
 	// Get query parameters
 	flightID := ctx.Query("flightId")
 	passengerID := ctx.Query("passengerId")
+	deck := ctx.Query("deck")
+	currency := ctx.Query("currency")
+	promoCode := ctx.Query("promoCode")
 
 	if flightID == "" {
 		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -32,8 +39,41 @@ func (c *SeatController) GetSeatMap(ctx *fiber.Ctx) error {
 	}
 
 	// Get the seat map
-	seatMap, err := c.seatService.GetSeatMap(flightID, passengerID)
+	seatMap, err := c.seatService.GetSeatMap(flightID, passengerID, deck, currency, promoCode)
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidDeck) {
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": true,
+				"msg":   "deck must be one of MAIN, UPPER or LOWER",
+			})
+		}
+		if errors.Is(err, services.ErrInvalidCurrency) {
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": true,
+				"msg":   "currency must be a 3-letter ISO 4217 code",
+			})
+		}
+		if errors.Is(err, services.ErrPromotionNotFound) {
+			return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": true,
+				"msg":   err.Error(),
+			})
+		}
+		if errors.Is(err, services.ErrRateNotFound) ||
+			errors.Is(err, services.ErrPromotionNotActive) ||
+			errors.Is(err, services.ErrPromotionNotUsable) ||
+			errors.Is(err, services.ErrPromotionExhausted) {
+			return ctx.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error": true,
+				"msg":   err.Error(),
+			})
+		}
+		if errors.Is(err, services.ErrFlightNotFound) {
+			return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": true,
+				"msg":   "Flight not found",
+			})
+		}
 		zap.L().Error("Failed to get seat map", zap.Error(err),
 			zap.String("flight_id", flightID),
 			zap.String("passenger_id", passengerID))
@@ -46,3 +86,184 @@ func (c *SeatController) GetSeatMap(ctx *fiber.Ctx) error {
 	// Return the seat map
 	return ctx.JSON(seatMap)
 }
+
+// availabilityRequest is the body of the seat and row availability endpoints
+type availabilityRequest struct {
+	Available bool `json:"available"`
+}
+
+// UpdateAvailability handles PUT /api/agent/seats/:id/availability
+func (c *SeatController) UpdateAvailability(ctx *fiber.Ctx) error {
+	seatID := ctx.Params("id")
+
+	var req availabilityRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	if err := c.seatService.UpdateAvailability(seatID, req.Available); err != nil {
+		return handleLayoutError(ctx, err, "Failed to update seat availability")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"seat_id":   seatID,
+		"available": req.Available,
+	})
+}
+
+// UpdateRowAvailability handles PUT /api/agent/rows/:id/availability
+func (c *SeatController) UpdateRowAvailability(ctx *fiber.Ctx) error {
+	rowID := ctx.Params("id")
+
+	var req availabilityRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	if err := c.seatService.UpdateRowAvailability(rowID, req.Available); err != nil {
+		return handleLayoutError(ctx, err, "Failed to update row availability")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"row_id":    rowID,
+		"available": req.Available,
+	})
+}
+
+// seatRequest is the body of the seat create and update endpoints. Fields
+// missing from the body keep the defaults of a new seat or the seat's current values.
+type seatRequest struct {
+	RowID               string `json:"row_id"`
+	StorefrontSlotCode  string `json:"storefront_slot_code"`
+	Code                string `json:"code"`
+	Available           bool   `json:"available"`
+	Entitled            bool   `json:"entitled"`
+	FeeWaived           bool   `json:"fee_waived"`
+	FreeOfCharge        bool   `json:"free_of_charge"`
+	EntitledRuleID      string `json:"entitled_rule_id"`
+	FeeWaivedRuleID     string `json:"fee_waived_rule_id"`
+	RefundIndicator     string `json:"refund_indicator"`
+	SeatCharacteristics string `json:"seat_characteristics"`
+	RawCharacteristics  string `json:"raw_characteristics"`
+}
+
+// priceRequest is the body of the seat price endpoint
+type priceRequest struct {
+	Type     string      `json:"type"`
+	Amount   json.Number `json:"amount"`
+	Currency string      `json:"currency"`
+}
+
+// CreateSeat handles POST /api/admin/rows/:id/seats
+func (c *SeatController) CreateSeat(ctx *fiber.Ctx) error {
+	seat := models.NewSeat(ctx.Params("id"), "", "")
+	req := newSeatRequest(seat)
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	req.RowID = ctx.Params("id")
+	req.apply(seat)
+
+	seat, err := c.seatService.CreateSeat(seat)
+	if err != nil {
+		return handleLayoutError(ctx, err, "Failed to create seat")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(seat)
+}
+
+// UpdateSeat handles PUT /api/admin/seats/:id. Fields missing from the body keep their current values.
+func (c *SeatController) UpdateSeat(ctx *fiber.Ctx) error {
+	existing, err := c.seatService.GetByID(ctx.Params("id"))
+	if err != nil {
+		return handleLayoutError(ctx, err, "Failed to update seat")
+	}
+
+	req := newSeatRequest(existing.Seat)
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	req.apply(existing.Seat)
+
+	seat, err := c.seatService.UpdateSeat(existing.Seat)
+	if err != nil {
+		return handleLayoutError(ctx, err, "Failed to update seat")
+	}
+
+	return ctx.JSON(seat)
+}
+
+// DeleteSeat handles DELETE /api/admin/seats/:id
+func (c *SeatController) DeleteSeat(ctx *fiber.Ctx) error {
+	if err := c.seatService.DeleteSeat(ctx.Params("id")); err != nil {
+		return handleLayoutError(ctx, err, "Failed to delete seat")
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+// SetPrice handles PUT /api/admin/seats/:id/price
+func (c *SeatController) SetPrice(ctx *fiber.Ctx) error {
+	var req priceRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	price, err := c.seatService.SetPrice(ctx.Params("id"), req.Type, req.Amount.String(), req.Currency)
+	if err != nil {
+		return handleLayoutError(ctx, err, "Failed to set seat price")
+	}
+
+	return ctx.JSON(price)
+}
+
+// newSeatRequest returns the request that would leave a seat unchanged
+func newSeatRequest(seat *models.Seat) seatRequest {
+	return seatRequest{
+		RowID:               seat.RowID,
+		StorefrontSlotCode:  seat.StorefrontSlotCode,
+		Code:                seat.Code,
+		Available:           seat.Available,
+		Entitled:            seat.Entitled,
+		FeeWaived:           seat.FeeWaived,
+		FreeOfCharge:        seat.FreeOfCharge,
+		EntitledRuleID:      seat.EntitledRuleID,
+		FeeWaivedRuleID:     seat.FeeWaivedRuleID,
+		RefundIndicator:     seat.RefundIndicator,
+		SeatCharacteristics: seat.SeatCharacteristics,
+		RawCharacteristics:  seat.RawCharacteristics,
+	}
+}
+
+// apply copies the request onto a seat
+func (r seatRequest) apply(seat *models.Seat) {
+	seat.RowID = r.RowID
+	seat.StorefrontSlotCode = r.StorefrontSlotCode
+	seat.Code = r.Code
+	seat.Available = r.Available
+	seat.Entitled = r.Entitled
+	seat.FeeWaived = r.FeeWaived
+	seat.FreeOfCharge = r.FreeOfCharge
+	seat.EntitledRuleID = r.EntitledRuleID
+	seat.FeeWaivedRuleID = r.FeeWaivedRuleID
+	seat.RefundIndicator = r.RefundIndicator
+	seat.SeatCharacteristics = r.SeatCharacteristics
+	seat.RawCharacteristics = r.RawCharacteristics
+}
@@ -0,0 +1,186 @@
+package controllers
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/evaizee/seat-arrangements/backend/middleware"
+	"github.com/evaizee/seat-arrangements/backend/services"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// UserController handles HTTP requests related to the signed-in user's profile
+type UserController struct {
+	userService services.UserService
+}
+
+// NewUserController creates a new UserController
+func NewUserController(userService services.UserService) *UserController {
+	return &UserController{
+		userService: userService,
+	}
+}
+
+// updateProfileRequest is the body of PUT /api/users/me
+type updateProfileRequest struct {
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+// changePasswordRequest is the body of PUT /api/users/me/password
+type changePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+// deleteAccountRequest is the body of DELETE /api/users/me
+type deleteAccountRequest struct {
+	Password string `json:"password"`
+}
+
+// linkPassengerRequest is the body of POST /api/users/me/passengers
+type linkPassengerRequest struct {
+	PassengerID string `json:"passenger_id"`
+	LastName    string `json:"last_name"`
+}
+
+// GetMe handles GET /api/users/me
+func (c *UserController) GetMe(ctx *fiber.Ctx) error {
+	userID := middleware.CurrentUserID(ctx)
+
+	profile, err := c.userService.GetProfile(userID)
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to get profile")
+	}
+
+	return ctx.JSON(profile)
+}
+
+// UpdateMe handles PUT /api/users/me
+func (c *UserController) UpdateMe(ctx *fiber.Ctx) error {
+	userID := middleware.CurrentUserID(ctx)
+
+	var req updateProfileRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Invalid request body",
+		})
+	}
+
+	if strings.TrimSpace(req.FirstName) == "" || strings.TrimSpace(req.LastName) == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "First name and last name are required",
+		})
+	}
+
+	user, err := c.userService.UpdateName(userID, req.FirstName, req.LastName)
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to update profile")
+	}
+
+	return ctx.JSON(user)
+}
+
+// ChangePassword handles PUT /api/users/me/password
+func (c *UserController) ChangePassword(ctx *fiber.Ctx) error {
+	userID := middleware.CurrentUserID(ctx)
+
+	var req changePasswordRequest
+	if err := ctx.BodyParser(&req); err != nil || req.CurrentPassword == "" || req.NewPassword == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Current password and new password are required",
+		})
+	}
+
+	if err := c.userService.ChangePassword(userID, req.CurrentPassword, req.NewPassword); err != nil {
+		return c.handleError(ctx, err, "Failed to change password")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"msg": "Password changed",
+	})
+}
+
+// DeleteMe handles DELETE /api/users/me
+func (c *UserController) DeleteMe(ctx *fiber.Ctx) error {
+	userID := middleware.CurrentUserID(ctx)
+
+	var req deleteAccountRequest
+	if err := ctx.BodyParser(&req); err != nil || req.Password == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Password is required",
+		})
+	}
+
+	if err := c.userService.DeleteAccount(userID, req.Password); err != nil {
+		return c.handleError(ctx, err, "Failed to delete account")
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+// LinkPassenger handles POST /api/users/me/passengers
+func (c *UserController) LinkPassenger(ctx *fiber.Ctx) error {
+	userID := middleware.CurrentUserID(ctx)
+
+	var req linkPassengerRequest
+	if err := ctx.BodyParser(&req); err != nil || req.PassengerID == "" || req.LastName == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Passenger ID and last name are required",
+		})
+	}
+
+	passenger, err := c.userService.LinkPassenger(userID, req.PassengerID, req.LastName)
+	if err != nil {
+		return c.handleError(ctx, err, "Failed to link passenger")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(passenger)
+}
+
+// UnlinkPassenger handles DELETE /api/users/me/passengers/:id
+func (c *UserController) UnlinkPassenger(ctx *fiber.Ctx) error {
+	userID := middleware.CurrentUserID(ctx)
+
+	if err := c.userService.UnlinkPassenger(userID, ctx.Params("id")); err != nil {
+		return c.handleError(ctx, err, "Failed to unlink passenger")
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+// handleError maps user service errors to HTTP responses
+func (c *UserController) handleError(ctx *fiber.Ctx, err error, msg string) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrUserNotFound), errors.Is(err, services.ErrPassengerNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, services.ErrIncorrectPassword):
+		status = fiber.StatusUnauthorized
+	case errors.Is(err, services.ErrWeakPassword), errors.Is(err, services.ErrNameMismatch):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, services.ErrEmailNotVerified), errors.Is(err, services.ErrContactMismatch):
+		status = fiber.StatusForbidden
+	case errors.Is(err, services.ErrPassengerLinked):
+		status = fiber.StatusConflict
+	}
+
+	if status == fiber.StatusInternalServerError {
+		zap.L().Error(msg, zap.Error(err), zap.String("user_id", middleware.CurrentUserID(ctx)))
+		return ctx.Status(status).JSON(fiber.Map{
+			"error": true,
+			"msg":   msg,
+		})
+	}
+
+	return ctx.Status(status).JSON(fiber.Map{
+		"error": true,
+		"msg":   err.Error(),
+	})
+}
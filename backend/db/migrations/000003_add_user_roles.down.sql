@@ -0,0 +1,5 @@
+-- Drop index
+DROP INDEX IF EXISTS idx_users_role;
+
+-- Drop role column
+ALTER TABLE users DROP COLUMN IF EXISTS role;
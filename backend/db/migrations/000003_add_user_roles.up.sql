@@ -0,0 +1,9 @@
+-- Add role column to users
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(50) NOT NULL DEFAULT 'passenger';
+
+-- Create index on role
+CREATE INDEX IF NOT EXISTS idx_users_role ON users(role);
+
+-- Give the mock users distinct roles
+UPDATE users SET role = 'admin' WHERE id = '11111111-1111-1111-1111-111111111111';
+UPDATE users SET role = 'check_in_agent' WHERE id = '22222222-2222-2222-2222-222222222222';
@@ -0,0 +1,8 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_user_tokens_user_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS user_tokens;
+
+-- Drop email verification column
+ALTER TABLE users DROP COLUMN IF EXISTS email_verified_at;
@@ -0,0 +1,10 @@
+-- Restore the original bookings foreign key
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_user_id_fkey;
+ALTER TABLE bookings ADD CONSTRAINT bookings_user_id_fkey
+    FOREIGN KEY (user_id) REFERENCES users(id);
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_passengers_user_id;
+
+-- Drop user link
+ALTER TABLE passengers DROP COLUMN IF EXISTS user_id;
@@ -0,0 +1,14 @@
+-- Link passengers to the user account that manages them
+ALTER TABLE passengers ADD COLUMN IF NOT EXISTS user_id UUID REFERENCES users(id) ON DELETE SET NULL;
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_passengers_user_id ON passengers(user_id);
+
+-- Keep bookings when their user deletes the account
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_user_id_fkey;
+ALTER TABLE bookings ADD CONSTRAINT bookings_user_id_fkey
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL;
+
+-- Link the mock passenger to the matching mock user
+UPDATE passengers SET user_id = '33333333-3333-3333-3333-333333333333'
+WHERE first_name = 'Rutwik' AND last_name = 'Sabre';
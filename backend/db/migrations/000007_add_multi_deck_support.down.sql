@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_cabins_segment_deck;
+
+-- Drop facility markers
+ALTER TABLE cabins DROP COLUMN IF EXISTS front_facilities;
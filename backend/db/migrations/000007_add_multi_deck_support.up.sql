@@ -0,0 +1,8 @@
+-- Facility markers (stairs, galleys, lavatories) shown in front of a cabin
+ALTER TABLE cabins ADD COLUMN IF NOT EXISTS front_facilities VARCHAR(255) NOT NULL DEFAULT '';
+
+-- Normalize deck names
+UPDATE cabins SET deck = UPPER(TRIM(deck));
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_cabins_segment_deck ON cabins(segment_id, deck, first_row);
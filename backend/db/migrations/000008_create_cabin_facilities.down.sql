@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_cabin_facilities_cabin_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS cabin_facilities;
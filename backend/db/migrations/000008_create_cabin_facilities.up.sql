@@ -0,0 +1,22 @@
+-- Create cabin_facilities table
+CREATE TABLE IF NOT EXISTS cabin_facilities (
+    id UUID PRIMARY KEY,
+    cabin_id UUID NOT NULL REFERENCES cabins(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    row_number INTEGER NOT NULL,
+    position VARCHAR(10) NOT NULL DEFAULT 'FRONT',
+    columns VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_cabin_facilities_cabin_id ON cabin_facilities(cabin_id);
+
+-- Insert mock facilities: galley and lavatory ahead of row 4, which is an exit row over the wing
+INSERT INTO cabin_facilities (id, cabin_id, type, row_number, position, columns, created_at)
+VALUES
+  ('f1111111-1111-1111-1111-111111111111', '77777777-7777-7777-7777-777777777777', 'GALLEY', 4, 'FRONT', 'A,B,C', NOW()),
+  ('f2222222-2222-2222-2222-222222222222', '77777777-7777-7777-7777-777777777777', 'LAVATORY', 4, 'FRONT', 'D,E,F', NOW()),
+  ('f3333333-3333-3333-3333-333333333333', '77777777-7777-7777-7777-777777777777', 'EXIT_LEFT', 4, 'FRONT', '', NOW()),
+  ('f4444444-4444-4444-4444-444444444444', '77777777-7777-7777-7777-777777777777', 'EXIT_RIGHT', 4, 'FRONT', '', NOW()),
+  ('f5555555-5555-5555-5555-555555555555', '77777777-7777-7777-7777-777777777777', 'WING', 4, 'FRONT', '', NOW());
@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_booking_seat_changes_booking_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS booking_seat_changes;
@@ -0,0 +1,26 @@
+-- Create booking_seat_changes table
+CREATE TABLE IF NOT EXISTS booking_seat_changes (
+    id UUID PRIMARY KEY,
+    booking_id UUID NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    booking_seat_id UUID NOT NULL REFERENCES booking_seats(id) ON DELETE CASCADE,
+    type VARCHAR(10) NOT NULL,
+    from_seat_id UUID NOT NULL REFERENCES seats(id),
+    to_seat_id UUID NOT NULL REFERENCES seats(id),
+    old_price DECIMAL(10, 2) NOT NULL,
+    new_price DECIMAL(10, 2) NOT NULL,
+    price_difference DECIMAL(10, 2) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    changed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_booking_seat_changes_booking_id ON booking_seat_changes(booking_id);
+
+-- Seats held by confirmed bookings are no longer available
+UPDATE seats SET available = false
+WHERE id IN (
+    SELECT bs.seat_id FROM booking_seats bs
+    JOIN bookings b ON b.id = bs.booking_id
+    WHERE b.status = 'confirmed'
+);
@@ -0,0 +1,17 @@
+-- Create booking_refunds table
+CREATE TABLE IF NOT EXISTS booking_refunds (
+    id UUID PRIMARY KEY,
+    booking_id UUID NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    booking_seat_id UUID NOT NULL REFERENCES booking_seats(id) ON DELETE CASCADE,
+    seat_id UUID NOT NULL REFERENCES seats(id),
+    refund_indicator VARCHAR(10),
+    price DECIMAL(10, 2) NOT NULL,
+    percent DECIMAL(5, 2) NOT NULL,
+    amount DECIMAL(10, 2) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    reason VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_booking_refunds_booking_id ON booking_refunds(booking_id);
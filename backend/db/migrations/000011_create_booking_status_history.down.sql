@@ -0,0 +1,8 @@
+-- Drop constraints
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_status_check;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_booking_status_history_booking_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS booking_status_history;
@@ -0,0 +1,22 @@
+-- Create booking_status_history table
+CREATE TABLE IF NOT EXISTS booking_status_history (
+    id UUID PRIMARY KEY,
+    booking_id UUID NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+    reason TEXT,
+    changed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_booking_status_history_booking_id ON booking_status_history(booking_id);
+
+-- Restrict bookings to the statuses of the booking lifecycle
+ALTER TABLE bookings ADD CONSTRAINT bookings_status_check CHECK (status IN (
+    'pending', 'held', 'confirmed', 'checked_in', 'boarded', 'cancelled', 'no_show', 'refunded'
+));
+
+-- Record the current status of existing bookings
+INSERT INTO booking_status_history (id, booking_id, from_status, to_status, created_at)
+SELECT gen_random_uuid(), id, NULL, status, created_at FROM bookings;
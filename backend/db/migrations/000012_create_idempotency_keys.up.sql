@@ -0,0 +1,15 @@
+-- Create idempotency_keys table. Keys are scoped to the user that sent them and
+-- may be reused once expired.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key VARCHAR(255) NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    method VARCHAR(10) NOT NULL,
+    path TEXT NOT NULL,
+    fingerprint VARCHAR(64) NOT NULL,
+    response_status INTEGER,
+    response_body BYTEA,
+    content_type VARCHAR(255),
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, key)
+);
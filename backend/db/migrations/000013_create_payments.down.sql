@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_payments_booking_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS payments;
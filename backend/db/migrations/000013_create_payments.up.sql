@@ -0,0 +1,17 @@
+-- Create payments table
+CREATE TABLE IF NOT EXISTS payments (
+    id UUID PRIMARY KEY,
+    booking_id UUID NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL,
+    provider_reference VARCHAR(255),
+    status VARCHAR(20) NOT NULL,
+    amount DECIMAL(10, 2) NOT NULL,
+    refunded_amount DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    currency VARCHAR(3) NOT NULL,
+    failure_reason TEXT,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_payments_booking_id ON payments(booking_id);
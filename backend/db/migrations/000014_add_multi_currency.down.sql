@@ -0,0 +1,16 @@
+-- Drop tables
+DROP TABLE IF EXISTS exchange_rates;
+
+-- Restore two decimal place amounts
+ALTER TABLE payments
+    ALTER COLUMN amount TYPE DECIMAL(10, 2),
+    ALTER COLUMN refunded_amount TYPE DECIMAL(10, 2);
+ALTER TABLE booking_refunds
+    ALTER COLUMN price TYPE DECIMAL(10, 2),
+    ALTER COLUMN amount TYPE DECIMAL(10, 2);
+ALTER TABLE booking_seat_changes
+    ALTER COLUMN old_price TYPE DECIMAL(10, 2),
+    ALTER COLUMN new_price TYPE DECIMAL(10, 2),
+    ALTER COLUMN price_difference TYPE DECIMAL(10, 2);
+ALTER TABLE booking_seats ALTER COLUMN price TYPE DECIMAL(10, 2);
+ALTER TABLE seat_prices ALTER COLUMN amount TYPE DECIMAL(10, 2);
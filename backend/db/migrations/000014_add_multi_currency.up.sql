@@ -0,0 +1,32 @@
+-- Amounts are stored exactly with up to 4 decimal places, enough for every ISO 4217 currency
+ALTER TABLE seat_prices ALTER COLUMN amount TYPE NUMERIC(14, 4);
+ALTER TABLE booking_seats ALTER COLUMN price TYPE NUMERIC(14, 4);
+ALTER TABLE booking_seat_changes
+    ALTER COLUMN old_price TYPE NUMERIC(14, 4),
+    ALTER COLUMN new_price TYPE NUMERIC(14, 4),
+    ALTER COLUMN price_difference TYPE NUMERIC(14, 4);
+ALTER TABLE booking_refunds
+    ALTER COLUMN price TYPE NUMERIC(14, 4),
+    ALTER COLUMN amount TYPE NUMERIC(14, 4);
+ALTER TABLE payments
+    ALTER COLUMN amount TYPE NUMERIC(14, 4),
+    ALTER COLUMN refunded_amount TYPE NUMERIC(14, 4);
+
+-- Create exchange_rates table
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    id UUID PRIMARY KEY,
+    base_currency VARCHAR(3) NOT NULL,
+    quote_currency VARCHAR(3) NOT NULL,
+    rate NUMERIC(20, 10) NOT NULL CHECK (rate > 0),
+    effective_from TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    UNIQUE (base_currency, quote_currency, effective_from)
+);
+
+-- Insert mock exchange rates from the currency of the mock seat prices
+INSERT INTO exchange_rates (id, base_currency, quote_currency, rate, effective_from, created_at)
+VALUES
+  ('e1e1e1e1-0000-0000-0000-000000000001', 'MYR', 'USD', 0.2130, '2024-01-01', NOW()),
+  ('e1e1e1e1-0000-0000-0000-000000000002', 'MYR', 'SGD', 0.2870, '2024-01-01', NOW()),
+  ('e1e1e1e1-0000-0000-0000-000000000003', 'MYR', 'EUR', 0.1960, '2024-01-01', NOW()),
+  ('e1e1e1e1-0000-0000-0000-000000000004', 'MYR', 'JPY', 32.0500, '2024-01-01', NOW());
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_bookings_hold_expires_at;
+
+ALTER TABLE bookings DROP COLUMN IF EXISTS hold_expires_at;
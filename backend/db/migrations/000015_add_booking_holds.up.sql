@@ -0,0 +1,4 @@
+-- Held bookings keep their seats at the quoted prices until the hold expires
+ALTER TABLE bookings ADD COLUMN hold_expires_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_bookings_hold_expires_at ON bookings(hold_expires_at) WHERE status = 'held';
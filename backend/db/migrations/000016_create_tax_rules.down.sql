@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS booking_seat_taxes;
+
+ALTER TABLE booking_seats DROP COLUMN IF EXISTS base_price;
+
+DROP TABLE IF EXISTS tax_rules;
+DROP TABLE IF EXISTS airports;
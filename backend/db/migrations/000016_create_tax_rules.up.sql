@@ -0,0 +1,68 @@
+-- Create airports table, used to find the countries a flight flies between
+CREATE TABLE IF NOT EXISTS airports (
+    code VARCHAR(3) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    country_code CHAR(2) NOT NULL
+);
+
+-- Create tax_rules table. A NULL origin or destination country matches any
+-- country; percent rules charge rate percent of the base price, fixed rules
+-- charge amount per chargeable seat.
+CREATE TABLE IF NOT EXISTS tax_rules (
+    id UUID PRIMARY KEY,
+    code VARCHAR(20) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    origin_country CHAR(2),
+    destination_country CHAR(2),
+    type VARCHAR(20) NOT NULL CHECK (type IN ('percent', 'fixed')),
+    rate NUMERIC(7, 4),
+    amount NUMERIC(14, 4),
+    currency VARCHAR(3),
+    effective_from TIMESTAMP NOT NULL,
+    effective_to TIMESTAMP,
+    created_at TIMESTAMP NOT NULL,
+    CHECK (type <> 'percent' OR rate IS NOT NULL),
+    CHECK (type <> 'fixed' OR (amount IS NOT NULL AND currency IS NOT NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_tax_rules_countries ON tax_rules(origin_country, destination_country);
+
+-- Booked seats keep the base price next to the total in price
+ALTER TABLE booking_seats ADD COLUMN base_price NUMERIC(14, 4);
+UPDATE booking_seats SET base_price = price;
+ALTER TABLE booking_seats ALTER COLUMN base_price SET NOT NULL;
+
+-- Create booking_seat_taxes table
+CREATE TABLE IF NOT EXISTS booking_seat_taxes (
+    id UUID PRIMARY KEY,
+    booking_seat_id UUID NOT NULL REFERENCES booking_seats(id) ON DELETE CASCADE,
+    code VARCHAR(20) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    amount NUMERIC(14, 4) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_booking_seat_taxes_booking_seat_id ON booking_seat_taxes(booking_seat_id);
+
+-- Insert mock airports
+INSERT INTO airports (code, name, country_code)
+VALUES
+  ('KUL', 'Kuala Lumpur International Airport', 'MY'),
+  ('PEN', 'Penang International Airport', 'MY'),
+  ('BKI', 'Kota Kinabalu International Airport', 'MY'),
+  ('SIN', 'Singapore Changi Airport', 'SG'),
+  ('CGK', 'Soekarno-Hatta International Airport', 'ID'),
+  ('DPS', 'Ngurah Rai International Airport', 'ID'),
+  ('BKK', 'Suvarnabhumi Airport', 'TH'),
+  ('NRT', 'Narita International Airport', 'JP'),
+  ('HND', 'Haneda Airport', 'JP'),
+  ('LHR', 'Heathrow Airport', 'GB'),
+  ('SYD', 'Sydney Airport', 'AU');
+
+-- Insert mock tax rules
+INSERT INTO tax_rules (id, code, name, origin_country, destination_country, type, rate, amount, currency, effective_from, created_at)
+VALUES
+  ('7a7a7a7a-0000-0000-0000-000000000001', 'SST', 'Sales and Service Tax', 'MY', NULL, 'percent', 8, NULL, NULL, '2024-01-01', NOW()),
+  ('7a7a7a7a-0000-0000-0000-000000000002', 'VAT', 'Value Added Tax', 'ID', NULL, 'percent', 11, NULL, NULL, '2024-01-01', NOW()),
+  ('7a7a7a7a-0000-0000-0000-000000000003', 'SSF', 'Seat Service Fee', 'MY', NULL, 'fixed', NULL, 2.00, 'MYR', '2024-01-01', NOW());
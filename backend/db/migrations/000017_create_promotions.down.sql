@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_bookings_promotion_id;
+
+ALTER TABLE booking_seats DROP COLUMN IF EXISTS discount;
+ALTER TABLE bookings DROP COLUMN IF EXISTS promotion_id;
+
+DROP TABLE IF EXISTS promotions;
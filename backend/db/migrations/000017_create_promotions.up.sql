@@ -0,0 +1,34 @@
+-- Create promotions table. Characteristics and routes are comma-separated and
+-- empty when the promotion applies to every seat or route.
+CREATE TABLE IF NOT EXISTS promotions (
+    id UUID PRIMARY KEY,
+    code VARCHAR(20) NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    discount_type VARCHAR(20) NOT NULL CHECK (discount_type IN ('percent', 'amount')),
+    percent NUMERIC(7, 4),
+    amount NUMERIC(14, 4),
+    currency VARCHAR(3),
+    characteristics TEXT NOT NULL DEFAULT '',
+    routes TEXT NOT NULL DEFAULT '',
+    valid_from TIMESTAMP NOT NULL,
+    valid_to TIMESTAMP,
+    usage_limit INTEGER CHECK (usage_limit > 0),
+    usage_count INTEGER NOT NULL DEFAULT 0 CHECK (usage_limit IS NULL OR usage_count <= usage_limit),
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    CHECK (discount_type <> 'percent' OR percent IS NOT NULL),
+    CHECK (discount_type <> 'amount' OR (amount IS NOT NULL AND currency IS NOT NULL))
+);
+
+-- Bookings redeem at most one promotion; booked seats keep the discount given
+ALTER TABLE bookings ADD COLUMN promotion_id UUID REFERENCES promotions(id);
+ALTER TABLE booking_seats ADD COLUMN discount NUMERIC(14, 4) NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_bookings_promotion_id ON bookings(promotion_id);
+
+-- Insert mock promotions
+INSERT INTO promotions (id, code, description, discount_type, percent, amount, currency, characteristics, routes, valid_from, valid_to, usage_limit, created_at, updated_at)
+VALUES
+  ('9b9b9b9b-0000-0000-0000-000000000001', 'LEGROOM25', '25% off extra-legroom seats on KUL routes', 'percent', 25, NULL, NULL, 'L', 'KUL', '2024-01-01', NULL, NULL, NOW(), NOW()),
+  ('9b9b9b9b-0000-0000-0000-000000000002', 'WELCOME10', 'MYR 10 off any seat for the first 100 bookings', 'amount', NULL, 10.00, 'MYR', '', '', '2024-01-01', NULL, 100, NOW(), NOW());
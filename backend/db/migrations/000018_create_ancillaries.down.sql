@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS booking_ancillaries;
+DROP TABLE IF EXISTS bundle_products;
+DROP TABLE IF EXISTS bundles;
+DROP TABLE IF EXISTS ancillary_products;
@@ -0,0 +1,71 @@
+-- Create ancillary_products table
+CREATE TABLE IF NOT EXISTS ancillary_products (
+    id UUID PRIMARY KEY,
+    code VARCHAR(20) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    category VARCHAR(20) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    price NUMERIC(14, 4) NOT NULL CHECK (price >= 0),
+    currency VARCHAR(3) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+-- Create bundles table. Seat characteristics are comma-separated and empty
+-- when the bundle is offered with every seat.
+CREATE TABLE IF NOT EXISTS bundles (
+    id UUID PRIMARY KEY,
+    code VARCHAR(20) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    seat_characteristics TEXT NOT NULL DEFAULT '',
+    discount_percent NUMERIC(7, 4) NOT NULL DEFAULT 0 CHECK (discount_percent >= 0 AND discount_percent <= 100),
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+-- Create bundle_products table
+CREATE TABLE IF NOT EXISTS bundle_products (
+    bundle_id UUID NOT NULL REFERENCES bundles(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES ancillary_products(id),
+    PRIMARY KEY (bundle_id, product_id)
+);
+
+-- Create booking_ancillaries table
+CREATE TABLE IF NOT EXISTS booking_ancillaries (
+    id UUID PRIMARY KEY,
+    booking_id UUID NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    booking_seat_id UUID NOT NULL REFERENCES booking_seats(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES ancillary_products(id),
+    bundle_id UUID REFERENCES bundles(id),
+    price NUMERIC(14, 4) NOT NULL,
+    discount NUMERIC(14, 4) NOT NULL DEFAULT 0,
+    currency VARCHAR(3) NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_booking_ancillaries_booking_id ON booking_ancillaries(booking_id);
+
+-- Insert mock ancillary products
+INSERT INTO ancillary_products (id, code, name, category, description, price, currency, created_at, updated_at)
+VALUES
+  ('a5a5a5a5-0000-0000-0000-000000000001', 'BAG20', 'Checked baggage 20kg', 'baggage', 'One checked bag up to 20kg', 75.00, 'MYR', NOW(), NOW()),
+  ('a5a5a5a5-0000-0000-0000-000000000002', 'BAG30', 'Checked baggage 30kg', 'baggage', 'One checked bag up to 30kg', 110.00, 'MYR', NOW(), NOW()),
+  ('a5a5a5a5-0000-0000-0000-000000000003', 'MEAL', 'Hot meal', 'meal', 'Pre-ordered hot meal with a drink', 25.00, 'MYR', NOW(), NOW()),
+  ('a5a5a5a5-0000-0000-0000-000000000004', 'PRIORITY', 'Priority boarding', 'priority', 'Board the aircraft first', 20.00, 'MYR', NOW(), NOW());
+
+-- Insert mock bundles
+INSERT INTO bundles (id, code, name, description, seat_characteristics, discount_percent, created_at, updated_at)
+VALUES
+  ('b6b6b6b6-0000-0000-0000-000000000001', 'VALUE', 'Value pack', 'Any seat with 20kg baggage and a hot meal', '', 10, NOW(), NOW()),
+  ('b6b6b6b6-0000-0000-0000-000000000002', 'COMFORT', 'Comfort pack', 'Extra-legroom seat with 30kg baggage, a hot meal and priority boarding', 'L', 20, NOW(), NOW());
+
+INSERT INTO bundle_products (bundle_id, product_id)
+VALUES
+  ('b6b6b6b6-0000-0000-0000-000000000001', 'a5a5a5a5-0000-0000-0000-000000000001'),
+  ('b6b6b6b6-0000-0000-0000-000000000001', 'a5a5a5a5-0000-0000-0000-000000000003'),
+  ('b6b6b6b6-0000-0000-0000-000000000002', 'a5a5a5a5-0000-0000-0000-000000000002'),
+  ('b6b6b6b6-0000-0000-0000-000000000002', 'a5a5a5a5-0000-0000-0000-000000000003'),
+  ('b6b6b6b6-0000-0000-0000-000000000002', 'a5a5a5a5-0000-0000-0000-000000000004');
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_passengers_segment_id;
+ALTER TABLE passengers DROP COLUMN IF EXISTS document_expiry;
+ALTER TABLE passengers DROP COLUMN IF EXISTS document_number;
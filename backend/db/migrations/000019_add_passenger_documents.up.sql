@@ -0,0 +1,14 @@
+-- Travel document number and expiry of each passenger
+ALTER TABLE passengers ADD COLUMN IF NOT EXISTS document_number VARCHAR(50);
+ALTER TABLE passengers ADD COLUMN IF NOT EXISTS document_expiry DATE;
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_passengers_segment_id ON passengers(segment_id);
+
+-- Blank optional fields of the mock passengers are unset rather than empty
+UPDATE passengers SET issuing_country = NULL WHERE issuing_country = '';
+UPDATE passengers SET country_of_birth = NULL WHERE country_of_birth = '';
+
+-- Give the mock passport holders document numbers
+UPDATE passengers SET document_number = 'A' || LPAD(id::text, 8, '0'), document_expiry = '2030-12-31'
+WHERE document_type = 'P';
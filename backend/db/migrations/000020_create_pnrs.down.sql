@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_booking_seats_passenger_id;
+DROP INDEX IF EXISTS idx_bookings_pnr_id;
+DROP INDEX IF EXISTS idx_passengers_pnr_id;
+DROP INDEX IF EXISTS idx_pnrs_itinerary_id;
+
+ALTER TABLE booking_seats DROP COLUMN IF EXISTS passenger_id;
+ALTER TABLE bookings DROP COLUMN IF EXISTS pnr_id;
+ALTER TABLE passengers DROP COLUMN IF EXISTS pnr_id;
+
+DROP TABLE IF EXISTS pnrs;
@@ -0,0 +1,37 @@
+-- Create pnrs table. A passenger name record groups the passengers travelling
+-- together on the segments of an itinerary under a six-character record locator.
+CREATE TABLE IF NOT EXISTS pnrs (
+    id UUID PRIMARY KEY,
+    record_locator CHAR(6) NOT NULL UNIQUE,
+    itinerary_id UUID NOT NULL REFERENCES itineraries(id),
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+-- Passengers, bookings and booked seats belong to a PNR; each booked seat is
+-- assigned to one of the PNR's passengers on the booked segment
+ALTER TABLE passengers ADD COLUMN IF NOT EXISTS pnr_id UUID REFERENCES pnrs(id);
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS pnr_id UUID REFERENCES pnrs(id);
+ALTER TABLE booking_seats ADD COLUMN IF NOT EXISTS passenger_id INTEGER REFERENCES passengers(id) ON DELETE SET NULL;
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_pnrs_itinerary_id ON pnrs(itinerary_id);
+CREATE INDEX IF NOT EXISTS idx_passengers_pnr_id ON passengers(pnr_id);
+CREATE INDEX IF NOT EXISTS idx_bookings_pnr_id ON bookings(pnr_id);
+CREATE INDEX IF NOT EXISTS idx_booking_seats_passenger_id ON booking_seats(passenger_id);
+
+-- Group the mock passengers under a mock PNR holding the mock booking
+INSERT INTO pnrs (id, record_locator, itinerary_id, user_id, created_at, updated_at)
+VALUES ('7c7c7c7c-0000-0000-0000-000000000001', 'MYK2PA', '44444444-4444-4444-4444-444444444444', '33333333-3333-3333-3333-333333333333', NOW(), NOW());
+
+UPDATE passengers SET pnr_id = '7c7c7c7c-0000-0000-0000-000000000001'
+WHERE segment_id = '55555555-5555-5555-5555-555555555555';
+
+UPDATE bookings SET pnr_id = '7c7c7c7c-0000-0000-0000-000000000001'
+WHERE id = '12345678-1234-1234-1234-123456789012';
+
+UPDATE booking_seats SET passenger_id = (
+    SELECT id FROM passengers WHERE first_name = 'Rutwik' AND last_name = 'Sabre'
+)
+WHERE id = '87654321-4321-4321-4321-210987654321';
@@ -0,0 +1,17 @@
+-- Restore the original seat codes of row 4
+UPDATE seat_rows SET seat_codes = 'BLANK,AISLE,SEAT', updated_at = NOW()
+WHERE id = '99999999-9999-9999-9999-999999999999' AND seat_codes = 'A,B,C,D,E,F';
+
+-- Restore row 0 and its placeholder slots
+INSERT INTO seat_rows (id, cabin_id, row_number, seat_codes, created_at, updated_at)
+VALUES ('88888888-8888-8888-8888-888888888888', '77777777-7777-7777-7777-777777777777', 0, 'BULKHEAD,BLANK', NOW(), NOW())
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO seats (id, row_id, segment_id, storefront_slot_code, code, available, entitled, fee_waived, free_of_charge, originally_selected, entitled_rule_id, fee_waived_rule_id, refund_indicator, seat_characteristics, raw_characteristics, created_at, updated_at)
+VALUES
+  ('11111111-aaaa-bbbb-cccc-dddddddddddd', '88888888-8888-8888-8888-888888888888', '55555555-5555-5555-5555-555555555555', 'BLANK', NULL, false, false, false, true, false, NULL, NULL, NULL, 'LEFT_SIDE', 'LEFT_SIDE', NOW(), NOW()),
+  ('22222222-aaaa-bbbb-cccc-dddddddddddd', '88888888-8888-8888-8888-888888888888', '55555555-5555-5555-5555-555555555555', 'AISLE', NULL, false, false, false, true, false, NULL, NULL, NULL, NULL, NULL, NOW(), NOW()),
+  ('33333333-aaaa-bbbb-cccc-dddddddddddd', '88888888-8888-8888-8888-888888888888', '55555555-5555-5555-5555-555555555555', 'BLANK', NULL, false, false, false, true, false, NULL, NULL, NULL, 'RIGHT_SIDE', 'RIGHT_SIDE', NOW(), NOW())
+ON CONFLICT (id) DO NOTHING;
+
+DELETE FROM cabin_facilities WHERE id = 'f6666666-6666-6666-6666-666666666666';
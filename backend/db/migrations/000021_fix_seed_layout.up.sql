@@ -0,0 +1,14 @@
+-- Make the mock layout valid under the cabin layout rules: rows must fall
+-- within their cabin's row range and list the cabin's seat columns
+
+-- Row 0 only held placeholder slots for a bulkhead; mark the bulkhead on row 4 instead
+INSERT INTO cabin_facilities (id, cabin_id, type, row_number, position, columns, created_at)
+VALUES ('f6666666-6666-6666-6666-666666666666', '77777777-7777-7777-7777-777777777777', 'BULKHEAD', 4, 'FRONT', '', NOW())
+ON CONFLICT (id) DO NOTHING;
+
+DELETE FROM seats WHERE row_id = '88888888-8888-8888-8888-888888888888';
+DELETE FROM seat_rows WHERE id = '88888888-8888-8888-8888-888888888888';
+
+-- List the seat columns of row 4
+UPDATE seat_rows SET seat_codes = 'A,B,C,D,E,F', updated_at = NOW()
+WHERE id = '99999999-9999-9999-9999-999999999999' AND seat_codes = 'BLANK,AISLE,SEAT';
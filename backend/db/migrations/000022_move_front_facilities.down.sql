@@ -0,0 +1,17 @@
+-- Facility markers (stairs, galleys, lavatories) shown in front of a cabin
+ALTER TABLE cabins ADD COLUMN IF NOT EXISTS front_facilities VARCHAR(255) NOT NULL DEFAULT '';
+
+-- Rebuild the markers from the space facilities in front of each cabin's first
+-- row. The facilities stay in cabin_facilities, as the ones moved by the up
+-- migration cannot be told apart from facilities added since.
+UPDATE cabins c SET front_facilities = f.facilities
+FROM (
+    SELECT cf.cabin_id, string_agg(DISTINCT cf.type, ',') AS facilities
+    FROM cabin_facilities cf
+    JOIN cabins cc ON cc.id = cf.cabin_id
+    WHERE cf.row_number = cc.first_row
+    AND cf.position = 'FRONT'
+    AND cf.type IN ('STAIRS', 'GALLEY', 'LAVATORY')
+    GROUP BY cf.cabin_id
+) f
+WHERE c.id = f.cabin_id;
@@ -0,0 +1,30 @@
+-- Move the facility markers in front of each cabin into cabin_facilities as
+-- FRONT space facilities of the cabin's first row. The cabin's seat columns
+-- are split between its markers from left to right, leaving out columns
+-- another facility already takes there.
+INSERT INTO cabin_facilities (id, cabin_id, type, row_number, position, columns, created_at)
+SELECT gen_random_uuid(), f.cabin_id, f.type, f.first_row, 'FRONT', string_agg(l.letter, ',' ORDER BY l.i), NOW()
+FROM (
+    SELECT c.id AS cabin_id,
+           c.first_row,
+           UPPER(TRIM(t.type)) AS type,
+           ROW_NUMBER() OVER (PARTITION BY c.id ORDER BY t.n) AS n,
+           COUNT(*) OVER (PARTITION BY c.id) AS k,
+           regexp_replace(UPPER(c.seat_columns), 'LEFT_SIDE|RIGHT_SIDE|AISLE|[^A-Z]', '', 'g') AS letters
+    FROM cabins c
+    CROSS JOIN LATERAL unnest(string_to_array(c.front_facilities, ',')) WITH ORDINALITY AS t(type, n)
+    WHERE TRIM(t.type) <> ''
+) f
+CROSS JOIN LATERAL unnest(regexp_split_to_array(f.letters, '')) WITH ORDINALITY AS l(letter, i)
+WHERE (l.i - 1) * f.k / length(f.letters) = f.n - 1
+AND NOT EXISTS (
+    SELECT 1 FROM cabin_facilities e
+    WHERE e.cabin_id = f.cabin_id
+    AND e.row_number = f.first_row
+    AND e.position = 'FRONT'
+    AND l.letter = ANY(string_to_array(replace(e.columns, ' ', ''), ','))
+)
+GROUP BY f.cabin_id, f.first_row, f.type, f.n;
+
+-- Drop facility markers
+ALTER TABLE cabins DROP COLUMN IF EXISTS front_facilities;
@@ -5,8 +5,11 @@ import (
 	"fmt"
 
 	"github.com/evaizee/seat-arrangements/backend/controllers"
+	"github.com/evaizee/seat-arrangements/backend/mailer"
+	"github.com/evaizee/seat-arrangements/backend/payments"
 	"github.com/evaizee/seat-arrangements/backend/repositories"
 	"github.com/evaizee/seat-arrangements/backend/repositories/postgres"
+	"github.com/evaizee/seat-arrangements/backend/security"
 	"github.com/evaizee/seat-arrangements/backend/services"
 	"github.com/evaizee/seat-arrangements/backend/services/impl"
 	_ "github.com/lib/pq"
@@ -19,15 +22,35 @@ type Container struct {
 	// Database connection
 	DB *sql.DB
 
+	// JWT signing and verification keys
+	KeySet *security.KeySet
+
+	// Outgoing email
+	Mailer mailer.Mailer
+
+	// Payment provider
+	PaymentProvider payments.Provider
+
 	// Repositories
 	UserRepository          repositories.UserRepository
+	UserTokenRepository     repositories.UserTokenRepository
+	AuditLogRepository      repositories.AuditLogRepository
+	IdempotencyRepository   repositories.IdempotencyRepository
 	FlightRepository        repositories.FlightRepository
 	AircraftRepository      repositories.AircraftRepository
 	CabinRepository         repositories.CabinRepository
 	SeatRepository          repositories.SeatRepository
 	RowRepository          repositories.RowRepository
+	FacilityRepository      repositories.FacilityRepository
 	BookingRepository       repositories.BookingRepository
+	PaymentRepository       repositories.PaymentRepository
+	ExchangeRateRepository  repositories.ExchangeRateRepository
+	AirportRepository       repositories.AirportRepository
+	TaxRuleRepository       repositories.TaxRuleRepository
+	PromotionRepository     repositories.PromotionRepository
+	AncillaryRepository     repositories.AncillaryRepository
 	PassengerRepository     repositories.PassengerRepository
+	PNRRepository           repositories.PNRRepository
 	FrequentFlyerRepository repositories.FrequentFlyerRepository
 
 	// Services
@@ -36,19 +59,29 @@ type Container struct {
 	AircraftService      services.AircraftService
 	CabinService         services.CabinService
 	SeatService          services.SeatService
+	LayoutService        services.LayoutService
 	BookingService       services.BookingService
+	CurrencyService      services.CurrencyService
+	PromotionService     services.PromotionService
+	AncillaryService     services.AncillaryService
 	AuthService          services.AuthService
 	PassengerService     services.PassengerService
 	FrequentFlyerService services.FrequentFlyerService
+	PNRService           services.PNRService
 
 	// Controllers
-	// UserController     *controllers.UserController
-	// FlightController   *controllers.FlightController
-	// AircraftController *controllers.AircraftController
-	// CabinController    *controllers.CabinController
+	UserController *controllers.UserController
+	FlightController *controllers.FlightController
+	AircraftController *controllers.AircraftController
+	CabinController    *controllers.CabinController
 	SeatController *controllers.SeatController
-	// BookingController  *controllers.BookingController
-	// AuthController     *controllers.AuthController
+	BookingController *controllers.BookingController
+	CurrencyController *controllers.CurrencyController
+	PromotionController *controllers.PromotionController
+	AncillaryController *controllers.AncillaryController
+	PassengerController *controllers.PassengerController
+	PNRController *controllers.PNRController
+	AuthController *controllers.AuthController
 }
 
 // NewContainer creates a new dependency injection container
@@ -59,6 +92,15 @@ func NewContainer() *Container {
 	// Connect to the database
 	container.initDB()
 
+	// Load JWT keys
+	container.initKeySet()
+
+	// Initialize the mailer
+	container.initMailer()
+
+	// Initialize the payment provider
+	container.initPaymentProvider()
+
 	// Initialize repositories
 	container.initRepositories()
 
@@ -109,22 +151,80 @@ func (c *Container) initDB() {
 	c.DB = db
 }
 
+// initKeySet loads the JWT signing and verification keys
+func (c *Container) initKeySet() {
+	keySet, err := security.LoadKeySet()
+	if err != nil {
+		zap.L().Fatal("Failed to load JWT keys", zap.Error(err))
+	}
+
+	zap.L().Info("Loaded JWT keys",
+		zap.String("algorithm", keySet.SigningKey().Algorithm),
+		zap.String("signing_key_id", keySet.SigningKey().ID))
+
+	c.KeySet = keySet
+}
+
+// initMailer initializes the mailer selected by mail.driver
+func (c *Container) initMailer() {
+	m, err := mailer.New()
+	if err != nil {
+		zap.L().Fatal("Failed to initialize mailer", zap.Error(err))
+	}
+
+	c.Mailer = m
+}
+
+// initPaymentProvider initializes the payment provider selected by payment.provider
+func (c *Container) initPaymentProvider() {
+	provider, err := payments.New()
+	if err != nil {
+		zap.L().Fatal("Failed to initialize payment provider", zap.Error(err))
+	}
+
+	zap.L().Info("Initialized payment provider", zap.String("provider", provider.Name()))
+
+	c.PaymentProvider = provider
+}
+
 // initRepositories initializes all repositories
 func (c *Container) initRepositories() {
 	c.UserRepository = postgres.NewUserRepository(c.DB)
+	c.UserTokenRepository = postgres.NewUserTokenRepository(c.DB)
+	c.AuditLogRepository = postgres.NewAuditLogRepository(c.DB)
+	c.IdempotencyRepository = postgres.NewIdempotencyRepository(c.DB)
 	// Only uncomment these when the repository implementations are available
 	c.FlightRepository = postgres.NewFlightRepository(c.DB)
 	c.AircraftRepository = postgres.NewAircraftRepository(c.DB)
 	c.CabinRepository = postgres.NewCabinRepository(c.DB)
 	c.SeatRepository = postgres.NewSeatRepository(c.DB)
 	c.RowRepository = postgres.NewRowRepository(c.DB)
-	// c.BookingRepository = postgres.NewBookingRepository(c.DB)
+	c.FacilityRepository = postgres.NewFacilityRepository(c.DB)
+	c.BookingRepository = postgres.NewBookingRepository(c.DB)
+	c.PaymentRepository = postgres.NewPaymentRepository(c.DB)
+	c.ExchangeRateRepository = postgres.NewExchangeRateRepository(c.DB)
+	c.AirportRepository = postgres.NewAirportRepository(c.DB)
+	c.TaxRuleRepository = postgres.NewTaxRuleRepository(c.DB)
+	c.PromotionRepository = postgres.NewPromotionRepository(c.DB)
+	c.AncillaryRepository = postgres.NewAncillaryRepository(c.DB)
 	c.PassengerRepository = postgres.NewPassengerRepository(c.DB)
 	c.FrequentFlyerRepository = postgres.NewFrequentFlyerRepository(c.DB)
+	c.PNRRepository = postgres.NewPNRRepository(c.DB)
 }
 
 // initServices initializes all services
 func (c *Container) initServices() {
+	c.UserService = impl.NewUserService(c.UserRepository, c.PassengerRepository, c.FrequentFlyerRepository)
+	c.FlightService = impl.NewFlightService(c.FlightRepository, c.AircraftRepository, c.CabinRepository)
+	c.AircraftService = impl.NewAircraftService(c.AircraftRepository)
+	c.CabinService = impl.NewCabinService(
+		c.CabinRepository,
+		c.AircraftRepository,
+		c.FlightRepository,
+		c.RowRepository,
+		c.SeatRepository,
+		c.FacilityRepository,
+	)
 
 	// Initialize SeatService with just the repositories we have available
 	c.SeatService = impl.NewSeatService(
@@ -134,21 +234,64 @@ func (c *Container) initServices() {
 		c.CabinRepository,
 		c.FlightRepository,
 		c.RowRepository,
+		c.FacilityRepository,
+		c.ExchangeRateRepository,
+		c.AirportRepository,
+		c.TaxRuleRepository,
+		c.PromotionRepository,
+		c.AncillaryRepository,
 	)
 
-	// c.BookingService = impl.NewBookingService(c.BookingRepository, c.SeatRepository)
-	// c.AuthService = impl.NewAuthService(c.UserRepository)
-	c.PassengerService = impl.NewPassengerService(c.PassengerRepository, c.FrequentFlyerRepository)
+	c.LayoutService = impl.NewLayoutService(
+		c.FlightRepository,
+		c.AircraftRepository,
+		c.CabinRepository,
+		c.RowRepository,
+		c.SeatRepository,
+	)
+
+	c.BookingService = impl.NewBookingService(
+		c.BookingRepository,
+		c.SeatRepository,
+		c.RowRepository,
+		c.CabinRepository,
+		c.FlightRepository,
+		c.PaymentRepository,
+		c.ExchangeRateRepository,
+		c.AirportRepository,
+		c.TaxRuleRepository,
+		c.PromotionRepository,
+		c.AncillaryRepository,
+		c.PassengerRepository,
+		c.PaymentProvider,
+	)
+	c.CurrencyService = impl.NewCurrencyService(c.ExchangeRateRepository)
+	c.PromotionService = impl.NewPromotionService(c.PromotionRepository)
+	c.AncillaryService = impl.NewAncillaryService(c.AncillaryRepository)
+	c.AuthService = impl.NewAuthService(
+		c.UserRepository,
+		c.UserTokenRepository,
+		c.AuditLogRepository,
+		c.KeySet,
+		c.Mailer,
+	)
+	c.PassengerService = impl.NewPassengerService(c.PassengerRepository, c.FrequentFlyerRepository, c.FlightRepository, c.AirportRepository)
 	c.FrequentFlyerService = impl.NewFrequentFlyerService(c.FrequentFlyerRepository)
+	c.PNRService = impl.NewPNRService(c.PNRRepository, c.PassengerRepository, c.FlightRepository, c.BookingRepository)
 }
 
 // initControllers initializes all controllers
 func (c *Container) initControllers() {
-	// c.UserController = controllers.NewUserController(c.UserService)
-	// c.FlightController = controllers.NewFlightController(c.FlightService)
-	// c.AircraftController = controllers.NewAircraftController(c.AircraftService)
-	// c.CabinController = controllers.NewCabinController(c.CabinService)
+	c.UserController = controllers.NewUserController(c.UserService)
+	c.FlightController = controllers.NewFlightController(c.FlightService)
+	c.AircraftController = controllers.NewAircraftController(c.AircraftService, c.CabinService)
+	c.CabinController = controllers.NewCabinController(c.CabinService, c.SeatService)
 	c.SeatController = controllers.NewSeatController(c.SeatService)
-	// c.BookingController = controllers.NewBookingController(c.BookingService)
-	// c.AuthController = controllers.NewAuthController(c.AuthService)
+	c.BookingController = controllers.NewBookingController(c.BookingService)
+	c.CurrencyController = controllers.NewCurrencyController(c.CurrencyService)
+	c.PromotionController = controllers.NewPromotionController(c.PromotionService)
+	c.AncillaryController = controllers.NewAncillaryController(c.AncillaryService, c.SeatService)
+	c.PassengerController = controllers.NewPassengerController(c.PassengerService, c.FrequentFlyerService)
+	c.PNRController = controllers.NewPNRController(c.PNRService, c.PassengerService, c.BookingService)
+	c.AuthController = controllers.NewAuthController(c.AuthService, c.KeySet)
 }
@@ -0,0 +1,51 @@
+package mailer
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// LogMailer logs emails instead of sending them, for use in development.
+// When a file is configured each message is also appended to it.
+type LogMailer struct {
+	file string
+	mu   sync.Mutex
+}
+
+// NewLogMailer creates a new LogMailer
+func NewLogMailer(file string) Mailer {
+	return &LogMailer{file: file}
+}
+
+// Send logs the message and appends it to the configured file
+func (m *LogMailer) Send(message Message) error {
+	zap.L().Info("Email sent",
+		zap.String("to", message.To),
+		zap.String("subject", message.Subject),
+		zap.String("body", message.Body))
+
+	if m.file == "" {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, err := os.OpenFile(m.file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open mail log: %w", err)
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "Date: %s\nTo: %s\nSubject: %s\n\n%s\n\n----\n",
+		time.Now().Format(time.RFC1123Z), message.To, message.Subject, message.Body)
+	if err != nil {
+		return fmt.Errorf("failed to write mail log: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package mailer
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+// Message is a plain text email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends emails
+type Mailer interface {
+	Send(message Message) error
+}
+
+// New creates the mailer selected by mail.driver ("log" or "smtp")
+func New() (Mailer, error) {
+	driver := viper.GetString("mail.driver")
+
+	switch driver {
+	case "", "log":
+		return NewLogMailer(viper.GetString("mail.log.file")), nil
+	case "smtp":
+		return NewSMTPMailer(
+			viper.GetString("mail.smtp.host"),
+			viper.GetInt("mail.smtp.port"),
+			viper.GetString("mail.smtp.username"),
+			viper.GetString("mail.smtp.password"),
+			viper.GetString("mail.from"),
+		), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", driver)
+	}
+}
@@ -0,0 +1,58 @@
+package mailer
+
+import (
+	"fmt"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTPMailer sends emails through an SMTP server
+type SMTPMailer struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+}
+
+// NewSMTPMailer creates a new SMTPMailer
+func NewSMTPMailer(host string, port int, username, password, from string) Mailer {
+	return &SMTPMailer{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+// Send sends the message, authenticating when a username is configured
+func (m *SMTPMailer) Send(message Message) error {
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+
+	headers := []string{
+		"From: " + sanitizeHeader(m.from),
+		"To: " + sanitizeHeader(message.To),
+		"Subject: " + sanitizeHeader(message.Subject),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+	}
+	data := strings.Join(headers, "\r\n") + "\r\n\r\n" + message.Body
+
+	addr := fmt.Sprintf("%s:%d", m.host, m.port)
+	if err := smtp.SendMail(addr, auth, m.from, []string{message.To}, []byte(data)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+// sanitizeHeader strips line breaks so values cannot inject extra headers
+func sanitizeHeader(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+	"github.com/gofiber/fiber/v2"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// HeaderIdempotencyKey is the request header carrying the client's idempotency key
+const HeaderIdempotencyKey = "Idempotency-Key"
+
+// maxIdempotencyKeyLength matches the key column of the idempotency_keys table
+const maxIdempotencyKeyLength = 255
+
+// Idempotency replays the stored response when a POST, PUT, PATCH or DELETE
+// request is retried with the same Idempotency-Key, so retries do not book or
+// charge twice. Keys are scoped to the authenticated user and kept for
+// idempotency.ttl; reusing a key for a different request is rejected. Requests
+// without the header are processed as usual. It must run after JWTAuth.
+func Idempotency(repo repositories.IdempotencyRepository) fiber.Handler {
+	ttl := viper.GetDuration("idempotency.ttl")
+	if ttl <= 0 {
+		ttl = 24 * time.Hour
+	}
+
+	return func(c *fiber.Ctx) error {
+		key := c.Get(HeaderIdempotencyKey)
+		userID := CurrentUserID(c)
+		if key == "" || userID == "" || !isMutation(c.Method()) {
+			return c.Next()
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": true,
+				"msg":   "Idempotency-Key must be at most 255 characters",
+			})
+		}
+
+		record := models.NewIdempotencyRecord(userID, key, c.Method(), c.OriginalURL(), fingerprint(c), ttl)
+
+		claimed, err := repo.Claim(record)
+		if err != nil {
+			zap.L().Error("Failed to claim idempotency key", zap.Error(err), zap.String("user_id", userID))
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": true,
+				"msg":   "Failed to process request",
+			})
+		}
+
+		if !claimed {
+			return replay(c, repo, record)
+		}
+
+		if err := c.Next(); err != nil {
+			// Let the request be retried once the error has been handled
+			if deleteErr := repo.Delete(userID, key); deleteErr != nil {
+				zap.L().Error("Failed to release idempotency key", zap.Error(deleteErr), zap.String("user_id", userID))
+			}
+			return err
+		}
+
+		// Server errors are not stored so the client can retry them
+		record.ResponseStatus = c.Response().StatusCode()
+		if record.ResponseStatus >= fiber.StatusInternalServerError {
+			if err := repo.Delete(userID, key); err != nil {
+				zap.L().Error("Failed to release idempotency key", zap.Error(err), zap.String("user_id", userID))
+			}
+			return nil
+		}
+
+		record.ResponseBody = append([]byte(nil), c.Response().Body()...)
+		record.ContentType = string(c.Response().Header.ContentType())
+		if err := repo.SaveResponse(record); err != nil {
+			zap.L().Error("Failed to save idempotent response", zap.Error(err), zap.String("user_id", userID))
+		}
+
+		return nil
+	}
+}
+
+// replay answers a request whose key was already used with the stored response
+func replay(c *fiber.Ctx, repo repositories.IdempotencyRepository, request *models.IdempotencyRecord) error {
+	record, err := repo.Get(request.UserID, request.Key)
+	if err != nil {
+		zap.L().Error("Failed to get idempotency key", zap.Error(err), zap.String("user_id", request.UserID))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Failed to process request",
+		})
+	}
+
+	if record == nil {
+		// The key was released by a failed request in the meantime
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": true,
+			"msg":   "A request with this Idempotency-Key failed, please retry",
+		})
+	}
+
+	if record.Fingerprint != request.Fingerprint {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Idempotency-Key was already used for a different request",
+		})
+	}
+
+	if !record.Completed() {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": true,
+			"msg":   "A request with this Idempotency-Key is still being processed",
+		})
+	}
+
+	c.Set("Idempotent-Replayed", "true")
+	if record.ContentType != "" {
+		c.Set(fiber.HeaderContentType, record.ContentType)
+	}
+	return c.Status(record.ResponseStatus).Send(record.ResponseBody)
+}
+
+// fingerprint identifies a request by its method, URL and body
+func fingerprint(c *fiber.Ctx) string {
+	hash := sha256.New()
+	hash.Write([]byte(c.Method()))
+	hash.Write([]byte{0})
+	hash.Write([]byte(c.OriginalURL()))
+	hash.Write([]byte{0})
+	hash.Write(c.Body())
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// isMutation reports whether requests with the method change state
+func isMutation(method string) bool {
+	switch method {
+	case fiber.MethodPost, fiber.MethodPut, fiber.MethodPatch, fiber.MethodDelete:
+		return true
+	}
+	return false
+}
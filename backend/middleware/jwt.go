@@ -3,13 +3,14 @@ package middleware
 import (
 	"strings"
 
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/security"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
-	"github.com/spf13/viper"
 )
 
-// JWTAuth is a middleware that checks for a valid JWT token
-func JWTAuth() fiber.Handler {
+// JWTAuth is a middleware that checks for a valid JWT token signed by one of the keys in keySet
+func JWTAuth(keySet *security.KeySet) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		// Get the Authorization header
 		authHeader := c.Get("Authorization")
@@ -34,16 +35,8 @@ func JWTAuth() fiber.Handler {
 		// Get the token string
 		tokenString := parts[1]
 
-		// Parse the token
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			// Validate the signing method
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fiber.NewError(fiber.StatusUnauthorized, "Invalid token signing method")
-			}
-
-			// Return the secret key
-			return []byte(viper.GetString("jwt.secret")), nil
-		})
+		// Parse the token, resolving the key from its kid header
+		token, err := keySet.Parse(tokenString)
 
 		// Check for parsing errors
 		if err != nil {
@@ -79,8 +72,15 @@ func JWTAuth() fiber.Handler {
 			})
 		}
 
-		// Set the user ID in the locals
+		// Get the role, defaulting to passenger for tokens issued before roles existed
+		role, ok := claims["role"].(string)
+		if !ok || role == "" {
+			role = models.RolePassenger
+		}
+
+		// Set the user ID and role in the locals
 		c.Locals("user_id", userID)
+		c.Locals("role", role)
 
 		// Continue to the next middleware/handler
 		return c.Next()
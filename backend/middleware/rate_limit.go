@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+	"github.com/spf13/viper"
+)
+
+// AuthRateLimit is a per-IP sliding window rate limiter for the auth endpoints.
+// It is configured with auth.rate_limit.max requests per auth.rate_limit.window.
+func AuthRateLimit() fiber.Handler {
+	max := viper.GetInt("auth.rate_limit.max")
+	if max <= 0 {
+		max = 20
+	}
+
+	window := viper.GetDuration("auth.rate_limit.window")
+	if window <= 0 {
+		window = time.Minute
+	}
+
+	return limiter.New(limiter.Config{
+		Max:        max,
+		Expiration: window,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return c.IP()
+		},
+		LimitReached: func(c *fiber.Ctx) error {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(window.Seconds())))
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error": true,
+				"msg":   "Too many requests, please try again later",
+			})
+		},
+		LimiterMiddleware: limiter.SlidingWindow{},
+	})
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequireRoles is a middleware that only lets through users with one of the given roles.
+// It must be registered after JWTAuth. Admins are always allowed.
+func RequireRoles(roles ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		role := CurrentRole(c)
+		if role == "" {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": true,
+				"msg":   "Missing user role",
+			})
+		}
+
+		if role == models.RoleAdmin {
+			return c.Next()
+		}
+
+		for _, allowed := range roles {
+			if role == allowed {
+				return c.Next()
+			}
+		}
+
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": true,
+			"msg":   "Insufficient permissions",
+		})
+	}
+}
+
+// CurrentUserID returns the authenticated user ID set by JWTAuth
+func CurrentUserID(c *fiber.Ctx) string {
+	userID, _ := c.Locals("user_id").(string)
+	return userID
+}
+
+// CurrentRole returns the authenticated user role set by JWTAuth
+func CurrentRole(c *fiber.Ctx) string {
+	role, _ := c.Locals("role").(string)
+	return role
+}
+
+// IsStaff reports whether the authenticated user may act on records they do not own
+func IsStaff(c *fiber.Ctx) bool {
+	switch CurrentRole(c) {
+	case models.RoleCheckInAgent, models.RoleAdmin:
+		return true
+	}
+	return false
+}
+
+// CanAccessUser reports whether the authenticated user may see or modify data owned by ownerID.
+// Passengers may only access their own records while agents and admins may access any.
+func CanAccessUser(c *fiber.Ctx, ownerID string) bool {
+	if IsStaff(c) {
+		return true
+	}
+	return ownerID != "" && CurrentUserID(c) == ownerID
+}
@@ -1,9 +1,33 @@
 package models
 
 import (
+	"sort"
 	"time"
+
+	"github.com/google/uuid"
+)
+
+// Layout markers that may appear in Cabin.SeatColumns alongside seat column letters
+const (
+	ColumnLeftSide  = "LEFT_SIDE"
+	ColumnRightSide = "RIGHT_SIDE"
+	ColumnAisle     = "AISLE"
 )
 
+// Decks of an aircraft, in the order they are shown in seat maps
+const (
+	DeckMain  = "MAIN"
+	DeckUpper = "UPPER"
+	DeckLower = "LOWER"
+)
+
+// deckOrder is the position of each deck in a seat map
+var deckOrder = map[string]int{
+	DeckMain:  0,
+	DeckUpper: 1,
+	DeckLower: 2,
+}
+
 // Aircraft represents an aircraft in the system
 type Aircraft struct {
 	ID        string    `json:"id"`
@@ -30,4 +54,90 @@ type Cabin struct {
 type CabinWithSeats struct {
 	Cabin *Cabin  `json:"cabin"`
 	Seats []*Seat `json:"seats"`
-}
\ No newline at end of file
+}
+
+// NewAircraft creates a new aircraft
+func NewAircraft(code, name string) *Aircraft {
+	return &Aircraft{
+		ID:        uuid.New().String(),
+		Code:      code,
+		Name:      name,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+// NewCabin creates a new cabin
+func NewCabin(aircraftID, segmentID, deck string, firstRow, lastRow int, seatColumns string) *Cabin {
+	return &Cabin{
+		ID:          uuid.New().String(),
+		AircraftID:  aircraftID,
+		SegmentID:   segmentID,
+		Deck:        deck,
+		FirstRow:    firstRow,
+		LastRow:     lastRow,
+		SeatColumns: seatColumns,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+}
+
+// Layout parses the cabin's seat columns into column groups separated by aisles
+func (c *Cabin) Layout() (*CabinLayout, error) {
+	return ParseCabinLayout(c.SeatColumns)
+}
+
+// Columns returns the seat column letters of the cabin, without layout markers.
+// It returns no columns when the layout is invalid.
+func (c *Cabin) Columns() []string {
+	layout, err := c.Layout()
+	if err != nil {
+		return []string{}
+	}
+	return layout.Columns()
+}
+
+// HasColumn reports whether the cabin has the given seat column
+func (c *Cabin) HasColumn(column string) bool {
+	for _, col := range c.Columns() {
+		if col == column {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsRow reports whether a row number falls within the cabin's row range
+func (c *Cabin) ContainsRow(rowNumber int) bool {
+	return rowNumber >= c.FirstRow && rowNumber <= c.LastRow
+}
+
+// OverlapsRows reports whether the cabin's row range overlaps another cabin's
+func (c *Cabin) OverlapsRows(other *Cabin) bool {
+	return c.FirstRow <= other.LastRow && other.FirstRow <= c.LastRow
+}
+
+// IsLayoutMarker reports whether a seat column entry is a layout marker rather than a seat column
+func IsLayoutMarker(column string) bool {
+	switch column {
+	case ColumnLeftSide, ColumnRightSide, ColumnAisle:
+		return true
+	}
+	return false
+}
+
+// IsValidDeck reports whether deck is a known aircraft deck
+func IsValidDeck(deck string) bool {
+	_, ok := deckOrder[deck]
+	return ok
+}
+
+// SortCabins orders cabins by deck and then by first row
+func SortCabins(cabins []*Cabin) {
+	sort.SliceStable(cabins, func(i, j int) bool {
+		if cabins[i].Deck != cabins[j].Deck {
+			return deckOrder[cabins[i].Deck] < deckOrder[cabins[j].Deck]
+		}
+		return cabins[i].FirstRow < cabins[j].FirstRow
+	})
+}
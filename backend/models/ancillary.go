@@ -0,0 +1,225 @@
+package models
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Ancillary product categories
+const (
+	AncillaryCategoryBaggage  = "baggage"
+	AncillaryCategoryMeal     = "meal"
+	AncillaryCategoryPriority = "priority"
+	AncillaryCategoryOther    = "other"
+)
+
+// productCodePattern matches ancillary product and bundle codes such as "BAG20"
+var productCodePattern = regexp.MustCompile(`^[A-Z0-9_]{2,20}$`)
+
+// AncillaryProduct is an extra sold with a seat, such as checked baggage or a meal
+type AncillaryProduct struct {
+	ID          string    `json:"id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	Category    string    `json:"category"`
+	Description string    `json:"description"`
+	Price       Money     `json:"price"`
+	Active      bool      `json:"active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Bundle is a seat sold together with ancillary products, which are discounted
+// by DiscountPercent against buying them separately. SeatCharacteristics limit
+// the bundle to seats with any of them; it is offered with every seat when empty.
+type Bundle struct {
+	ID                  string              `json:"id"`
+	Code                string              `json:"code"`
+	Name                string              `json:"name"`
+	Description         string              `json:"description"`
+	SeatCharacteristics []string            `json:"seat_characteristics"`
+	DiscountPercent     string              `json:"discount_percent"`
+	Products            []*AncillaryProduct `json:"products"`
+	Active              bool                `json:"active"`
+	CreatedAt           time.Time           `json:"created_at"`
+	UpdatedAt           time.Time           `json:"updated_at"`
+}
+
+// BookingAncillary is an ancillary product added to a booked seat, either on
+// its own or as part of a bundle. Total is the price less the discount.
+type BookingAncillary struct {
+	ID            string    `json:"id"`
+	BookingID     string    `json:"booking_id"`
+	BookingSeatID string    `json:"booking_seat_id"`
+	ProductID     string    `json:"product_id"`
+	BundleID      string    `json:"bundle_id,omitempty"`
+	Price         Money     `json:"price"`
+	Discount      Money     `json:"discount"`
+	Total         Money     `json:"total"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// BundleOffers are the bundles offered to a passenger on a flight
+type BundleOffers struct {
+	FlightID  string         `json:"flight_id"`
+	Passenger PassengerInfo  `json:"passenger"`
+	Currency  string         `json:"currency"`
+	Offers    []*BundleOffer `json:"offers"`
+}
+
+// BundleOffer is a bundle priced for a flight: the list price of its products,
+// the bundle discount on them and each available seat it can be bought with
+type BundleOffer struct {
+	Bundle   *Bundle            `json:"bundle"`
+	Products Money              `json:"products"`
+	Discount Money              `json:"discount"`
+	Seats    []*BundleSeatOffer `json:"seats"`
+	From     *Money             `json:"from,omitempty"`
+}
+
+// BundleSeatOffer is the price of a bundle with a particular seat
+type BundleSeatOffer struct {
+	Code      string `json:"code"`
+	SeatTotal Money  `json:"seat_total"`
+	Total     Money  `json:"total"`
+}
+
+// NewAncillaryProduct creates a new active ancillary product
+func NewAncillaryProduct(code, name, category, description string, price Money) *AncillaryProduct {
+	return &AncillaryProduct{
+		ID:          uuid.New().String(),
+		Code:        code,
+		Name:        name,
+		Category:    category,
+		Description: description,
+		Price:       price,
+		Active:      true,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+}
+
+// Validate checks that an ancillary product is well formed
+func (p *AncillaryProduct) Validate() error {
+	if !productCodePattern.MatchString(p.Code) {
+		return fmt.Errorf("code must be 2 to 20 letters, digits or underscores")
+	}
+
+	if strings.TrimSpace(p.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	switch p.Category {
+	case AncillaryCategoryBaggage, AncillaryCategoryMeal, AncillaryCategoryPriority, AncillaryCategoryOther:
+	default:
+		return fmt.Errorf("unknown category %q", p.Category)
+	}
+
+	if !IsValidCurrency(p.Price.Currency) || p.Price.Amount < 0 {
+		return fmt.Errorf("price must be a non-negative amount in a valid currency")
+	}
+
+	return nil
+}
+
+// NewBundle creates a new active bundle
+func NewBundle(code, name, description string, seatCharacteristics []string, discountPercent string, products []*AncillaryProduct) *Bundle {
+	return &Bundle{
+		ID:                  uuid.New().String(),
+		Code:                code,
+		Name:                name,
+		Description:         description,
+		SeatCharacteristics: seatCharacteristics,
+		DiscountPercent:     discountPercent,
+		Products:            products,
+		Active:              true,
+		CreatedAt:           time.Now(),
+		UpdatedAt:           time.Now(),
+	}
+}
+
+// Validate checks that a bundle is well formed
+func (b *Bundle) Validate() error {
+	if !productCodePattern.MatchString(b.Code) {
+		return fmt.Errorf("code must be 2 to 20 letters, digits or underscores")
+	}
+
+	if strings.TrimSpace(b.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	percent, err := b.DiscountValue()
+	if err != nil || percent.Sign() < 0 || percent.Cmp(big.NewRat(100, 1)) > 0 {
+		return fmt.Errorf("discount_percent must be between 0 and 100")
+	}
+
+	if len(b.Products) == 0 {
+		return fmt.Errorf("a bundle needs at least one product")
+	}
+
+	seen := make(map[string]bool, len(b.Products))
+	for _, product := range b.Products {
+		if seen[product.ID] {
+			return fmt.Errorf("product %s is listed more than once", product.Code)
+		}
+		seen[product.ID] = true
+	}
+
+	for _, characteristic := range b.SeatCharacteristics {
+		if characteristic == "" || strings.Contains(characteristic, ",") {
+			return fmt.Errorf("invalid seat characteristic %q", characteristic)
+		}
+	}
+
+	return nil
+}
+
+// DiscountValue returns the bundle discount percentage as an exact rational number
+func (b *Bundle) DiscountValue() (*big.Rat, error) {
+	if !decimalPattern.MatchString(b.DiscountPercent) {
+		return nil, fmt.Errorf("invalid discount percent %q", b.DiscountPercent)
+	}
+
+	value, ok := new(big.Rat).SetString(b.DiscountPercent)
+	if !ok {
+		return nil, fmt.Errorf("invalid discount percent %q", b.DiscountPercent)
+	}
+	return value, nil
+}
+
+// AppliesToSeat reports whether the bundle can be bought with a seat that has
+// the given characteristics
+func (b *Bundle) AppliesToSeat(characteristics []string) bool {
+	if len(b.SeatCharacteristics) == 0 {
+		return true
+	}
+
+	for _, characteristic := range characteristics {
+		for _, eligible := range b.SeatCharacteristics {
+			if strings.EqualFold(strings.TrimSpace(characteristic), eligible) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NewBookingAncillary adds an ancillary product to a booked seat at a price and discount
+func NewBookingAncillary(bookingSeat *BookingSeat, productID, bundleID string, price, discount Money) *BookingAncillary {
+	return &BookingAncillary{
+		ID:            uuid.New().String(),
+		BookingID:     bookingSeat.BookingID,
+		BookingSeatID: bookingSeat.ID,
+		ProductID:     productID,
+		BundleID:      bundleID,
+		Price:         price,
+		Discount:      discount,
+		Total:         price.Sub(discount),
+		CreatedAt:     time.Now(),
+	}
+}
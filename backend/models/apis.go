@@ -0,0 +1,132 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// secureFlightCountry is the country whose Secure Flight programme requires the
+// date of birth and gender of every passenger flying to, from or within it
+const secureFlightCountry = "US"
+
+// minDocumentValidityMonths is how many months beyond the date of travel a travel
+// document must remain valid to enter the countries that require it
+var minDocumentValidityMonths = map[string]int{
+	"CN": 6,
+	"ID": 6,
+	"MY": 6,
+	"SG": 6,
+	"TH": 6,
+}
+
+// APISRequirements is the advance passenger information a route requires
+// before its passengers may check in
+type APISRequirements struct {
+	// Document requires the travel document, its issuing country and the
+	// passenger's nationality, date of birth and gender
+	Document bool `json:"document"`
+	// SecureFlight requires the passenger's date of birth and gender
+	SecureFlight bool `json:"secure_flight"`
+	// DocumentValidityMonths is how long the travel document must remain valid after departure
+	DocumentValidityMonths int `json:"document_validity_months"`
+}
+
+// APISRequirementsFor returns the advance passenger information required on a
+// route between two countries. A route whose countries are not both known is
+// treated as international.
+func APISRequirementsFor(originCountry, destinationCountry string) APISRequirements {
+	requirements := APISRequirements{
+		Document:     originCountry == "" || destinationCountry == "" || originCountry != destinationCountry,
+		SecureFlight: originCountry == secureFlightCountry || destinationCountry == secureFlightCountry,
+	}
+	if requirements.Document {
+		requirements.DocumentValidityMonths = minDocumentValidityMonths[destinationCountry]
+	}
+	return requirements
+}
+
+// ValidateAPIS checks that the passenger carries the advance passenger
+// information a route departing at departure requires, returning
+// ValidationErrors naming each missing or invalid field. records are the
+// traveller's passenger records on the other segments of their reservation,
+// whose names and dates of birth must match as they are sent to the authorities.
+func (p *Passenger) ValidateAPIS(requirements APISRequirements, departure time.Time, records []*Passenger) error {
+	var errs ValidationErrors
+
+	if requirements.Document || requirements.SecureFlight {
+		if p.DateOfBirth == nil {
+			errs.Add("date_of_birth", "is required for this route")
+		}
+		if p.Gender == nil {
+			errs.Add("gender", "is required for this route")
+		}
+	}
+
+	if requirements.Document {
+		if p.DocumentType == nil {
+			errs.Add("document_type", "is required for this route")
+		}
+		if p.DocumentNumber == nil {
+			errs.Add("document_number", "is required for this route")
+		}
+		if p.DocumentExpiry == nil {
+			errs.Add("document_expiry", "is required for this route")
+		}
+		if p.IssuingCountry == nil {
+			errs.Add("issuing_country", "is required for this route")
+		}
+		if p.Nationality == nil {
+			errs.Add("nationality", "is required for this route")
+		}
+	}
+
+	validateCountry(&errs, "issuing_country", p.IssuingCountry)
+	validateCountry(&errs, "nationality", p.Nationality)
+	validateCountry(&errs, "country_of_birth", p.CountryOfBirth)
+
+	if p.DateOfBirth != nil && p.DateOfBirth.After(departure) {
+		errs.Add("date_of_birth", "must be before the date of travel")
+	}
+
+	if p.DocumentExpiry != nil {
+		validUntil := departure.AddDate(0, requirements.DocumentValidityMonths, 0)
+		switch {
+		case requirements.DocumentValidityMonths > 0 && p.DocumentExpiry.Before(validUntil):
+			errs.Add("document_expiry", "must be at least %d months after the date of travel", requirements.DocumentValidityMonths)
+		case !p.DocumentExpiry.After(departure):
+			errs.Add("document_expiry", "must be after the date of travel")
+		}
+	}
+
+	for _, record := range records {
+		if record.ID == p.ID {
+			continue
+		}
+		if !sameName(record.FirstName, p.FirstName) {
+			errs.Add("first_name", "must match the traveller's first name on segment %s", record.SegmentID)
+		}
+		if !sameName(record.LastName, p.LastName) {
+			errs.Add("last_name", "must match the traveller's last name on segment %s", record.SegmentID)
+		}
+		if record.DateOfBirth != nil && p.DateOfBirth != nil && !record.DateOfBirth.Equal(*p.DateOfBirth) {
+			errs.Add("date_of_birth", "must match the traveller's date of birth on segment %s", record.SegmentID)
+		}
+	}
+
+	return errs.Err()
+}
+
+// sameName reports whether two names are the same, ignoring case and repeated spaces
+func sameName(a, b string) bool {
+	return strings.EqualFold(strings.Join(strings.Fields(a), " "), strings.Join(strings.Fields(b), " "))
+}
+
+// APISCheck is the outcome of checking a passenger's advance passenger
+// information against the route of their segment
+type APISCheck struct {
+	PassengerID  int              `json:"passenger_id"`
+	SegmentID    string           `json:"segment_id"`
+	Requirements APISRequirements `json:"requirements"`
+	Complete     bool             `json:"complete"`
+	Errors       ValidationErrors `json:"errors"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audit log actions
+const (
+	AuditActionAccountLocked = "account_locked"
+)
+
+// AuditLog records a security or operational event
+type AuditLog struct {
+	ID        string    `json:"id"`
+	UserID    *string   `json:"user_id,omitempty"`
+	Action    string    `json:"action"`
+	IPAddress string    `json:"ip_address"`
+	Details   string    `json:"details"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewAuditLog creates a new audit log entry
+func NewAuditLog(userID *string, action, ipAddress, details string) *AuditLog {
+	return &AuditLog{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Action:    action,
+		IPAddress: ipAddress,
+		Details:   details,
+		CreatedAt: time.Now(),
+	}
+}
@@ -6,56 +6,214 @@ import (
 	"github.com/google/uuid"
 )
 
-// Booking represents a booking in the system
+// BookingStatus is a stage of the booking lifecycle
+type BookingStatus string
+
+// Booking statuses
+const (
+	BookingStatusPending   BookingStatus = "pending"
+	BookingStatusHeld      BookingStatus = "held"
+	BookingStatusConfirmed BookingStatus = "confirmed"
+	BookingStatusCheckedIn BookingStatus = "checked_in"
+	BookingStatusBoarded   BookingStatus = "boarded"
+	BookingStatusCancelled BookingStatus = "cancelled"
+	BookingStatusNoShow    BookingStatus = "no_show"
+	BookingStatusRefunded  BookingStatus = "refunded"
+)
+
+// Seat change types
+const (
+	SeatChangeTypeChange = "change"
+	SeatChangeTypeSwap   = "swap"
+)
+
+// Booking represents a booking in the system. Held bookings keep their seats
+// and prices until HoldExpiresAt. PromotionID is the promotion redeemed by the booking.
 type Booking struct {
-	ID        string    `json:"id"`
-	UserID    string    `json:"user_id"`
-	FlightID  string    `json:"flight_id"`
-	Status    string    `json:"status"` // confirmed, cancelled, pending
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID            string        `json:"id"`
+	UserID        string        `json:"user_id"`
+	FlightID      string        `json:"flight_id"`
+	Status        BookingStatus `json:"status"`
+	PromotionID   string        `json:"promotion_id,omitempty"`
+	PNRID         string        `json:"pnr_id,omitempty"`
+	HoldExpiresAt *time.Time    `json:"hold_expires_at,omitempty"`
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
 }
 
-// BookingSeat represents a seat in a booking
+// BookingSeat represents a seat in a booking. Price is the total paid: the
+// base price less the discount plus the taxes and fees.
 type BookingSeat struct {
-	ID        string    `json:"id"`
-	BookingID string    `json:"booking_id"`
-	SeatID    string    `json:"seat_id"`
-	Price     float64   `json:"price"`
-	Currency  string    `json:"currency"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID          string            `json:"id"`
+	BookingID   string            `json:"booking_id"`
+	SeatID      string            `json:"seat_id"`
+	PassengerID *int              `json:"passenger_id,omitempty"`
+	BasePrice   Money             `json:"base_price"`
+	Discount    Money             `json:"discount"`
+	Taxes       []*BookingSeatTax `json:"taxes"`
+	Price       Money             `json:"price"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
 }
 
-// BookingWithDetails represents a booking with its flight and seats
+// BookingWithDetails represents a booking with its flight, seats, ancillaries and payments
 type BookingWithDetails struct {
-	Booking *Booking      `json:"booking"`
-	Flight  *Flight       `json:"flight"`
-	Seats   []*BookingSeat `json:"seats"`
-	Total   float64       `json:"total"`
+	Booking     *Booking            `json:"booking"`
+	Flight      *Flight             `json:"flight"`
+	Seats       []*BookingSeat      `json:"seats"`
+	Ancillaries []*BookingAncillary `json:"ancillaries"`
+	Payments    []*Payment          `json:"payments"`
+	Total       Money               `json:"total"`
+}
+
+// BookingStatusChange records a booking moving from one status to another.
+// FromStatus is empty for the status a booking was created with.
+type BookingStatusChange struct {
+	ID         string        `json:"id"`
+	BookingID  string        `json:"booking_id"`
+	FromStatus BookingStatus `json:"from_status,omitempty"`
+	ToStatus   BookingStatus `json:"to_status"`
+	Reason     string        `json:"reason,omitempty"`
+	ChangedBy  *string       `json:"changed_by,omitempty"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+// SeatChange records a booked seat being moved to another seat
+type SeatChange struct {
+	ID              string    `json:"id"`
+	BookingID       string    `json:"booking_id"`
+	BookingSeatID   string    `json:"booking_seat_id"`
+	Type            string    `json:"type"` // change, swap
+	FromSeatID      string    `json:"from_seat_id"`
+	ToSeatID        string    `json:"to_seat_id"`
+	OldPrice        Money     `json:"old_price"`
+	NewPrice        Money     `json:"new_price"`
+	PriceDifference Money     `json:"price_difference"`
+	ChangedBy       *string   `json:"changed_by,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
 }
 
-// NewBooking creates a new booking
+// NewBooking creates a new pending booking
 func NewBooking(userID, flightID string) *Booking {
 	return &Booking{
 		ID:        uuid.New().String(),
 		UserID:    userID,
 		FlightID:  flightID,
-		Status:    "confirmed",
+		Status:    BookingStatusPending,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
 }
 
+// bookingTransitions lists the statuses a booking may move to from each status.
+// Boarded and refunded bookings are final.
+var bookingTransitions = map[BookingStatus][]BookingStatus{
+	BookingStatusPending:   {BookingStatusHeld, BookingStatusConfirmed, BookingStatusCancelled},
+	BookingStatusHeld:      {BookingStatusConfirmed, BookingStatusCancelled},
+	BookingStatusConfirmed: {BookingStatusCheckedIn, BookingStatusCancelled, BookingStatusNoShow},
+	BookingStatusCheckedIn: {BookingStatusBoarded, BookingStatusNoShow},
+	BookingStatusCancelled: {BookingStatusRefunded},
+	BookingStatusNoShow:    {BookingStatusRefunded},
+}
+
+// IsValid reports whether the status is part of the booking lifecycle
+func (s BookingStatus) IsValid() bool {
+	_, ok := bookingTransitions[s]
+	return ok || s == BookingStatusBoarded || s == BookingStatusRefunded
+}
+
+// CanTransitionTo reports whether the booking may move to status
+func (b *Booking) CanTransitionTo(status BookingStatus) bool {
+	for _, next := range bookingTransitions[b.Status] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionTo moves the booking to status and returns the change to record.
+// Callers check CanTransitionTo first.
+func (b *Booking) TransitionTo(status BookingStatus, changedBy *string, reason string) *BookingStatusChange {
+	change := &BookingStatusChange{
+		ID:         uuid.New().String(),
+		BookingID:  b.ID,
+		FromStatus: b.Status,
+		ToStatus:   status,
+		Reason:     reason,
+		ChangedBy:  changedBy,
+		CreatedAt:  time.Now(),
+	}
+
+	b.Status = status
+	b.UpdatedAt = change.CreatedAt
+	if status != BookingStatusHeld {
+		b.HoldExpiresAt = nil
+	}
+	return change
+}
+
+// Hold moves the booking to held until ttl from now and returns the change to record
+func (b *Booking) Hold(ttl time.Duration, changedBy *string) *BookingStatusChange {
+	change := b.TransitionTo(BookingStatusHeld, changedBy, "")
+	expiresAt := change.CreatedAt.Add(ttl)
+	b.HoldExpiresAt = &expiresAt
+	return change
+}
+
+// HoldExpired reports whether the booking is held and its hold has run out
+func (b *Booking) HoldExpired(now time.Time) bool {
+	return b.Status == BookingStatusHeld && b.HoldExpiresAt != nil && now.After(*b.HoldExpiresAt)
+}
+
+// InitialStatusChange returns the change recording the status a booking was created with
+func (b *Booking) InitialStatusChange(changedBy *string) *BookingStatusChange {
+	return &BookingStatusChange{
+		ID:        uuid.New().String(),
+		BookingID: b.ID,
+		ToStatus:  b.Status,
+		ChangedBy: changedBy,
+		CreatedAt: b.CreatedAt,
+	}
+}
+
 // NewBookingSeat creates a new booking seat
-func NewBookingSeat(bookingID, seatID string, price float64, currency string) *BookingSeat {
-	return &BookingSeat{
+func NewBookingSeat(bookingID, seatID string, price *PriceBreakdown) *BookingSeat {
+	bookingSeat := &BookingSeat{
 		ID:        uuid.New().String(),
 		BookingID: bookingID,
 		SeatID:    seatID,
-		Price:     price,
-		Currency:  currency,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-}
\ No newline at end of file
+	bookingSeat.SetPrice(price)
+	return bookingSeat
+}
+
+// SetPrice replaces the base price, discount, taxes and total of the booking seat
+func (s *BookingSeat) SetPrice(price *PriceBreakdown) {
+	s.BasePrice = price.Base
+	s.Discount = price.Discount
+	s.Price = price.Total
+	s.Taxes = make([]*BookingSeatTax, 0, len(price.Taxes))
+	for _, tax := range price.Taxes {
+		s.Taxes = append(s.Taxes, NewBookingSeatTax(s.ID, tax))
+	}
+}
+
+// NewSeatChange creates a seat change record for a booked seat moving to toSeatID at newPrice
+func NewSeatChange(bookingSeat *BookingSeat, changeType, toSeatID string, newPrice Money, changedBy *string) *SeatChange {
+	return &SeatChange{
+		ID:              uuid.New().String(),
+		BookingID:       bookingSeat.BookingID,
+		BookingSeatID:   bookingSeat.ID,
+		Type:            changeType,
+		FromSeatID:      bookingSeat.SeatID,
+		ToSeatID:        toSeatID,
+		OldPrice:        bookingSeat.Price,
+		NewPrice:        newPrice,
+		PriceDifference: newPrice.Sub(bookingSeat.Price),
+		ChangedBy:       changedBy,
+		CreatedAt:       time.Now(),
+	}
+}
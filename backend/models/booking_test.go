@@ -0,0 +1,89 @@
+package models
+
+import "testing"
+
+func TestBookingTransitions(t *testing.T) {
+	allowed := map[BookingStatus][]BookingStatus{
+		BookingStatusPending:   {BookingStatusHeld, BookingStatusConfirmed, BookingStatusCancelled},
+		BookingStatusHeld:      {BookingStatusConfirmed, BookingStatusCancelled},
+		BookingStatusConfirmed: {BookingStatusCheckedIn, BookingStatusCancelled, BookingStatusNoShow},
+		BookingStatusCheckedIn: {BookingStatusBoarded, BookingStatusNoShow},
+		BookingStatusBoarded:   {},
+		BookingStatusCancelled: {BookingStatusRefunded},
+		BookingStatusNoShow:    {BookingStatusRefunded},
+		BookingStatusRefunded:  {},
+	}
+
+	statuses := []BookingStatus{
+		BookingStatusPending,
+		BookingStatusHeld,
+		BookingStatusConfirmed,
+		BookingStatusCheckedIn,
+		BookingStatusBoarded,
+		BookingStatusCancelled,
+		BookingStatusNoShow,
+		BookingStatusRefunded,
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			want := false
+			for _, next := range allowed[from] {
+				if next == to {
+					want = true
+				}
+			}
+
+			t.Run(string(from)+"->"+string(to), func(t *testing.T) {
+				booking := &Booking{Status: from}
+				if got := booking.CanTransitionTo(to); got != want {
+					t.Errorf("CanTransitionTo(%s) from %s = %v, want %v", to, from, got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestBookingStatusIsValid(t *testing.T) {
+	tests := []struct {
+		status BookingStatus
+		want   bool
+	}{
+		{BookingStatusPending, true},
+		{BookingStatusHeld, true},
+		{BookingStatusConfirmed, true},
+		{BookingStatusCheckedIn, true},
+		{BookingStatusBoarded, true},
+		{BookingStatusCancelled, true},
+		{BookingStatusNoShow, true},
+		{BookingStatusRefunded, true},
+		{"", false},
+		{"CONFIRMED", false},
+		{"expired", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			if got := tt.status.IsValid(); got != tt.want {
+				t.Errorf("BookingStatus(%q).IsValid() = %v, want %v", tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransitionTo(t *testing.T) {
+	booking := NewBooking("user", "flight")
+	changedBy := "agent"
+
+	change := booking.TransitionTo(BookingStatusConfirmed, &changedBy, "paid")
+	if booking.Status != BookingStatusConfirmed {
+		t.Errorf("Status = %s, want %s", booking.Status, BookingStatusConfirmed)
+	}
+	if change.FromStatus != BookingStatusPending || change.ToStatus != BookingStatusConfirmed {
+		t.Errorf("change = %s -> %s, want %s -> %s",
+			change.FromStatus, change.ToStatus, BookingStatusPending, BookingStatusConfirmed)
+	}
+	if change.BookingID != booking.ID || change.Reason != "paid" || change.ChangedBy != &changedBy {
+		t.Errorf("change = %+v, does not record the booking, reason and user", change)
+	}
+}
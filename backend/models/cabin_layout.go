@@ -0,0 +1,151 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Seat position characteristics derived from the cabin layout
+const (
+	CharacteristicWindow = "W"
+	CharacteristicAisle  = "A"
+	CharacteristicMiddle = "9"
+)
+
+// CabinLayout describes the seat columns of a cabin as groups separated by aisles
+type CabinLayout struct {
+	Groups [][]string `json:"groups"`
+}
+
+// ParseCabinLayout parses a cabin's seat columns. Groups may be separated by "|"
+// ("ABC|DEF") or by AISLE markers in a comma-separated list
+// ("LEFT_SIDE,A,B,C,AISLE,D,E,F,RIGHT_SIDE"); a list without aisles is a single group.
+func ParseCabinLayout(seatColumns string) (*CabinLayout, error) {
+	layout := &CabinLayout{Groups: [][]string{}}
+	group := []string{}
+	seen := map[string]bool{}
+
+	closeGroup := func() {
+		if len(group) > 0 {
+			layout.Groups = append(layout.Groups, group)
+			group = []string{}
+		}
+	}
+
+	for _, token := range strings.Split(seatColumns, ",") {
+		token = strings.ToUpper(strings.TrimSpace(token))
+		switch token {
+		case "", ColumnLeftSide, ColumnRightSide:
+			continue
+		case ColumnAisle:
+			closeGroup()
+			continue
+		}
+
+		for i, part := range strings.Split(token, "|") {
+			if i > 0 {
+				closeGroup()
+			}
+			for _, r := range part {
+				if r < 'A' || r > 'Z' {
+					return nil, fmt.Errorf("seat column %q must be a letter", string(r))
+				}
+				column := string(r)
+				if seen[column] {
+					return nil, fmt.Errorf("seat column %s appears more than once", column)
+				}
+				seen[column] = true
+				group = append(group, column)
+			}
+		}
+	}
+	closeGroup()
+
+	if len(layout.Groups) == 0 {
+		return nil, fmt.Errorf("layout has no seat columns")
+	}
+
+	return layout, nil
+}
+
+// Columns returns every seat column of the layout from left to right
+func (l *CabinLayout) Columns() []string {
+	columns := []string{}
+	for _, group := range l.Groups {
+		columns = append(columns, group...)
+	}
+	return columns
+}
+
+// SeatColumns returns the columns with side and aisle markers, as used in seat maps
+func (l *CabinLayout) SeatColumns() []string {
+	columns := []string{ColumnLeftSide}
+	for i, group := range l.Groups {
+		if i > 0 {
+			columns = append(columns, ColumnAisle)
+		}
+		columns = append(columns, group...)
+	}
+	return append(columns, ColumnRightSide)
+}
+
+// String returns the layout in group notation, e.g. "ABC|DEF"
+func (l *CabinLayout) String() string {
+	groups := make([]string, 0, len(l.Groups))
+	for _, group := range l.Groups {
+		groups = append(groups, strings.Join(group, ""))
+	}
+	return strings.Join(groups, "|")
+}
+
+// Position returns the window, aisle and middle characteristics of a column.
+// A column can be both window and aisle, e.g. a single seat by the window.
+func (l *CabinLayout) Position(column string) []string {
+	for g, group := range l.Groups {
+		for i, col := range group {
+			if col != column {
+				continue
+			}
+
+			position := []string{}
+			if (g == 0 && i == 0) || (g == len(l.Groups)-1 && i == len(group)-1) {
+				position = append(position, CharacteristicWindow)
+			}
+			if (i == 0 && g > 0) || (i == len(group)-1 && g < len(l.Groups)-1) {
+				position = append(position, CharacteristicAisle)
+			}
+			if len(position) == 0 {
+				position = append(position, CharacteristicMiddle)
+			}
+			return position
+		}
+	}
+	return nil
+}
+
+// SeatCharacteristics returns the characteristics of a seat in the layout. The
+// window, aisle and middle characteristics come from the seat's column rather
+// than the stored characteristics, so seat maps, offers and bookings agree.
+func (l *CabinLayout) SeatCharacteristics(seat *Seat) []string {
+	characteristics := []string{}
+	if _, column, ok := ParseSeatCode(seat.Code); ok {
+		characteristics = append(characteristics, l.Position(column)...)
+	}
+
+	for _, characteristic := range strings.Split(seat.SeatCharacteristics, ",") {
+		characteristic = strings.TrimSpace(characteristic)
+		if characteristic != "" && !IsPositionCharacteristic(characteristic) {
+			characteristics = append(characteristics, characteristic)
+		}
+	}
+	return characteristics
+}
+
+// IsPositionCharacteristic reports whether a characteristic describes the seat position
+func IsPositionCharacteristic(characteristic string) bool {
+	switch characteristic {
+	case CharacteristicWindow, CharacteristicAisle, CharacteristicMiddle:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExchangeRate is the number of units of QuoteCurrency one unit of BaseCurrency
+// buys from EffectiveFrom until a later rate for the pair takes effect
+type ExchangeRate struct {
+	ID            string    `json:"id"`
+	BaseCurrency  string    `json:"base_currency"`
+	QuoteCurrency string    `json:"quote_currency"`
+	Rate          string    `json:"rate"`
+	EffectiveFrom time.Time `json:"effective_from"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// NewExchangeRate creates a new exchange rate
+func NewExchangeRate(baseCurrency, quoteCurrency, rate string, effectiveFrom time.Time) *ExchangeRate {
+	return &ExchangeRate{
+		ID:            uuid.New().String(),
+		BaseCurrency:  baseCurrency,
+		QuoteCurrency: quoteCurrency,
+		Rate:          rate,
+		EffectiveFrom: effectiveFrom,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// Value returns the rate as an exact rational number
+func (r *ExchangeRate) Value() (*big.Rat, error) {
+	if !decimalPattern.MatchString(r.Rate) {
+		return nil, fmt.Errorf("invalid exchange rate %q", r.Rate)
+	}
+
+	value, ok := new(big.Rat).SetString(r.Rate)
+	if !ok || value.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid exchange rate %q", r.Rate)
+	}
+	return value, nil
+}
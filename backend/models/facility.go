@@ -0,0 +1,82 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cabin facility types. Space facilities occupy seat columns in a facility row
+// next to a seat row; row markers decorate the sides of a seat row.
+const (
+	FacilityTypeLavatory  = "LAVATORY"
+	FacilityTypeGalley    = "GALLEY"
+	FacilityTypeCloset    = "CLOSET"
+	FacilityTypeStairs    = "STAIRS"
+	FacilityTypeExitLeft  = "EXIT_LEFT"
+	FacilityTypeExitRight = "EXIT_RIGHT"
+	FacilityTypeWing      = "WING"
+	FacilityTypeBulkhead  = "BULKHEAD"
+)
+
+// Positions of a space facility relative to its row
+const (
+	FacilityPositionFront = "FRONT"
+	FacilityPositionRear  = "REAR"
+)
+
+// CabinFacility is a non-seat feature of a cabin such as a lavatory, galley, exit or wing
+type CabinFacility struct {
+	ID        string    `json:"id"`
+	CabinID   string    `json:"cabin_id"`
+	Type      string    `json:"type"`
+	RowNumber int       `json:"row_number"`
+	Position  string    `json:"position"`
+	Columns   string    `json:"columns"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewCabinFacility creates a new cabin facility
+func NewCabinFacility(cabinID, facilityType string, rowNumber int, position, columns string) *CabinFacility {
+	return &CabinFacility{
+		ID:        uuid.New().String(),
+		CabinID:   cabinID,
+		Type:      facilityType,
+		RowNumber: rowNumber,
+		Position:  position,
+		Columns:   columns,
+		CreatedAt: time.Now(),
+	}
+}
+
+// IsRowMarker reports whether the facility decorates a seat row rather than occupying space
+func (f *CabinFacility) IsRowMarker() bool {
+	switch f.Type {
+	case FacilityTypeExitLeft, FacilityTypeExitRight, FacilityTypeWing, FacilityTypeBulkhead:
+		return true
+	}
+	return false
+}
+
+// ColumnList returns the seat columns the facility occupies
+func (f *CabinFacility) ColumnList() []string {
+	columns := []string{}
+	for _, column := range strings.Split(f.Columns, ",") {
+		column = strings.TrimSpace(column)
+		if column != "" {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// IsValidFacilityType reports whether facilityType is a known cabin facility type
+func IsValidFacilityType(facilityType string) bool {
+	switch facilityType {
+	case FacilityTypeLavatory, FacilityTypeGalley, FacilityTypeCloset, FacilityTypeStairs,
+		FacilityTypeExitLeft, FacilityTypeExitRight, FacilityTypeWing, FacilityTypeBulkhead:
+		return true
+	}
+	return false
+}
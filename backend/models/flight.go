@@ -31,4 +31,26 @@ type FlightWithDetails struct {
 	Flight   *Flight   `json:"flight"`
 	Aircraft *Aircraft `json:"aircraft"`
 	Cabins   []*Cabin  `json:"cabins"`
-}
\ No newline at end of file
+}
+
+// FlightFilter holds the optional criteria for searching flights.
+// Empty fields are not filtered on.
+type FlightFilter struct {
+	Origin        string
+	Destination   string
+	DepartureFrom *time.Time // inclusive
+	DepartureTo   *time.Time // exclusive
+	AirlineCode   string     // matches the marketing or operating airline
+	FlightNumber  string
+	ItineraryID   string // must be a UUID
+	Limit         int
+	Offset        int
+}
+
+// FlightSearchResult is a page of flights matching a FlightFilter
+type FlightSearchResult struct {
+	Flights  []*Flight `json:"flights"`
+	Total    int       `json:"total"`
+	Page     int       `json:"page"`
+	PageSize int       `json:"page_size"`
+}
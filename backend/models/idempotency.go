@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+)
+
+// IdempotencyRecord stores the response to a request made with an Idempotency-Key,
+// so a retried request gets the same response instead of being processed again
+type IdempotencyRecord struct {
+	Key            string    `json:"key"`
+	UserID         string    `json:"user_id"`
+	Method         string    `json:"method"`
+	Path           string    `json:"path"`
+	Fingerprint    string    `json:"fingerprint"`
+	ResponseStatus int       `json:"response_status"`
+	ResponseBody   []byte    `json:"-"`
+	ContentType    string    `json:"content_type"`
+	CreatedAt      time.Time `json:"created_at"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+// NewIdempotencyRecord creates a record for a request that is being processed
+func NewIdempotencyRecord(userID, key, method, path, fingerprint string, ttl time.Duration) *IdempotencyRecord {
+	now := time.Now()
+	return &IdempotencyRecord{
+		Key:         key,
+		UserID:      userID,
+		Method:      method,
+		Path:        path,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}
+}
+
+// Completed reports whether the response to the request has been stored
+func (r *IdempotencyRecord) Completed() bool {
+	return r.ResponseStatus != 0
+}
@@ -0,0 +1,51 @@
+package models
+
+// Layout issue codes reported by the layout consistency check
+const (
+	IssueCabinOverlap          = "cabin_overlap"
+	IssueCabinLayoutInvalid    = "cabin_layout_invalid"
+	IssueCabinDeckInvalid      = "cabin_deck_invalid"
+	IssueCabinAircraftMismatch = "cabin_aircraft_mismatch"
+	IssueRowOutOfRange         = "row_out_of_range"
+	IssueRowDuplicate          = "row_duplicate"
+	IssueRowSeatCodesMismatch  = "row_seat_codes_mismatch"
+	IssueSeatCodeInvalid       = "seat_code_invalid"
+	IssueSeatRowMismatch       = "seat_row_mismatch"
+	IssueSeatColumnUnknown     = "seat_column_unknown"
+	IssueSeatDuplicate         = "seat_duplicate"
+	IssueSeatSegmentMismatch   = "seat_segment_mismatch"
+	IssueSeatSlotAvailable     = "seat_slot_available"
+	IssueSeatMissingPrice      = "seat_missing_price"
+)
+
+// LayoutIssue is a single inconsistency found in the seat layout data
+type LayoutIssue struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	SegmentID string `json:"segment_id,omitempty"`
+	CabinID   string `json:"cabin_id,omitempty"`
+	RowID     string `json:"row_id,omitempty"`
+	SeatID    string `json:"seat_id,omitempty"`
+	Fixable   bool   `json:"fixable"`
+	Fixed     bool   `json:"fixed"`
+}
+
+// LayoutReport is the result of a layout consistency check
+type LayoutReport struct {
+	SegmentID     string         `json:"segment_id,omitempty"`
+	CabinsChecked int            `json:"cabins_checked"`
+	RowsChecked   int            `json:"rows_checked"`
+	SeatsChecked  int            `json:"seats_checked"`
+	Issues        []*LayoutIssue `json:"issues"`
+}
+
+// Unresolved returns the number of issues that were not fixed
+func (r *LayoutReport) Unresolved() int {
+	count := 0
+	for _, issue := range r.Issues {
+		if !issue.Fixed {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,206 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// currencyPattern matches ISO 4217 alphabetic currency codes
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// decimalPattern matches a plain decimal amount such as "65", "65.5" or "-0.125"
+var decimalPattern = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// currencyMinorUnits lists the ISO 4217 currencies whose minor unit is not 2
+var currencyMinorUnits = map[string]int{
+	"BHD": 3, "BIF": 0, "CLF": 4, "CLP": 0, "DJF": 0, "GNF": 0, "IQD": 3,
+	"ISK": 0, "JOD": 3, "JPY": 0, "KMF": 0, "KRW": 0, "KWD": 3, "LYD": 3,
+	"OMR": 3, "PYG": 0, "RWF": 0, "TND": 3, "UGX": 0, "UYI": 0, "UYW": 4,
+	"VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+}
+
+// MinorUnits returns the number of decimal places of a currency, 2 for most
+func MinorUnits(currency string) int {
+	if units, ok := currencyMinorUnits[currency]; ok {
+		return units
+	}
+	return 2
+}
+
+// IsValidCurrency reports whether code is an ISO 4217 alphabetic currency code
+func IsValidCurrency(code string) bool {
+	return currencyPattern.MatchString(code)
+}
+
+// Money is an amount in the minor units of a currency, e.g. cents for USD or
+// sen for MYR, so amounts are exact. It is written to JSON as
+// {"amount": 65.00, "currency": "MYR"}.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// NewMoney creates an amount from minor units
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// ZeroMoney returns a zero amount in a currency
+func ZeroMoney(currency string) Money {
+	return Money{Currency: currency}
+}
+
+// ParseMoney parses a decimal amount such as "65.00" in a currency. Amounts
+// with more decimal places than the currency has are rounded half away from zero.
+func ParseMoney(amount, currency string) (Money, error) {
+	amount = strings.TrimSpace(amount)
+	if !decimalPattern.MatchString(amount) {
+		return Money{}, fmt.Errorf("invalid amount %q", amount)
+	}
+
+	value, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return Money{}, fmt.Errorf("invalid amount %q", amount)
+	}
+
+	return moneyFromRat(value, currency), nil
+}
+
+// moneyFromRat rounds a major unit amount to the minor units of a currency
+func moneyFromRat(value *big.Rat, currency string) Money {
+	scaled := new(big.Rat).Mul(value, new(big.Rat).SetInt(pow10(MinorUnits(currency))))
+	return Money{Amount: roundRat(scaled), Currency: currency}
+}
+
+// Rat returns the amount in major units
+func (m Money) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(big.NewInt(m.Amount), pow10(MinorUnits(m.Currency)))
+}
+
+// Decimal returns the amount in major units with the currency's decimal places, e.g. "65.00"
+func (m Money) Decimal() string {
+	return m.Rat().FloatString(MinorUnits(m.Currency))
+}
+
+// String returns the amount and currency, e.g. "65.00 MYR"
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+// IsZero reports whether the amount is zero
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// IsPositive reports whether the amount is greater than zero
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+// Add returns the sum of two amounts in the same currency. Adding amounts in
+// different currencies is a programming error and panics; convert them first.
+func (m Money) Add(other Money) Money {
+	m.mustMatch(other, "add")
+	return Money{Amount: m.Amount + other.Amount, Currency: m.Currency}
+}
+
+// Sub returns the difference of two amounts in the same currency. Subtracting
+// amounts in different currencies is a programming error and panics.
+func (m Money) Sub(other Money) Money {
+	m.mustMatch(other, "subtract")
+	return Money{Amount: m.Amount - other.Amount, Currency: m.Currency}
+}
+
+// mustMatch panics if other is not in the same currency as the amount
+func (m Money) mustMatch(other Money, operation string) {
+	if m.Currency != other.Currency {
+		panic(fmt.Sprintf("money: cannot %s %s and %s", operation, m, other))
+	}
+}
+
+// Percent returns percent of the amount, rounded half away from zero
+func (m Money) Percent(percent float64) Money {
+	factor := new(big.Rat)
+	factor.SetFloat64(percent)
+	factor.Quo(factor, big.NewRat(100, 1))
+	return m.Multiply(factor)
+}
+
+// Multiply returns the amount multiplied by factor, rounded half away from zero
+func (m Money) Multiply(factor *big.Rat) Money {
+	product := new(big.Rat).Mul(new(big.Rat).SetInt64(m.Amount), factor)
+	return Money{Amount: roundRat(product), Currency: m.Currency}
+}
+
+// Convert returns the amount in another currency at rate units of that currency
+// per unit of this one, rounded to the other currency's minor units
+func (m Money) Convert(currency string, rate *big.Rat) Money {
+	return moneyFromRat(new(big.Rat).Mul(m.Rat(), rate), currency)
+}
+
+// moneyJSON is the JSON form of Money
+type moneyJSON struct {
+	Amount   json.Number `json:"amount"`
+	Currency string      `json:"currency"`
+}
+
+// MarshalJSON writes the amount in major units
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{Amount: json.Number(m.Decimal()), Currency: m.Currency})
+}
+
+// UnmarshalJSON reads an amount in major units
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var value moneyJSON
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	parsed, err := ParseMoney(value.Amount.String(), value.Currency)
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+	return nil
+}
+
+// SumMoney adds up amounts per currency, in the order the currencies first appear
+func SumMoney(amounts []Money) []Money {
+	totals := []Money{}
+	index := map[string]int{}
+	for _, amount := range amounts {
+		i, ok := index[amount.Currency]
+		if !ok {
+			index[amount.Currency] = len(totals)
+			totals = append(totals, ZeroMoney(amount.Currency))
+			i = len(totals) - 1
+		}
+		totals[i] = totals[i].Add(amount)
+	}
+	return totals
+}
+
+// roundRat rounds a rational number to an integer, half away from zero
+func roundRat(value *big.Rat) int64 {
+	num := new(big.Int).Abs(value.Num())
+	den := value.Denom()
+
+	quotient, remainder := new(big.Int).QuoRem(num, den, new(big.Int))
+	if new(big.Int).Mul(remainder, big.NewInt(2)).Cmp(den) >= 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+
+	if value.Sign() < 0 {
+		quotient.Neg(quotient)
+	}
+	return quotient.Int64()
+}
+
+// pow10 returns 10^n
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
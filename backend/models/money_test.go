@@ -0,0 +1,189 @@
+package models
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestRoundRat(t *testing.T) {
+	tests := []struct {
+		name  string
+		value *big.Rat
+		want  int64
+	}{
+		{"zero", big.NewRat(0, 1), 0},
+		{"integer", big.NewRat(42, 1), 42},
+		{"negative integer", big.NewRat(-42, 1), -42},
+		{"below half", big.NewRat(249, 100), 2},
+		{"half rounds up", big.NewRat(5, 2), 3},
+		{"above half", big.NewRat(251, 100), 3},
+		{"one half", big.NewRat(1, 2), 1},
+		{"negative below half", big.NewRat(-249, 100), -2},
+		{"negative half rounds down", big.NewRat(-5, 2), -3},
+		{"negative above half", big.NewRat(-251, 100), -3},
+		{"negative one half", big.NewRat(-1, 2), -1},
+		{"third", big.NewRat(7, 3), 2},
+		{"two thirds", big.NewRat(5, 3), 2},
+		{"negative third", big.NewRat(-7, 3), -2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := roundRat(tt.value); got != tt.want {
+				t.Errorf("roundRat(%s) = %d, want %d", tt.value.RatString(), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMinorUnits(t *testing.T) {
+	tests := []struct {
+		currency string
+		want     int
+	}{
+		{"USD", 2},
+		{"MYR", 2},
+		{"EUR", 2},
+		{"JPY", 0},
+		{"KRW", 0},
+		{"KWD", 3},
+		{"BHD", 3},
+		{"CLF", 4},
+		{"XXX", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.currency, func(t *testing.T) {
+			if got := MinorUnits(tt.currency); got != tt.want {
+				t.Errorf("MinorUnits(%q) = %d, want %d", tt.currency, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		currency string
+		want     int64
+		wantErr  bool
+	}{
+		{"whole amount", "65", "USD", 6500, false},
+		{"one decimal", "65.5", "USD", 6550, false},
+		{"exact decimals", "65.05", "MYR", 6505, false},
+		{"surrounding spaces", " 12.30 ", "USD", 1230, false},
+		{"half rounds up", "0.125", "USD", 13, false},
+		{"below half rounds down", "0.124", "USD", 12, false},
+		{"negative half rounds down", "-0.125", "USD", -13, false},
+		{"negative below half", "-0.124", "USD", -12, false},
+		{"zero decimals", "1000", "JPY", 1000, false},
+		{"zero decimals half", "1.5", "JPY", 2, false},
+		{"zero decimals negative half", "-1.5", "JPY", -2, false},
+		{"three decimals", "1.234", "KWD", 1234, false},
+		{"three decimals rounded", "1.2345", "KWD", 1235, false},
+		{"four decimals", "1.2345", "CLF", 12345, false},
+		{"four decimals rounded", "1.23456", "CLF", 12346, false},
+		{"empty", "", "USD", 0, true},
+		{"not a number", "abc", "USD", 0, true},
+		{"trailing point", "1.", "USD", 0, true},
+		{"leading point", ".5", "USD", 0, true},
+		{"exponent", "1e3", "USD", 0, true},
+		{"decimal comma", "1,5", "USD", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMoney(tt.amount, tt.currency)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseMoney(%q, %q) = %v, want an error", tt.amount, tt.currency, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseMoney(%q, %q) returned error: %v", tt.amount, tt.currency, err)
+			}
+			if got.Amount != tt.want || got.Currency != tt.currency {
+				t.Errorf("ParseMoney(%q, %q) = %d %s, want %d %s",
+					tt.amount, tt.currency, got.Amount, got.Currency, tt.want, tt.currency)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name     string
+		money    Money
+		currency string
+		rate     *big.Rat
+		want     int64
+	}{
+		{"same rate", NewMoney(6500, "USD"), "MYR", big.NewRat(1, 1), 6500},
+		{"to zero decimals", NewMoney(10000, "USD"), "JPY", big.NewRat(150, 1), 15000},
+		{"to zero decimals rounded", NewMoney(1, "USD"), "JPY", big.NewRat(1505, 10), 2},
+		{"inverse rate", NewMoney(15000, "JPY"), "USD", big.NewRat(1, 150), 10000},
+		{"inverse rate rounded", NewMoney(1000, "JPY"), "USD", big.NewRat(1, 150), 667},
+		{"inverse rate rounds up to a minor unit", NewMoney(1, "JPY"), "USD", big.NewRat(1, 150), 1},
+		{"inverse decimal rate", NewMoney(1000, "MYR"), "USD", big.NewRat(10, 47), 213},
+		{"to three decimals", NewMoney(1000, "USD"), "KWD", big.NewRat(307, 1000), 3070},
+		{"to four decimals", NewMoney(100, "USD"), "CLF", big.NewRat(1, 37), 270},
+		{"from three decimals", NewMoney(3070, "KWD"), "USD", big.NewRat(1000, 307), 1000},
+		{"negative half", NewMoney(-1, "USD"), "MYR", big.NewRat(1, 2), -1},
+		{"negative", NewMoney(-10000, "USD"), "JPY", big.NewRat(150, 1), -15000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.money.Convert(tt.currency, tt.rate)
+			if got.Amount != tt.want || got.Currency != tt.currency {
+				t.Errorf("%v.Convert(%q, %s) = %d %s, want %d %s",
+					tt.money, tt.currency, tt.rate.RatString(), got.Amount, got.Currency, tt.want, tt.currency)
+			}
+		})
+	}
+}
+
+func TestAddSub(t *testing.T) {
+	tests := []struct {
+		name      string
+		money     Money
+		other     Money
+		wantAdd   int64
+		wantSub   int64
+		wantPanic bool
+	}{
+		{"same currency", NewMoney(6500, "MYR"), NewMoney(1500, "MYR"), 8000, 5000, false},
+		{"negative result", NewMoney(1500, "MYR"), NewMoney(6500, "MYR"), 8000, -5000, false},
+		{"zero", NewMoney(6500, "USD"), ZeroMoney("USD"), 6500, 6500, false},
+		{"different currencies", NewMoney(6500, "MYR"), NewMoney(1500, "USD"), 0, 0, true},
+		{"zero in another currency", NewMoney(6500, "MYR"), ZeroMoney("USD"), 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, op := range []struct {
+				name  string
+				apply func(Money, Money) Money
+				want  int64
+			}{
+				{"Add", Money.Add, tt.wantAdd},
+				{"Sub", Money.Sub, tt.wantSub},
+			} {
+				func() {
+					defer func() {
+						if recovered := recover(); (recovered != nil) != tt.wantPanic {
+							t.Errorf("%v.%s(%v) panic = %v, want panic %v", tt.money, op.name, tt.other, recovered, tt.wantPanic)
+						}
+					}()
+
+					got := op.apply(tt.money, tt.other)
+					if got.Amount != op.want || got.Currency != tt.money.Currency {
+						t.Errorf("%v.%s(%v) = %v, want %d %s", tt.money, op.name, tt.other, got, op.want, tt.money.Currency)
+					}
+				}()
+			}
+		})
+	}
+}
@@ -7,7 +7,9 @@ import (
 // Passenger represents a passenger in the system
 type Passenger struct {
 	ID                int       `json:"id" db:"id"`
-	SegmentID         int       `json:"segment_id" db:"segment_id"`
+	SegmentID         string    `json:"segment_id" db:"segment_id"`
+	UserID            *string   `json:"user_id,omitempty" db:"user_id"`
+	PNRID             *string   `json:"pnr_id,omitempty" db:"pnr_id"`
 	PassengerIndex    int       `json:"passenger_index" db:"passenger_index"`
 	PassengerNameNumber string   `json:"passenger_name_number" db:"passenger_name_number"`
 	FirstName         string    `json:"first_name" db:"first_name"`
@@ -23,6 +25,8 @@ type Passenger struct {
 	Postcode          *string   `json:"postcode,omitempty" db:"postcode"`
 	AddressType       *string   `json:"address_type,omitempty" db:"address_type"`
 	DocumentType      *string   `json:"document_type,omitempty" db:"document_type"`
+	DocumentNumber    *string   `json:"document_number,omitempty" db:"document_number"`
+	DocumentExpiry    *time.Time `json:"document_expiry,omitempty" db:"document_expiry"`
 	IssuingCountry    *string   `json:"issuing_country,omitempty" db:"issuing_country"`
 	CountryOfBirth    *string   `json:"country_of_birth,omitempty" db:"country_of_birth"`
 	Nationality       *string   `json:"nationality,omitempty" db:"nationality"`
@@ -48,7 +52,7 @@ type PassengerWithDetails struct {
 }
 
 // NewPassenger creates a new passenger
-func NewPassenger(segmentID, passengerIndex int, passengerNameNumber, firstName, lastName string) *Passenger {
+func NewPassenger(segmentID string, passengerIndex int, passengerNameNumber, firstName, lastName string) *Passenger {
 	return &Passenger{
 		SegmentID:         segmentID,
 		PassengerIndex:    passengerIndex,
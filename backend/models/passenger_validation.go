@@ -0,0 +1,178 @@
+package models
+
+import (
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Passenger genders
+const (
+	GenderMale        = "MALE"
+	GenderFemale      = "FEMALE"
+	GenderUnspecified = "UNSPECIFIED"
+)
+
+// Passenger types
+const (
+	PassengerTypeAdult  = "ADT"
+	PassengerTypeChild  = "CHD"
+	PassengerTypeInfant = "INF"
+)
+
+// Travel document types
+const (
+	DocumentTypePassport     = "P" // Passport
+	DocumentTypeNationalID   = "I" // National identity card
+	DocumentTypeFacilitation = "F" // Approved non-standard facilitation document
+)
+
+// maxNameLength is the longest first or last name accepted
+const maxNameLength = 64
+
+var (
+	// namePattern matches personal names: letters separated by spaces, hyphens, apostrophes or periods
+	namePattern = regexp.MustCompile(`^\p{L}[\p{L}\p{M}]*([ '.\-]+[\p{L}\p{M}]+)*\.?$`)
+	// nameNumberPattern matches passenger name numbers such as "01.01"
+	nameNumberPattern = regexp.MustCompile(`^[0-9]{2}\.[0-9]{2}$`)
+	// phonePattern matches phone numbers with an optional leading + and 6 to 15 digits
+	phonePattern = regexp.MustCompile(`^\+?[0-9]{6,15}$`)
+	// documentNumberPattern matches travel document numbers
+	documentNumberPattern = regexp.MustCompile(`^[A-Z0-9]{5,20}$`)
+	// airlineCodePattern matches two-character IATA and three-letter ICAO airline designators
+	airlineCodePattern = regexp.MustCompile(`^([A-Z0-9]{2}|[A-Z]{3})$`)
+	// loyaltyNumberPattern matches frequent flyer numbers
+	loyaltyNumberPattern = regexp.MustCompile(`^[A-Z0-9]{4,20}$`)
+)
+
+// Validate checks the passenger's fields, returning ValidationErrors naming each invalid field
+func (p *Passenger) Validate(now time.Time) error {
+	var errs ValidationErrors
+
+	if _, err := uuid.Parse(p.SegmentID); err != nil {
+		errs.Add("segment_id", "must be a segment ID")
+	}
+
+	if p.PassengerIndex < 1 {
+		errs.Add("passenger_index", "must be at least 1")
+	}
+
+	if !nameNumberPattern.MatchString(p.PassengerNameNumber) {
+		errs.Add("passenger_name_number", "must look like 01.01")
+	}
+
+	validateName(&errs, "first_name", p.FirstName)
+	validateName(&errs, "last_name", p.LastName)
+
+	if p.DateOfBirth != nil {
+		if p.DateOfBirth.After(now) {
+			errs.Add("date_of_birth", "must not be in the future")
+		} else if p.DateOfBirth.Before(now.AddDate(-130, 0, 0)) {
+			errs.Add("date_of_birth", "must be within the last 130 years")
+		}
+	}
+
+	if p.Gender != nil {
+		switch *p.Gender {
+		case GenderMale, GenderFemale, GenderUnspecified:
+		default:
+			errs.Add("gender", "must be one of %s, %s or %s", GenderMale, GenderFemale, GenderUnspecified)
+		}
+	}
+
+	if p.Type != nil {
+		switch *p.Type {
+		case PassengerTypeAdult, PassengerTypeChild, PassengerTypeInfant:
+		default:
+			errs.Add("type", "must be one of %s, %s or %s", PassengerTypeAdult, PassengerTypeChild, PassengerTypeInfant)
+		}
+	}
+
+	if p.Email != nil {
+		if address, err := mail.ParseAddress(*p.Email); err != nil || address.Address != *p.Email {
+			errs.Add("email", "must be a valid email address")
+		}
+	}
+
+	if p.Phone != nil && !phonePattern.MatchString(*p.Phone) {
+		errs.Add("phone", "must be 6 to 15 digits with an optional leading +")
+	}
+
+	validateCountry(&errs, "country", p.Country)
+	validateCountry(&errs, "issuing_country", p.IssuingCountry)
+	validateCountry(&errs, "country_of_birth", p.CountryOfBirth)
+	validateCountry(&errs, "nationality", p.Nationality)
+
+	if p.DocumentType != nil {
+		switch *p.DocumentType {
+		case DocumentTypePassport, DocumentTypeNationalID, DocumentTypeFacilitation:
+		default:
+			errs.Add("document_type", "must be one of %s, %s or %s", DocumentTypePassport, DocumentTypeNationalID, DocumentTypeFacilitation)
+		}
+	}
+
+	if p.DocumentNumber != nil {
+		if !documentNumberPattern.MatchString(*p.DocumentNumber) {
+			errs.Add("document_number", "must be 5 to 20 letters or digits")
+		}
+		if p.DocumentType == nil {
+			errs.Add("document_type", "is required with a document number")
+		}
+		if p.IssuingCountry == nil {
+			errs.Add("issuing_country", "is required with a document number")
+		}
+	}
+
+	if p.DocumentExpiry != nil {
+		if p.DocumentNumber == nil {
+			errs.Add("document_number", "is required with a document expiry date")
+		}
+		if p.DateOfBirth != nil && !p.DocumentExpiry.After(*p.DateOfBirth) {
+			errs.Add("document_expiry", "must be after the date of birth")
+		}
+	}
+
+	return errs.Err()
+}
+
+// Validate checks the frequent flyer's fields, returning ValidationErrors naming each invalid field
+func (f *FrequentFlyer) Validate() error {
+	var errs ValidationErrors
+
+	if !airlineCodePattern.MatchString(f.Airline) {
+		errs.Add("airline", "must be a 2-character IATA or 3-letter ICAO airline code")
+	}
+
+	if !loyaltyNumberPattern.MatchString(f.Number) {
+		errs.Add("number", "must be 4 to 20 letters or digits")
+	}
+
+	if f.TierLevel != nil && (strings.TrimSpace(*f.TierLevel) == "" || utf8.RuneCountInString(*f.TierLevel) > 50) {
+		errs.Add("tier_level", "must be 1 to 50 characters")
+	}
+
+	return errs.Err()
+}
+
+// validateName checks a required personal name
+func validateName(errs *ValidationErrors, field, name string) {
+	switch {
+	case name == "":
+		errs.Add(field, "is required")
+	case utf8.RuneCountInString(name) > maxNameLength:
+		errs.Add(field, "must be at most %d characters", maxNameLength)
+	case !namePattern.MatchString(name):
+		errs.Add(field, "may only contain letters, spaces, hyphens, apostrophes and periods")
+	}
+}
+
+// validateCountry checks an optional ISO 3166-1 alpha-2 country code
+func validateCountry(errs *ValidationErrors, field string, code *string) {
+	if code != nil && !IsCountryCode(*code) {
+		errs.Add(field, "must be an ISO 3166-1 alpha-2 country code")
+	}
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PaymentStatus is a stage of a payment with the payment provider
+type PaymentStatus string
+
+// Payment statuses
+const (
+	PaymentStatusAuthorized PaymentStatus = "authorized"
+	PaymentStatusCaptured   PaymentStatus = "captured"
+	PaymentStatusVoided     PaymentStatus = "voided"
+	PaymentStatusRefunded   PaymentStatus = "refunded"
+	PaymentStatusDeclined   PaymentStatus = "declined"
+	PaymentStatusFailed     PaymentStatus = "failed"
+)
+
+// Payment is a charge for a booking taken through a payment provider
+type Payment struct {
+	ID                string        `json:"id"`
+	BookingID         string        `json:"booking_id"`
+	Provider          string        `json:"provider"`
+	ProviderReference string        `json:"provider_reference,omitempty"`
+	Status            PaymentStatus `json:"status"`
+	Amount            Money         `json:"amount"`
+	RefundedAmount    Money         `json:"refunded_amount"`
+	FailureReason     string        `json:"failure_reason,omitempty"`
+	CreatedAt         time.Time     `json:"created_at"`
+	UpdatedAt         time.Time     `json:"updated_at"`
+}
+
+// NewPayment creates a payment for a booking that has not been sent to the provider yet
+func NewPayment(bookingID, provider string, amount Money) *Payment {
+	return &Payment{
+		ID:             uuid.New().String(),
+		BookingID:      bookingID,
+		Provider:       provider,
+		Amount:         amount,
+		RefundedAmount: ZeroMoney(amount.Currency),
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+}
+
+// SetStatus updates the status of the payment
+func (p *Payment) SetStatus(status PaymentStatus) {
+	p.Status = status
+	p.UpdatedAt = time.Now()
+}
+
+// Fail marks the payment as declined or failed with the provider's reason
+func (p *Payment) Fail(status PaymentStatus, reason string) {
+	p.FailureReason = reason
+	p.SetStatus(status)
+}
@@ -0,0 +1,120 @@
+package models
+
+import (
+	"crypto/rand"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// recordLocatorAlphabet leaves out I, O, 0 and 1, which are easily confused when read out
+const recordLocatorAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// recordLocatorPattern matches six-character record locators such as "MYK2PA"
+var recordLocatorPattern = regexp.MustCompile(`^[A-Z0-9]{6}$`)
+
+// PNR is a passenger name record: the reservation of a group of passengers
+// travelling together on the segments of an itinerary, identified by a
+// six-character record locator
+type PNR struct {
+	ID            string    `json:"id"`
+	RecordLocator string    `json:"record_locator"`
+	ItineraryID   string    `json:"itinerary_id"`
+	UserID        string    `json:"user_id,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// PNRWithDetails is a PNR with the segments of its itinerary, its passengers and its bookings
+type PNRWithDetails struct {
+	PNR        *PNR            `json:"pnr"`
+	Segments   []*Flight       `json:"segments"`
+	Passengers []*PNRPassenger `json:"passengers"`
+	Bookings   []*PNRBooking   `json:"bookings"`
+}
+
+// PNRPassenger is a traveller on a PNR, identified by their name number, with
+// their passenger record on each segment they travel on
+type PNRPassenger struct {
+	PassengerNameNumber string       `json:"passenger_name_number"`
+	FirstName           string       `json:"first_name"`
+	LastName            string       `json:"last_name"`
+	Segments            []*Passenger `json:"segments"`
+}
+
+// PNRBooking is a booking on a PNR with its seats, each assigned to a passenger
+type PNRBooking struct {
+	Booking *Booking       `json:"booking"`
+	Seats   []*BookingSeat `json:"seats"`
+}
+
+// NewPNR creates a new PNR with a random record locator
+func NewPNR(itineraryID, userID string) (*PNR, error) {
+	locator, err := NewRecordLocator()
+	if err != nil {
+		return nil, err
+	}
+
+	return &PNR{
+		ID:            uuid.New().String(),
+		RecordLocator: locator,
+		ItineraryID:   itineraryID,
+		UserID:        userID,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}, nil
+}
+
+// NewRecordLocator returns a random six-character record locator
+func NewRecordLocator() (string, error) {
+	max := big.NewInt(int64(len(recordLocatorAlphabet)))
+
+	var locator strings.Builder
+	for i := 0; i < 6; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		locator.WriteByte(recordLocatorAlphabet[n.Int64()])
+	}
+	return locator.String(), nil
+}
+
+// NormalizeRecordLocator returns a record locator as it is stored
+func NormalizeRecordLocator(locator string) string {
+	return strings.ToUpper(strings.TrimSpace(locator))
+}
+
+// IsValidRecordLocator reports whether locator is a well-formed record locator
+func IsValidRecordLocator(locator string) bool {
+	return recordLocatorPattern.MatchString(locator)
+}
+
+// GroupPNRPassengers groups the passenger records of a PNR by name number, in name number order
+func GroupPNRPassengers(passengers []*Passenger) []*PNRPassenger {
+	grouped := []*PNRPassenger{}
+	byNameNumber := make(map[string]*PNRPassenger)
+
+	for _, passenger := range passengers {
+		traveller, ok := byNameNumber[passenger.PassengerNameNumber]
+		if !ok {
+			traveller = &PNRPassenger{
+				PassengerNameNumber: passenger.PassengerNameNumber,
+				FirstName:           passenger.FirstName,
+				LastName:            passenger.LastName,
+			}
+			byNameNumber[passenger.PassengerNameNumber] = traveller
+			grouped = append(grouped, traveller)
+		}
+		traveller.Segments = append(traveller.Segments, passenger)
+	}
+
+	sort.Slice(grouped, func(i, j int) bool {
+		return grouped[i].PassengerNameNumber < grouped[j].PassengerNameNumber
+	})
+	return grouped
+}
@@ -0,0 +1,165 @@
+package models
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Promotion discount types
+const (
+	DiscountTypePercent = "percent" // Percent off the base price of each eligible seat
+	DiscountTypeAmount  = "amount"  // Amount off the base price of each eligible seat
+)
+
+var (
+	// promoCodePattern matches promotion codes such as "LEGROOM25"
+	promoCodePattern = regexp.MustCompile(`^[A-Z0-9]{3,20}$`)
+	// routePattern matches an airport code or an ORIGIN-DESTINATION pair
+	routePattern = regexp.MustCompile(`^[A-Z]{3}(-[A-Z]{3})?$`)
+)
+
+// Promotion is a promotional code discounting seats. Characteristics limit the
+// discount to seats with any of them and Routes to flights on any of them; both
+// match everything when empty. A route is either an airport code, matching
+// flights from or to it, or an ORIGIN-DESTINATION pair. UsageLimit caps the
+// number of bookings that may hold the code at once.
+type Promotion struct {
+	ID              string     `json:"id"`
+	Code            string     `json:"code"`
+	Description     string     `json:"description"`
+	DiscountType    string     `json:"discount_type"`
+	Percent         string     `json:"percent,omitempty"`
+	Amount          *Money     `json:"amount,omitempty"`
+	Characteristics []string   `json:"characteristics"`
+	Routes          []string   `json:"routes"`
+	ValidFrom       time.Time  `json:"valid_from"`
+	ValidTo         *time.Time `json:"valid_to,omitempty"`
+	UsageLimit      *int       `json:"usage_limit,omitempty"`
+	UsageCount      int        `json:"usage_count"`
+	Active          bool       `json:"active"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// NewPromotion creates a new active promotion
+func NewPromotion() *Promotion {
+	return &Promotion{
+		ID:              uuid.New().String(),
+		Characteristics: []string{},
+		Routes:          []string{},
+		Active:          true,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+}
+
+// NormalizePromoCode returns a promotion code as it is stored
+func NormalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Validate checks that a promotion is well formed
+func (p *Promotion) Validate() error {
+	if !promoCodePattern.MatchString(p.Code) {
+		return fmt.Errorf("code must be 3 to 20 letters or digits")
+	}
+
+	switch p.DiscountType {
+	case DiscountTypePercent:
+		percent, err := p.PercentValue()
+		if err != nil || percent.Sign() <= 0 || percent.Cmp(big.NewRat(100, 1)) > 0 {
+			return fmt.Errorf("percent must be greater than 0 and at most 100")
+		}
+	case DiscountTypeAmount:
+		if p.Amount == nil || !p.Amount.IsPositive() || !IsValidCurrency(p.Amount.Currency) {
+			return fmt.Errorf("amount must be a positive amount in a valid currency")
+		}
+	default:
+		return fmt.Errorf("discount type must be %q or %q", DiscountTypePercent, DiscountTypeAmount)
+	}
+
+	for _, route := range p.Routes {
+		if !routePattern.MatchString(route) {
+			return fmt.Errorf("invalid route %q, expected an airport code or ORIGIN-DESTINATION", route)
+		}
+	}
+
+	for _, characteristic := range p.Characteristics {
+		if characteristic == "" || strings.Contains(characteristic, ",") {
+			return fmt.Errorf("invalid seat characteristic %q", characteristic)
+		}
+	}
+
+	if p.ValidTo != nil && !p.ValidTo.After(p.ValidFrom) {
+		return fmt.Errorf("valid_to must be after valid_from")
+	}
+
+	if p.UsageLimit != nil && *p.UsageLimit <= 0 {
+		return fmt.Errorf("usage_limit must be positive")
+	}
+
+	return nil
+}
+
+// PercentValue returns the percentage of a percent discount as an exact rational number
+func (p *Promotion) PercentValue() (*big.Rat, error) {
+	if !decimalPattern.MatchString(p.Percent) {
+		return nil, fmt.Errorf("invalid discount percent %q", p.Percent)
+	}
+
+	value, ok := new(big.Rat).SetString(p.Percent)
+	if !ok {
+		return nil, fmt.Errorf("invalid discount percent %q", p.Percent)
+	}
+	return value, nil
+}
+
+// IsValidAt reports whether the promotion is active and within its validity period
+func (p *Promotion) IsValidAt(at time.Time) bool {
+	if !p.Active || at.Before(p.ValidFrom) {
+		return false
+	}
+	return p.ValidTo == nil || at.Before(*p.ValidTo)
+}
+
+// IsExhausted reports whether the promotion has reached its usage limit
+func (p *Promotion) IsExhausted() bool {
+	return p.UsageLimit != nil && p.UsageCount >= *p.UsageLimit
+}
+
+// AppliesToRoute reports whether the promotion covers a flight between two airports
+func (p *Promotion) AppliesToRoute(origin, destination string) bool {
+	if len(p.Routes) == 0 {
+		return true
+	}
+
+	for _, route := range p.Routes {
+		if route == origin || route == destination || route == origin+"-"+destination {
+			return true
+		}
+	}
+	return false
+}
+
+// AppliesToSeat reports whether the promotion discounts a seat that has the
+// given characteristics
+func (p *Promotion) AppliesToSeat(characteristics []string) bool {
+	if len(p.Characteristics) == 0 {
+		return true
+	}
+
+	for _, characteristic := range characteristics {
+		characteristic = strings.TrimSpace(characteristic)
+		for _, eligible := range p.Characteristics {
+			if strings.EqualFold(characteristic, eligible) {
+				return true
+			}
+		}
+	}
+	return false
+}
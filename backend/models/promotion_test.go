@@ -0,0 +1,119 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPromotionAppliesToSeat(t *testing.T) {
+	layout, err := ParseCabinLayout("ABC|DEF")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name            string
+		characteristics []string
+		seat            *Seat
+		want            bool
+	}{
+		{"no characteristics match every seat", nil, &Seat{Code: "3B"}, true},
+		{"window seat", []string{CharacteristicWindow}, &Seat{Code: "3A"}, true},
+		{"aisle seat", []string{CharacteristicAisle}, &Seat{Code: "3D"}, true},
+		{"middle seat is not a window", []string{CharacteristicWindow}, &Seat{Code: "3B"}, false},
+		{"stored window ignored for a middle seat", []string{CharacteristicWindow}, &Seat{Code: "3E", SeatCharacteristics: "W"}, false},
+		{"layout window wins over a stored aisle", []string{CharacteristicWindow}, &Seat{Code: "3F", SeatCharacteristics: "A"}, true},
+		{"stored characteristic", []string{"E"}, &Seat{Code: "3B", SeatCharacteristics: "CH, e"}, true},
+		{"any of several", []string{"L", CharacteristicAisle}, &Seat{Code: "3C"}, true},
+		{"none of several", []string{"L", "E"}, &Seat{Code: "3C", SeatCharacteristics: "CH"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			promotion := &Promotion{Characteristics: tt.characteristics}
+			if got := promotion.AppliesToSeat(layout.SeatCharacteristics(tt.seat)); got != tt.want {
+				t.Errorf("AppliesToSeat(%v) = %v, want %v", layout.SeatCharacteristics(tt.seat), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPromotionAppliesToRoute(t *testing.T) {
+	tests := []struct {
+		name        string
+		routes      []string
+		origin      string
+		destination string
+		want        bool
+	}{
+		{"no routes match every flight", nil, "KUL", "SIN", true},
+		{"origin airport", []string{"KUL"}, "KUL", "SIN", true},
+		{"destination airport", []string{"SIN"}, "KUL", "SIN", true},
+		{"other airport", []string{"BKK"}, "KUL", "SIN", false},
+		{"airport pair", []string{"KUL-SIN"}, "KUL", "SIN", true},
+		{"reversed airport pair", []string{"SIN-KUL"}, "KUL", "SIN", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			promotion := &Promotion{Routes: tt.routes}
+			if got := promotion.AppliesToRoute(tt.origin, tt.destination); got != tt.want {
+				t.Errorf("AppliesToRoute(%s, %s) = %v, want %v", tt.origin, tt.destination, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPromotionIsValidAt(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	tests := []struct {
+		name    string
+		active  bool
+		validTo *time.Time
+		at      time.Time
+		want    bool
+	}{
+		{"before the start", true, &to, from.Add(-time.Second), false},
+		{"at the start", true, &to, from, true},
+		{"within the period", true, &to, from.AddDate(0, 0, 10), true},
+		{"at the end", true, &to, to, false},
+		{"open ended", true, nil, from.AddDate(5, 0, 0), true},
+		{"inactive", false, nil, from.AddDate(0, 0, 10), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			promotion := &Promotion{Active: tt.active, ValidFrom: from, ValidTo: tt.validTo}
+			if got := promotion.IsValidAt(tt.at); got != tt.want {
+				t.Errorf("IsValidAt(%v) = %v, want %v", tt.at, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPromotionIsExhausted(t *testing.T) {
+	limit := 2
+
+	tests := []struct {
+		name  string
+		limit *int
+		count int
+		want  bool
+	}{
+		{"no limit", nil, 100, false},
+		{"below the limit", &limit, 1, false},
+		{"at the limit", &limit, 2, true},
+		{"above the limit", &limit, 3, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			promotion := &Promotion{UsageLimit: tt.limit, UsageCount: tt.count}
+			if got := promotion.IsExhausted(); got != tt.want {
+				t.Errorf("IsExhausted() with %d uses = %v, want %v", tt.count, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Seat refund indicators
+const (
+	RefundIndicatorRefundable    = "R"
+	RefundIndicatorNonRefundable = "N"
+)
+
+// Refund reasons explaining how a seat refund was computed
+const (
+	RefundReasonPolicy        = "policy"
+	RefundReasonNonRefundable = "non_refundable"
+	RefundReasonFreeOfCharge  = "free_of_charge"
+)
+
+// SeatRefund is the refund of a single booked seat on cancellation
+type SeatRefund struct {
+	ID              string    `json:"id"`
+	BookingID       string    `json:"booking_id"`
+	BookingSeatID   string    `json:"booking_seat_id"`
+	SeatID          string    `json:"seat_id"`
+	RefundIndicator string    `json:"refund_indicator"`
+	Price           Money     `json:"price"`
+	Percent         float64   `json:"percent"`
+	Amount          Money     `json:"amount"`
+	Reason          string    `json:"reason"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// RefundBreakdown is the refund of a cancelled booking, per seat and in total per currency
+type RefundBreakdown struct {
+	BookingID            string        `json:"booking_id"`
+	Status               BookingStatus `json:"status"`
+	HoursBeforeDeparture float64       `json:"hours_before_departure"`
+	Seats                []*SeatRefund `json:"seats"`
+	Totals               []Money       `json:"totals"`
+}
+
+// NewSeatRefund creates the refund of a booked seat for the given percentage of its price
+func NewSeatRefund(bookingSeat *BookingSeat, refundIndicator string, percent float64, reason string) *SeatRefund {
+	return &SeatRefund{
+		ID:              uuid.New().String(),
+		BookingID:       bookingSeat.BookingID,
+		BookingSeatID:   bookingSeat.ID,
+		SeatID:          bookingSeat.SeatID,
+		RefundIndicator: refundIndicator,
+		Price:           bookingSeat.Price,
+		Percent:         percent,
+		Amount:          bookingSeat.Price.Percent(percent),
+		Reason:          reason,
+		CreatedAt:       time.Now(),
+	}
+}
+
+// NewRefundBreakdown sums seat refunds per currency
+func NewRefundBreakdown(booking *Booking, hoursBeforeDeparture float64, refunds []*SeatRefund) *RefundBreakdown {
+	amounts := make([]Money, 0, len(refunds))
+	for _, refund := range refunds {
+		amounts = append(amounts, refund.Amount)
+	}
+
+	return &RefundBreakdown{
+		BookingID:            booking.ID,
+		Status:               booking.Status,
+		HoursBeforeDeparture: hoursBeforeDeparture,
+		Seats:                refunds,
+		Totals:               SumMoney(amounts),
+	}
+}
@@ -1,7 +1,10 @@
 package models
 
 import (
+	"strings"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 // SeatRow represents a row of seats in an aircraft cabin
@@ -19,3 +22,27 @@ type SeatRowWithSeats struct {
 	*SeatRow
 	Seats []*SeatWithPrice `json:"seats"`
 }
+
+// NewSeatRow creates a new seat row
+func NewSeatRow(cabinID string, rowNumber int, seatCodes string) *SeatRow {
+	return &SeatRow{
+		ID:        uuid.New().String(),
+		CabinID:   cabinID,
+		RowNumber: rowNumber,
+		SeatCodes: seatCodes,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+// Columns returns the seat columns present in the row
+func (r *SeatRow) Columns() []string {
+	columns := []string{}
+	for _, column := range strings.Split(r.SeatCodes, ",") {
+		column = strings.TrimSpace(column)
+		if column != "" {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
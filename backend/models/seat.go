@@ -1,9 +1,29 @@
 package models
 
 import (
+	"regexp"
+	"strconv"
 	"time"
+
+	"github.com/google/uuid"
+)
+
+// Storefront slot codes
+const (
+	SlotCodeSeat  = "SEAT"
+	SlotCodeBlank = "BLANK"
+	SlotCodeAisle = "AISLE"
+)
+
+// Seat price types. Dynamic prices are computed by the pricing engine and not stored.
+const (
+	SeatPriceTypeStandard = "standard"
+	SeatPriceTypeDynamic  = "dynamic"
 )
 
+// seatCodePattern matches seat codes such as "4A" or "32K"
+var seatCodePattern = regexp.MustCompile(`^([0-9]+)([A-Z])$`)
+
 // Seat represents a seat in an aircraft cabin
 type Seat struct {
 	ID                  string    `json:"id"`
@@ -27,15 +47,57 @@ type Seat struct {
 
 // SeatPrice represents the price of a seat
 type SeatPrice struct {
-	ID       string  `json:"id"`
-	SeatID   string  `json:"seat_id"`
-	Type     string  `json:"type"`
-	Amount   float64 `json:"amount"`
-	Currency string  `json:"currency"`
+	ID     string `json:"id"`
+	SeatID string `json:"seat_id"`
+	Type   string `json:"type"`
+	Price  Money  `json:"price"`
 }
 
-// SeatWithPrice represents a seat with its price
+// SeatWithPrice represents a seat with its price and, when quoted for sale,
+// the taxes and fees charged on it
 type SeatWithPrice struct {
-	Seat  *Seat      `json:"seat"`
-	Price *SeatPrice `json:"price"`
-}
\ No newline at end of file
+	Seat      *Seat           `json:"seat"`
+	Price     *SeatPrice      `json:"price"`
+	Breakdown *PriceBreakdown `json:"breakdown,omitempty"`
+}
+
+// NewSeat creates a new seat
+func NewSeat(rowID, segmentID, code string) *Seat {
+	return &Seat{
+		ID:                 uuid.New().String(),
+		RowID:              rowID,
+		SegmentID:          segmentID,
+		StorefrontSlotCode: SlotCodeSeat,
+		Code:               code,
+		Available:          true,
+		Entitled:           true,
+		FreeOfCharge:       true,
+		CreatedAt:          time.Now(),
+		UpdatedAt:          time.Now(),
+	}
+}
+
+// NewSeatPrice creates a new seat price
+func NewSeatPrice(seatID, priceType string, price Money) *SeatPrice {
+	return &SeatPrice{
+		ID:     uuid.New().String(),
+		SeatID: seatID,
+		Type:   priceType,
+		Price:  price,
+	}
+}
+
+// ParseSeatCode splits a seat code such as "4A" into its row number and column
+func ParseSeatCode(code string) (int, string, bool) {
+	matches := seatCodePattern.FindStringSubmatch(code)
+	if matches == nil {
+		return 0, "", false
+	}
+
+	rowNumber, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return 0, "", false
+	}
+
+	return rowNumber, matches[2], true
+}
@@ -46,6 +46,7 @@ type SeatMap struct {
 // CabinMap represents a cabin in the seat map
 type CabinMap struct {
 	Deck        string       `json:"deck"`
+	Facilities  []string     `json:"facilities,omitempty"`
 	SeatColumns []string     `json:"seatColumns"`
 	SeatRows    []SeatMapRow `json:"seatRows"`
 	FirstRow    int          `json:"firstRow"`
@@ -61,15 +62,31 @@ type SeatMapRow struct {
 
 // SeatMapItem represents a seat in the seat map
 type SeatMapItem struct {
-	SlotCharacteristics []string `json:"slotCharacteristics,omitempty"`
-	StorefrontSlotCode  string   `json:"storefrontSlotCode"`
-	Available           bool     `json:"available"`
-	Code                string   `json:"code,omitempty"`
-	Entitled            bool     `json:"entitled"`
-	FeeWaived           bool     `json:"feeWaived"`
-	FreeOfCharge        bool     `json:"freeOfCharge"`
-	OriginallySelected  bool     `json:"originallySelected"`
-	Designations        []string `json:"designations,omitempty"`
+	SlotCharacteristics []string     `json:"slotCharacteristics,omitempty"`
+	StorefrontSlotCode  string       `json:"storefrontSlotCode"`
+	Available           bool         `json:"available"`
+	Code                string       `json:"code,omitempty"`
+	Entitled            bool         `json:"entitled"`
+	FeeWaived           bool         `json:"feeWaived"`
+	FreeOfCharge        bool         `json:"freeOfCharge"`
+	OriginallySelected  bool         `json:"originallySelected"`
+	Designations        []string     `json:"designations,omitempty"`
+	Prices              *SeatPricing `json:"prices,omitempty"`
+	OriginalPrices      *SeatPricing `json:"originalPrices,omitempty"`
+	Discount            *SeatPricing `json:"discount,omitempty"`
+	Taxes               *SeatPricing `json:"taxes,omitempty"`
+	Total               *SeatPricing `json:"total,omitempty"`
+	TaxBreakdown        []TaxLine    `json:"taxBreakdown,omitempty"`
+}
+
+// SeatPricing lists the price alternatives of a seat; the first is the one offered
+type SeatPricing struct {
+	Alternatives [][]Money `json:"alternatives"`
+}
+
+// NewSeatPricing returns the pricing of a seat offered at a single price
+func NewSeatPricing(price Money) *SeatPricing {
+	return &SeatPricing{Alternatives: [][]Money{{price}}}
 }
 
 // PassengerInfo represents information about a passenger
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tax rule types
+const (
+	TaxTypePercent = "percent" // Rate percent of the seat's base price
+	TaxTypeFixed   = "fixed"   // Amount per chargeable seat
+)
+
+// Airport is an airport and the country it is in
+type Airport struct {
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	CountryCode string `json:"country_code"`
+}
+
+// TaxRule is a tax or fee levied on seat purchases for flights between two
+// countries. An empty origin or destination country matches any country.
+type TaxRule struct {
+	ID                 string     `json:"id"`
+	Code               string     `json:"code"`
+	Name               string     `json:"name"`
+	OriginCountry      string     `json:"origin_country,omitempty"`
+	DestinationCountry string     `json:"destination_country,omitempty"`
+	Type               string     `json:"type"`
+	Rate               string     `json:"rate,omitempty"`
+	Amount             *Money     `json:"amount,omitempty"`
+	EffectiveFrom      time.Time  `json:"effective_from"`
+	EffectiveTo        *time.Time `json:"effective_to,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
+
+// TaxLine is a tax or fee charged on a seat
+type TaxLine struct {
+	Code   string `json:"code"`
+	Name   string `json:"name"`
+	Amount Money  `json:"amount"`
+}
+
+// BookingSeatTax is a tax or fee charged on a booked seat
+type BookingSeatTax struct {
+	ID            string `json:"id"`
+	BookingSeatID string `json:"booking_seat_id"`
+	TaxLine
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PriceBreakdown is the base price of a seat, the promotional discount on it,
+// the taxes and fees charged on the discounted price and the total paid
+type PriceBreakdown struct {
+	Base     Money     `json:"base"`
+	Discount Money     `json:"discount"`
+	Taxes    []TaxLine `json:"taxes"`
+	Total    Money     `json:"total"`
+}
+
+// NewPriceBreakdown totals a base price less its discount and the taxes
+// charged on it, which are all in the same currency
+func NewPriceBreakdown(base, discount Money, taxes []TaxLine) *PriceBreakdown {
+	if taxes == nil {
+		taxes = []TaxLine{}
+	}
+
+	total := base.Sub(discount)
+	for _, tax := range taxes {
+		total = total.Add(tax.Amount)
+	}
+
+	return &PriceBreakdown{Base: base, Discount: discount, Taxes: taxes, Total: total}
+}
+
+// Net returns the base price less the discount, on which taxes are charged
+func (b *PriceBreakdown) Net() Money {
+	return b.Base.Sub(b.Discount)
+}
+
+// TaxTotal returns the sum of the taxes
+func (b *PriceBreakdown) TaxTotal() Money {
+	return b.Total.Sub(b.Net())
+}
+
+// RateValue returns the percentage of a percent rule as an exact rational number
+func (r *TaxRule) RateValue() (*big.Rat, error) {
+	if !decimalPattern.MatchString(r.Rate) {
+		return nil, fmt.Errorf("invalid tax rate %q", r.Rate)
+	}
+
+	value, ok := new(big.Rat).SetString(r.Rate)
+	if !ok || value.Sign() < 0 {
+		return nil, fmt.Errorf("invalid tax rate %q", r.Rate)
+	}
+	return value, nil
+}
+
+// NewBookingSeatTax records a tax charged on a booked seat
+func NewBookingSeatTax(bookingSeatID string, tax TaxLine) *BookingSeatTax {
+	return &BookingSeatTax{
+		ID:            uuid.New().String(),
+		BookingSeatID: bookingSeatID,
+		TaxLine:       tax,
+		CreatedAt:     time.Now(),
+	}
+}
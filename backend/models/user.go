@@ -7,15 +7,42 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// User roles
+const (
+	RolePassenger      = "passenger"
+	RoleCheckInAgent   = "check_in_agent"
+	RoleRevenueManager = "revenue_manager"
+	RoleAdmin          = "admin"
+)
+
 // User represents a user in the system
 type User struct {
-	ID        string    `json:"id"`
-	Email     string    `json:"email"`
-	Password  string    `json:"-"` // Password is not included in JSON responses
-	FirstName string    `json:"first_name"`
-	LastName  string    `json:"last_name"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID                  string     `json:"id"`
+	Email               string     `json:"email"`
+	Password            string     `json:"-"` // Password is not included in JSON responses
+	FirstName           string     `json:"first_name"`
+	LastName            string     `json:"last_name"`
+	Role                string     `json:"role"`
+	EmailVerifiedAt     *time.Time `json:"email_verified_at,omitempty"`
+	FailedLoginAttempts int        `json:"-"` // Consecutive failed logins since the last success
+	LockedUntil         *time.Time `json:"-"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// UserProfile represents a user with the passengers and frequent flyer accounts they manage
+type UserProfile struct {
+	User       *User                   `json:"user"`
+	Passengers []*PassengerWithDetails `json:"passengers"`
+}
+
+// IsValidRole reports whether role is one of the known user roles
+func IsValidRole(role string) bool {
+	switch role {
+	case RolePassenger, RoleCheckInAgent, RoleRevenueManager, RoleAdmin:
+		return true
+	}
+	return false
 }
 
 // NewUser creates a new user with the given details
@@ -33,6 +60,7 @@ func NewUser(email, password, firstName, lastName string) (*User, error) {
 		Password:  string(hashedPassword),
 		FirstName: firstName,
 		LastName:  lastName,
+		Role:      RolePassenger,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -46,6 +74,26 @@ func (u *User) CheckPassword(password string) bool {
 	return err == nil
 }
 
+// IsEmailVerified reports whether the user has confirmed their email address
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// IsLocked reports whether the account is locked out at the given time
+func (u *User) IsLocked(now time.Time) bool {
+	return u.LockedUntil != nil && now.Before(*u.LockedUntil)
+}
+
+// HasRole checks if the user has any of the given roles
+func (u *User) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if u.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
 // UpdatePassword updates the user's password
 func (u *User) UpdatePassword(password string) error {
 	// Hash the new password
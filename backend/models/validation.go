@@ -0,0 +1,37 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldError is a validation failure of a single field
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationErrors are the field errors found while validating a record
+type ValidationErrors []FieldError
+
+// Add records a field error
+func (e *ValidationErrors) Add(field, format string, args ...interface{}) {
+	*e = append(*e, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// Err returns the field errors as an error, or nil if there are none
+func (e ValidationErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Error implements the error interface
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldError := range e {
+		messages[i] = fieldError.Field + ": " + fieldError.Message
+	}
+	return strings.Join(messages, "; ")
+}
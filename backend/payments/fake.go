@@ -0,0 +1,134 @@
+package payments
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// FakeDeclinedPaymentMethod is a payment method the fake provider always declines
+const FakeDeclinedPaymentMethod = "fake_declined"
+
+// fakeAuthorization is an authorization held in memory by the FakeProvider
+type fakeAuthorization struct {
+	amount   models.Money
+	captured models.Money
+	refunded models.Money
+	voided   bool
+}
+
+// FakeProvider keeps payments in memory, for use in development and tests. It
+// approves every payment method except FakeDeclinedPaymentMethod.
+type FakeProvider struct {
+	mu             sync.Mutex
+	authorizations map[string]*fakeAuthorization
+}
+
+// NewFakeProvider creates a new FakeProvider
+func NewFakeProvider() Provider {
+	return &FakeProvider{authorizations: map[string]*fakeAuthorization{}}
+}
+
+// Name returns "fake"
+func (p *FakeProvider) Name() string {
+	return "fake"
+}
+
+// Authorize approves the payment unless the payment method is FakeDeclinedPaymentMethod
+func (p *FakeProvider) Authorize(amount models.Money, paymentMethod, reference string) (string, error) {
+	if paymentMethod == FakeDeclinedPaymentMethod {
+		return "", ErrDeclined
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	providerReference := "fake_" + uuid.New().String()
+	p.authorizations[providerReference] = &fakeAuthorization{
+		amount:   amount,
+		captured: models.ZeroMoney(amount.Currency),
+		refunded: models.ZeroMoney(amount.Currency),
+	}
+
+	zap.L().Info("Fake payment authorized",
+		zap.String("provider_reference", providerReference),
+		zap.String("reference", reference),
+		zap.Stringer("amount", amount))
+
+	return providerReference, nil
+}
+
+// Capture collects up to the authorized amount
+func (p *FakeProvider) Capture(providerReference string, amount models.Money) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	auth, err := p.get(providerReference)
+	if err != nil {
+		return err
+	}
+
+	if auth.voided {
+		return fmt.Errorf("payment %s was voided", providerReference)
+	}
+	if amount.Currency != auth.amount.Currency {
+		return fmt.Errorf("capture in %s of a payment authorized in %s", amount.Currency, auth.amount.Currency)
+	}
+	if total := auth.captured.Add(amount); total.Amount > auth.amount.Amount {
+		return fmt.Errorf("capture of %s exceeds the authorized %s", total, auth.amount)
+	}
+
+	auth.captured = auth.captured.Add(amount)
+	return nil
+}
+
+// Void releases an authorization that was not captured
+func (p *FakeProvider) Void(providerReference string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	auth, err := p.get(providerReference)
+	if err != nil {
+		return err
+	}
+
+	if auth.captured.IsPositive() {
+		return fmt.Errorf("payment %s was already captured", providerReference)
+	}
+
+	auth.voided = true
+	return nil
+}
+
+// Refund returns up to the captured amount
+func (p *FakeProvider) Refund(providerReference string, amount models.Money) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	auth, err := p.get(providerReference)
+	if err != nil {
+		return err
+	}
+
+	if amount.Currency != auth.amount.Currency {
+		return fmt.Errorf("refund in %s of a payment authorized in %s", amount.Currency, auth.amount.Currency)
+	}
+	if total := auth.refunded.Add(amount); total.Amount > auth.captured.Amount {
+		return fmt.Errorf("refund of %s exceeds the captured %s", total, auth.captured)
+	}
+
+	auth.refunded = auth.refunded.Add(amount)
+	return nil
+}
+
+// get returns an authorization by reference. Callers hold the lock.
+func (p *FakeProvider) get(providerReference string) (*fakeAuthorization, error) {
+	auth, ok := p.authorizations[providerReference]
+	if !ok {
+		return nil, fmt.Errorf("unknown payment %s", providerReference)
+	}
+	return auth, nil
+}
@@ -0,0 +1,39 @@
+package payments
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/spf13/viper"
+)
+
+// ErrDeclined is returned when the provider declines to authorize a payment
+var ErrDeclined = errors.New("payment declined")
+
+// Provider authorizes, captures, voids and refunds card payments. Captures and
+// refunds are in the currency the payment was authorized in.
+type Provider interface {
+	// Name identifies the provider in stored payment records
+	Name() string
+	// Authorize reserves amount on the payment method and returns the provider's reference
+	Authorize(amount models.Money, paymentMethod, reference string) (string, error)
+	// Capture collects an authorized amount
+	Capture(providerReference string, amount models.Money) error
+	// Void releases an authorization that was not captured
+	Void(providerReference string) error
+	// Refund returns part or all of a captured amount
+	Refund(providerReference string, amount models.Money) error
+}
+
+// New creates the provider selected by payment.provider ("fake")
+func New() (Provider, error) {
+	provider := viper.GetString("payment.provider")
+
+	switch provider {
+	case "", "fake":
+		return NewFakeProvider(), nil
+	default:
+		return nil, fmt.Errorf("unknown payment provider %q", provider)
+	}
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import "errors"
+
+var (
+	// ErrReferenced is returned when a record cannot be deleted because other records still reference it
+	ErrReferenced = errors.New("record is still referenced by other records")
+	// ErrSeatUnavailable is returned when a seat cannot be claimed because it is no longer available
+	ErrSeatUnavailable = errors.New("seat is not available")
+	// ErrConflict is returned when a record was modified concurrently
+	ErrConflict = errors.New("record was modified concurrently")
+	// ErrDuplicate is returned when a record with the same unique key already exists
+	ErrDuplicate = errors.New("record already exists")
+	// ErrPromotionExhausted is returned when a promotion has reached its usage limit
+	ErrPromotionExhausted = errors.New("promotion usage limit reached")
+)
@@ -151,5 +151,5 @@ func (r *AircraftRepository) Update(aircraft *models.Aircraft) error {
 func (r *AircraftRepository) Delete(id string) error {
 	query := `DELETE FROM aircraft WHERE id = $1`
 	_, err := r.db.Exec(query, id)
-	return err
+	return translateError(err)
 }
@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+)
+
+// AirportRepository is a PostgreSQL implementation of the AirportRepository interface
+type AirportRepository struct {
+	db *sql.DB
+}
+
+// NewAirportRepository creates a new AirportRepository
+func NewAirportRepository(db *sql.DB) repositories.AirportRepository {
+	return &AirportRepository{db: db}
+}
+
+// GetByCode retrieves an airport by its IATA code
+func (r *AirportRepository) GetByCode(code string) (*models.Airport, error) {
+	query := `
+		SELECT code, name, country_code
+		FROM airports
+		WHERE code = $1
+	`
+
+	airport := &models.Airport{}
+	err := r.db.QueryRow(query, code).Scan(
+		&airport.Code,
+		&airport.Name,
+		&airport.CountryCode,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Airport not found
+		}
+		return nil, err
+	}
+
+	return airport, nil
+}
@@ -0,0 +1,260 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+)
+
+// AncillaryRepository is a PostgreSQL implementation of the AncillaryRepository interface
+type AncillaryRepository struct {
+	db *sql.DB
+}
+
+// NewAncillaryRepository creates a new AncillaryRepository
+func NewAncillaryRepository(db *sql.DB) repositories.AncillaryRepository {
+	return &AncillaryRepository{db: db}
+}
+
+// productColumns are the columns scanned by scanProduct
+const productColumns = `id, code, name, category, description, price, currency, active, created_at, updated_at`
+
+// bundleColumns are the columns scanned by scanBundle
+const bundleColumns = `id, code, name, description, seat_characteristics, discount_percent::text, active, created_at, updated_at`
+
+// CreateProduct creates a new ancillary product in the database
+func (r *AncillaryRepository) CreateProduct(product *models.AncillaryProduct) error {
+	query := `
+		INSERT INTO ancillary_products (id, code, name, category, description, price, currency, active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err := r.db.Exec(
+		query,
+		product.ID,
+		product.Code,
+		product.Name,
+		product.Category,
+		product.Description,
+		product.Price.Decimal(),
+		product.Price.Currency,
+		product.Active,
+		product.CreatedAt,
+		product.UpdatedAt,
+	)
+
+	return translateError(err)
+}
+
+// GetProducts retrieves all ancillary products ordered by category and code
+func (r *AncillaryRepository) GetProducts() ([]*models.AncillaryProduct, error) {
+	query := `SELECT ` + productColumns + ` FROM ancillary_products ORDER BY category, code`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := []*models.AncillaryProduct{}
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, product)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+// GetProductByID retrieves an ancillary product by ID
+func (r *AncillaryRepository) GetProductByID(id string) (*models.AncillaryProduct, error) {
+	query := `SELECT ` + productColumns + ` FROM ancillary_products WHERE id = $1`
+
+	product, err := scanProduct(r.db.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Product not found
+		}
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// CreateBundle creates a bundle and links its products in one transaction
+func (r *AncillaryRepository) CreateBundle(bundle *models.Bundle) error {
+	return withTx(r.db, func(tx *sql.Tx) error {
+		query := `
+			INSERT INTO bundles (id, code, name, description, seat_characteristics, discount_percent, active, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		`
+
+		_, err := tx.Exec(
+			query,
+			bundle.ID,
+			bundle.Code,
+			bundle.Name,
+			bundle.Description,
+			strings.Join(bundle.SeatCharacteristics, ","),
+			bundle.DiscountPercent,
+			bundle.Active,
+			bundle.CreatedAt,
+			bundle.UpdatedAt,
+		)
+		if err != nil {
+			return translateError(err)
+		}
+
+		for _, product := range bundle.Products {
+			_, err := tx.Exec(`INSERT INTO bundle_products (bundle_id, product_id) VALUES ($1, $2)`, bundle.ID, product.ID)
+			if err != nil {
+				return translateError(err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetBundles retrieves all bundles with their products, ordered by code
+func (r *AncillaryRepository) GetBundles() ([]*models.Bundle, error) {
+	query := `SELECT ` + bundleColumns + ` FROM bundles ORDER BY code`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bundles := []*models.Bundle{}
+	for rows.Next() {
+		bundle, err := scanBundle(rows)
+		if err != nil {
+			return nil, err
+		}
+		bundles = append(bundles, bundle)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, bundle := range bundles {
+		if bundle.Products, err = r.getBundleProducts(bundle.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return bundles, nil
+}
+
+// GetBundleByID retrieves a bundle with its products by ID
+func (r *AncillaryRepository) GetBundleByID(id string) (*models.Bundle, error) {
+	query := `SELECT ` + bundleColumns + ` FROM bundles WHERE id = $1`
+
+	bundle, err := scanBundle(r.db.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Bundle not found
+		}
+		return nil, err
+	}
+
+	if bundle.Products, err = r.getBundleProducts(bundle.ID); err != nil {
+		return nil, err
+	}
+
+	return bundle, nil
+}
+
+// getBundleProducts retrieves the products of a bundle
+func (r *AncillaryRepository) getBundleProducts(bundleID string) ([]*models.AncillaryProduct, error) {
+	query := `
+		SELECT p.id, p.code, p.name, p.category, p.description, p.price, p.currency, p.active, p.created_at, p.updated_at
+		FROM ancillary_products p
+		JOIN bundle_products bp ON bp.product_id = p.id
+		WHERE bp.bundle_id = $1
+		ORDER BY p.category, p.code
+	`
+
+	rows, err := r.db.Query(query, bundleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := []*models.AncillaryProduct{}
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, product)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+// scanProduct scans a row of productColumns
+func scanProduct(row rowScanner) (*models.AncillaryProduct, error) {
+	product := &models.AncillaryProduct{}
+	var money moneyColumns
+	var currency string
+	err := row.Scan(
+		&product.ID,
+		&product.Code,
+		&product.Name,
+		&product.Category,
+		&product.Description,
+		money.amount(&product.Price),
+		&currency,
+		&product.Active,
+		&product.CreatedAt,
+		&product.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := money.parse(currency); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// scanBundle scans a row of bundleColumns
+func scanBundle(row rowScanner) (*models.Bundle, error) {
+	bundle := &models.Bundle{}
+	var seatCharacteristics string
+	err := row.Scan(
+		&bundle.ID,
+		&bundle.Code,
+		&bundle.Name,
+		&bundle.Description,
+		&seatCharacteristics,
+		&bundle.DiscountPercent,
+		&bundle.Active,
+		&bundle.CreatedAt,
+		&bundle.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle.SeatCharacteristics = splitList(seatCharacteristics)
+	return bundle, nil
+}
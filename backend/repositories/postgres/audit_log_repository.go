@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+)
+
+// AuditLogRepository is a PostgreSQL implementation of the AuditLogRepository interface
+type AuditLogRepository struct {
+	db *sql.DB
+}
+
+// NewAuditLogRepository creates a new AuditLogRepository
+func NewAuditLogRepository(db *sql.DB) repositories.AuditLogRepository {
+	return &AuditLogRepository{db: db}
+}
+
+// Create creates a new audit log entry in the database
+func (r *AuditLogRepository) Create(entry *models.AuditLog) error {
+	query := `
+		INSERT INTO audit_logs (id, user_id, action, ip_address, details, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.db.Exec(
+		query,
+		entry.ID,
+		entry.UserID,
+		entry.Action,
+		entry.IPAddress,
+		entry.Details,
+		entry.CreatedAt,
+	)
+
+	return err
+}
+
+// GetByUserID retrieves audit log entries for a user, newest first
+func (r *AuditLogRepository) GetByUserID(userID string) ([]*models.AuditLog, error) {
+	query := `
+		SELECT id, user_id, action, ip_address, details, created_at
+		FROM audit_logs
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []*models.AuditLog{}
+	for rows.Next() {
+		entry := &models.AuditLog{}
+		err := rows.Scan(
+			&entry.ID,
+			&entry.UserID,
+			&entry.Action,
+			&entry.IPAddress,
+			&entry.Details,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,1003 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+)
+
+// BookingRepository is a PostgreSQL implementation of the BookingRepository interface
+type BookingRepository struct {
+	db *sql.DB
+}
+
+// NewBookingRepository creates a new BookingRepository
+func NewBookingRepository(db *sql.DB) repositories.BookingRepository {
+	return &BookingRepository{db: db}
+}
+
+// execer is implemented by both *sql.DB and *sql.Tx
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// Create creates a new booking in the database
+func (r *BookingRepository) Create(booking *models.Booking) error {
+	return insertBooking(r.db, booking)
+}
+
+// CreateSeat creates a new booking seat and its taxes in the database
+func (r *BookingRepository) CreateSeat(bookingSeat *models.BookingSeat) error {
+	return withTx(r.db, func(tx *sql.Tx) error {
+		return insertBookingSeat(tx, bookingSeat)
+	})
+}
+
+// GetByID retrieves a booking by ID
+func (r *BookingRepository) GetByID(id string) (*models.Booking, error) {
+	query := `
+		SELECT id, COALESCE(user_id::text, ''), flight_id, status, COALESCE(promotion_id::text, ''),
+			COALESCE(pnr_id::text, ''), hold_expires_at, created_at, updated_at
+		FROM bookings
+		WHERE id = $1
+	`
+
+	booking := &models.Booking{}
+	err := r.db.QueryRow(query, id).Scan(
+		&booking.ID,
+		&booking.UserID,
+		&booking.FlightID,
+		&booking.Status,
+		&booking.PromotionID,
+		&booking.PNRID,
+		&booking.HoldExpiresAt,
+		&booking.CreatedAt,
+		&booking.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Booking not found
+		}
+		return nil, err
+	}
+
+	return booking, nil
+}
+
+// GetByUserID retrieves the bookings of a user, newest first
+func (r *BookingRepository) GetByUserID(userID string) ([]*models.Booking, error) {
+	query := `
+		SELECT id, COALESCE(user_id::text, ''), flight_id, status, COALESCE(promotion_id::text, ''),
+			COALESCE(pnr_id::text, ''), hold_expires_at, created_at, updated_at
+		FROM bookings
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bookings := []*models.Booking{}
+	for rows.Next() {
+		booking := &models.Booking{}
+		err := rows.Scan(
+			&booking.ID,
+			&booking.UserID,
+			&booking.FlightID,
+			&booking.Status,
+			&booking.PromotionID,
+			&booking.PNRID,
+			&booking.HoldExpiresAt,
+			&booking.CreatedAt,
+			&booking.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		bookings = append(bookings, booking)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return bookings, nil
+}
+
+// GetExpiredHolds retrieves held bookings whose hold expired before a time
+func (r *BookingRepository) GetExpiredHolds(before time.Time) ([]*models.Booking, error) {
+	query := `
+		SELECT id, COALESCE(user_id::text, ''), flight_id, status, COALESCE(promotion_id::text, ''),
+			COALESCE(pnr_id::text, ''), hold_expires_at, created_at, updated_at
+		FROM bookings
+		WHERE status = $1 AND hold_expires_at < $2
+		ORDER BY hold_expires_at
+	`
+
+	rows, err := r.db.Query(query, models.BookingStatusHeld, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bookings := []*models.Booking{}
+	for rows.Next() {
+		booking := &models.Booking{}
+		err := rows.Scan(
+			&booking.ID,
+			&booking.UserID,
+			&booking.FlightID,
+			&booking.Status,
+			&booking.PromotionID,
+			&booking.PNRID,
+			&booking.HoldExpiresAt,
+			&booking.CreatedAt,
+			&booking.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		bookings = append(bookings, booking)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return bookings, nil
+}
+
+// GetSeatsByBookingID retrieves the seats of a booking
+func (r *BookingRepository) GetSeatsByBookingID(bookingID string) ([]*models.BookingSeat, error) {
+	query := `
+		SELECT id, booking_id, seat_id, passenger_id, base_price, discount, price, currency, created_at, updated_at
+		FROM booking_seats
+		WHERE booking_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(query, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	seats := []*models.BookingSeat{}
+	for rows.Next() {
+		seat := &models.BookingSeat{}
+		var money moneyColumns
+		var currency string
+		err := rows.Scan(
+			&seat.ID,
+			&seat.BookingID,
+			&seat.SeatID,
+			&seat.PassengerID,
+			money.amount(&seat.BasePrice),
+			money.amount(&seat.Discount),
+			money.amount(&seat.Price),
+			&currency,
+			&seat.CreatedAt,
+			&seat.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := money.parse(currency); err != nil {
+			return nil, err
+		}
+		seats = append(seats, seat)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, seat := range seats {
+		if seat.Taxes, err = r.getSeatTaxes(seat.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return seats, nil
+}
+
+// GetSeatByID retrieves a booking seat by ID
+func (r *BookingRepository) GetSeatByID(id string) (*models.BookingSeat, error) {
+	query := `
+		SELECT id, booking_id, seat_id, passenger_id, base_price, discount, price, currency, created_at, updated_at
+		FROM booking_seats
+		WHERE id = $1
+	`
+
+	seat := &models.BookingSeat{}
+	var money moneyColumns
+	var currency string
+	err := r.db.QueryRow(query, id).Scan(
+		&seat.ID,
+		&seat.BookingID,
+		&seat.SeatID,
+		&seat.PassengerID,
+		money.amount(&seat.BasePrice),
+		money.amount(&seat.Discount),
+		money.amount(&seat.Price),
+		&currency,
+		&seat.CreatedAt,
+		&seat.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Booking seat not found
+		}
+		return nil, err
+	}
+
+	if err := money.parse(currency); err != nil {
+		return nil, err
+	}
+
+	if seat.Taxes, err = r.getSeatTaxes(seat.ID); err != nil {
+		return nil, err
+	}
+
+	return seat, nil
+}
+
+// getSeatTaxes retrieves the taxes charged on a booking seat
+func (r *BookingRepository) getSeatTaxes(bookingSeatID string) ([]*models.BookingSeatTax, error) {
+	query := `
+		SELECT id, booking_seat_id, code, name, amount, currency, created_at
+		FROM booking_seat_taxes
+		WHERE booking_seat_id = $1
+		ORDER BY code
+	`
+
+	rows, err := r.db.Query(query, bookingSeatID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	taxes := []*models.BookingSeatTax{}
+	for rows.Next() {
+		tax := &models.BookingSeatTax{}
+		var money moneyColumns
+		var currency string
+		err := rows.Scan(
+			&tax.ID,
+			&tax.BookingSeatID,
+			&tax.Code,
+			&tax.Name,
+			money.amount(&tax.Amount),
+			&currency,
+			&tax.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := money.parse(currency); err != nil {
+			return nil, err
+		}
+		taxes = append(taxes, tax)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return taxes, nil
+}
+
+// Update updates a booking in the database
+func (r *BookingRepository) Update(booking *models.Booking) error {
+	query := `
+		UPDATE bookings
+		SET status = $2, hold_expires_at = $3, updated_at = $4
+		WHERE id = $1
+	`
+
+	_, err := r.db.Exec(query, booking.ID, booking.Status, booking.HoldExpiresAt, booking.UpdatedAt)
+	return err
+}
+
+// Delete deletes a booking by its ID
+func (r *BookingRepository) Delete(id string) error {
+	query := `DELETE FROM bookings WHERE id = $1`
+	_, err := r.db.Exec(query, id)
+	return translateError(err)
+}
+
+// DeleteSeats deletes the seats of a booking
+func (r *BookingRepository) DeleteSeats(bookingID string) error {
+	query := `DELETE FROM booking_seats WHERE booking_id = $1`
+	_, err := r.db.Exec(query, bookingID)
+	return translateError(err)
+}
+
+// CreateWithSeats creates a booking, claims its seats, redeems its promotion
+// and records its status history in one transaction. It fails with
+// ErrSeatUnavailable if any seat has already been taken and with
+// ErrPromotionExhausted if the promotion has reached its usage limit.
+func (r *BookingRepository) CreateWithSeats(booking *models.Booking, seats []*models.BookingSeat, changes []*models.BookingStatusChange) error {
+	return withTx(r.db, func(tx *sql.Tx) error {
+		if err := insertBooking(tx, booking); err != nil {
+			return err
+		}
+
+		if booking.PromotionID != "" {
+			if err := redeemPromotion(tx, booking.PromotionID); err != nil {
+				return err
+			}
+		}
+
+		for _, seat := range seats {
+			if err := claimSeat(tx, seat.SeatID); err != nil {
+				return err
+			}
+			if err := insertBookingSeat(tx, seat); err != nil {
+				return err
+			}
+		}
+
+		for _, change := range changes {
+			if err := insertStatusChange(tx, change); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// UpdateStatus saves a booking status change and records it in one transaction.
+// It fails with ErrConflict if the booking is no longer in the change's from status.
+func (r *BookingRepository) UpdateStatus(booking *models.Booking, change *models.BookingStatusChange) error {
+	return withTx(r.db, func(tx *sql.Tx) error {
+		if err := updateBooking(tx, booking, change.FromStatus); err != nil {
+			return err
+		}
+		return insertStatusChange(tx, change)
+	})
+}
+
+// GetStatusHistory retrieves the status changes of a booking, oldest first
+func (r *BookingRepository) GetStatusHistory(bookingID string) ([]*models.BookingStatusChange, error) {
+	query := `
+		SELECT id, booking_id, COALESCE(from_status, ''), to_status, COALESCE(reason, ''),
+			changed_by, created_at
+		FROM booking_status_history
+		WHERE booking_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(query, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := []*models.BookingStatusChange{}
+	for rows.Next() {
+		change := &models.BookingStatusChange{}
+		err := rows.Scan(
+			&change.ID,
+			&change.BookingID,
+			&change.FromStatus,
+			&change.ToStatus,
+			&change.Reason,
+			&change.ChangedBy,
+			&change.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// Cancel saves a cancelled booking, records the status change, releases its
+// seats and records the seat refunds in one transaction
+func (r *BookingRepository) Cancel(booking *models.Booking, change *models.BookingStatusChange, refunds []*models.SeatRefund) error {
+	return withTx(r.db, func(tx *sql.Tx) error {
+		if err := updateBooking(tx, booking, change.FromStatus); err != nil {
+			return err
+		}
+
+		if err := insertStatusChange(tx, change); err != nil {
+			return err
+		}
+
+		_, err := tx.Exec(`
+			UPDATE seats SET available = true, updated_at = NOW()
+			WHERE id IN (SELECT seat_id FROM booking_seats WHERE booking_id = $1)
+		`, booking.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, refund := range refunds {
+			if err := insertSeatRefund(tx, refund); err != nil {
+				return err
+			}
+		}
+
+		if booking.PromotionID != "" {
+			return releasePromotion(tx, booking.PromotionID)
+		}
+
+		return nil
+	})
+}
+
+// GetRefunds retrieves the seat refunds of a cancelled booking
+func (r *BookingRepository) GetRefunds(bookingID string) ([]*models.SeatRefund, error) {
+	query := `
+		SELECT id, booking_id, booking_seat_id, seat_id, COALESCE(refund_indicator, ''),
+			price, percent, amount, currency, reason, created_at
+		FROM booking_refunds
+		WHERE booking_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(query, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	refunds := []*models.SeatRefund{}
+	for rows.Next() {
+		refund := &models.SeatRefund{}
+		var money moneyColumns
+		var currency string
+		err := rows.Scan(
+			&refund.ID,
+			&refund.BookingID,
+			&refund.BookingSeatID,
+			&refund.SeatID,
+			&refund.RefundIndicator,
+			money.amount(&refund.Price),
+			&refund.Percent,
+			money.amount(&refund.Amount),
+			&currency,
+			&refund.Reason,
+			&refund.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := money.parse(currency); err != nil {
+			return nil, err
+		}
+		refunds = append(refunds, refund)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return refunds, nil
+}
+
+// ChangeSeat moves a booked seat in one transaction: it claims the new seat,
+// releases the old one, saves the booking seat and records the change. It fails
+// with ErrConflict if the booking is no longer in its current status.
+func (r *BookingRepository) ChangeSeat(booking *models.Booking, bookingSeat *models.BookingSeat, change *models.SeatChange) error {
+	return withTx(r.db, func(tx *sql.Tx) error {
+		if err := lockBooking(tx, booking); err != nil {
+			return err
+		}
+		if err := updateBookingSeat(tx, bookingSeat, change.FromSeatID); err != nil {
+			return err
+		}
+		if err := claimSeat(tx, change.ToSeatID); err != nil {
+			return err
+		}
+		if err := releaseSeat(tx, change.FromSeatID); err != nil {
+			return err
+		}
+		return insertSeatChange(tx, change)
+	})
+}
+
+// SwapSeats saves two booking seats that exchanged seats and records the changes in one transaction.
+// Each booking seat must still hold the other's new seat, and the bookings of both
+// seats must still be in their current status.
+func (r *BookingRepository) SwapSeats(bookings []*models.Booking, first, second *models.BookingSeat, changes []*models.SeatChange) error {
+	return withTx(r.db, func(tx *sql.Tx) error {
+		// Lock the bookings in ID order so concurrent swaps cannot deadlock
+		locked := append([]*models.Booking{}, bookings...)
+		sort.Slice(locked, func(i, j int) bool { return locked[i].ID < locked[j].ID })
+		for i, booking := range locked {
+			if i > 0 && booking.ID == locked[i-1].ID {
+				continue
+			}
+			if err := lockBooking(tx, booking); err != nil {
+				return err
+			}
+		}
+
+		if err := updateBookingSeat(tx, first, second.SeatID); err != nil {
+			return err
+		}
+		if err := updateBookingSeat(tx, second, first.SeatID); err != nil {
+			return err
+		}
+		for _, change := range changes {
+			if err := insertSeatChange(tx, change); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetSeatChanges retrieves the seat change history of a booking, oldest first
+func (r *BookingRepository) GetSeatChanges(bookingID string) ([]*models.SeatChange, error) {
+	query := `
+		SELECT id, booking_id, booking_seat_id, type, from_seat_id, to_seat_id,
+			old_price, new_price, price_difference, currency, changed_by, created_at
+		FROM booking_seat_changes
+		WHERE booking_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(query, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := []*models.SeatChange{}
+	for rows.Next() {
+		change := &models.SeatChange{}
+		var money moneyColumns
+		var currency string
+		err := rows.Scan(
+			&change.ID,
+			&change.BookingID,
+			&change.BookingSeatID,
+			&change.Type,
+			&change.FromSeatID,
+			&change.ToSeatID,
+			money.amount(&change.OldPrice),
+			money.amount(&change.NewPrice),
+			money.amount(&change.PriceDifference),
+			&currency,
+			&change.ChangedBy,
+			&change.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := money.parse(currency); err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// AddAncillaries adds ancillaries to a booking that is still in its current
+// status, failing with ErrConflict if the booking was changed in the meantime
+func (r *BookingRepository) AddAncillaries(booking *models.Booking, ancillaries []*models.BookingAncillary) error {
+	return withTx(r.db, func(tx *sql.Tx) error {
+		if err := lockBooking(tx, booking); err != nil {
+			return err
+		}
+
+		query := `
+			INSERT INTO booking_ancillaries (id, booking_id, booking_seat_id, product_id, bundle_id, price, discount, currency, created_at)
+			VALUES ($1, $2, $3, $4, NULLIF($5, '')::uuid, $6, $7, $8, $9)
+		`
+
+		for _, ancillary := range ancillaries {
+			_, err := tx.Exec(
+				query,
+				ancillary.ID,
+				ancillary.BookingID,
+				ancillary.BookingSeatID,
+				ancillary.ProductID,
+				ancillary.BundleID,
+				ancillary.Price.Decimal(),
+				ancillary.Discount.Decimal(),
+				ancillary.Price.Currency,
+				ancillary.CreatedAt,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// lockBooking locks a booking's row for the rest of the transaction, failing with
+// ErrConflict if the booking is gone or no longer in the booking's status
+func lockBooking(tx *sql.Tx, booking *models.Booking) error {
+	var status models.BookingStatus
+	err := tx.QueryRow(`SELECT status FROM bookings WHERE id = $1 FOR UPDATE`, booking.ID).Scan(&status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repositories.ErrConflict
+		}
+		return err
+	}
+
+	if status != booking.Status {
+		return repositories.ErrConflict
+	}
+
+	return nil
+}
+
+// GetAncillaries retrieves the ancillaries of a booking in the order they were added
+func (r *BookingRepository) GetAncillaries(bookingID string) ([]*models.BookingAncillary, error) {
+	query := `
+		SELECT id, booking_id, booking_seat_id, product_id, COALESCE(bundle_id::text, ''),
+			price, discount, currency, created_at
+		FROM booking_ancillaries
+		WHERE booking_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.db.Query(query, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ancillaries := []*models.BookingAncillary{}
+	for rows.Next() {
+		ancillary := &models.BookingAncillary{}
+		var money moneyColumns
+		var currency string
+		err := rows.Scan(
+			&ancillary.ID,
+			&ancillary.BookingID,
+			&ancillary.BookingSeatID,
+			&ancillary.ProductID,
+			&ancillary.BundleID,
+			money.amount(&ancillary.Price),
+			money.amount(&ancillary.Discount),
+			&currency,
+			&ancillary.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := money.parse(currency); err != nil {
+			return nil, err
+		}
+		ancillary.Total = ancillary.Price.Sub(ancillary.Discount)
+		ancillaries = append(ancillaries, ancillary)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ancillaries, nil
+}
+
+// GetByPNRID retrieves the bookings on a PNR in the order they were made
+func (r *BookingRepository) GetByPNRID(pnrID string) ([]*models.Booking, error) {
+	query := `
+		SELECT id, COALESCE(user_id::text, ''), flight_id, status, COALESCE(promotion_id::text, ''),
+			COALESCE(pnr_id::text, ''), hold_expires_at, created_at, updated_at
+		FROM bookings
+		WHERE pnr_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(query, pnrID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bookings := []*models.Booking{}
+	for rows.Next() {
+		booking := &models.Booking{}
+		err := rows.Scan(
+			&booking.ID,
+			&booking.UserID,
+			&booking.FlightID,
+			&booking.Status,
+			&booking.PromotionID,
+			&booking.PNRID,
+			&booking.HoldExpiresAt,
+			&booking.CreatedAt,
+			&booking.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		bookings = append(bookings, booking)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return bookings, nil
+}
+
+// LinkPNR puts a booking on its PNR and assigns the given booking seats to their
+// passengers, failing with ErrConflict if the booking was put on another PNR in
+// the meantime
+func (r *BookingRepository) LinkPNR(booking *models.Booking, seats []*models.BookingSeat) error {
+	return withTx(r.db, func(tx *sql.Tx) error {
+		result, err := tx.Exec(`
+			UPDATE bookings
+			SET pnr_id = $2, updated_at = $3
+			WHERE id = $1 AND (pnr_id IS NULL OR pnr_id = $2)
+		`, booking.ID, booking.PNRID, booking.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if affected == 0 {
+			return repositories.ErrConflict
+		}
+
+		for _, seat := range seats {
+			_, err := tx.Exec(`
+				UPDATE booking_seats
+				SET passenger_id = $2, updated_at = $3
+				WHERE id = $1 AND booking_id = $4
+			`, seat.ID, seat.PassengerID, seat.UpdatedAt, booking.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// insertBooking inserts a booking
+func insertBooking(db execer, booking *models.Booking) error {
+	query := `
+		INSERT INTO bookings (id, user_id, flight_id, status, promotion_id, pnr_id, hold_expires_at, created_at, updated_at)
+		VALUES ($1, NULLIF($2, '')::uuid, $3, $4, NULLIF($5, '')::uuid, NULLIF($6, '')::uuid, $7, $8, $9)
+	`
+
+	_, err := db.Exec(
+		query,
+		booking.ID,
+		booking.UserID,
+		booking.FlightID,
+		booking.Status,
+		booking.PromotionID,
+		booking.PNRID,
+		booking.HoldExpiresAt,
+		booking.CreatedAt,
+		booking.UpdatedAt,
+	)
+
+	return err
+}
+
+// updateBooking updates the status of a booking that is still in previousStatus,
+// failing with ErrConflict if it was changed in the meantime
+func updateBooking(db execer, booking *models.Booking, previousStatus models.BookingStatus) error {
+	query := `
+		UPDATE bookings
+		SET status = $2, hold_expires_at = $3, updated_at = $4
+		WHERE id = $1 AND status = $5
+	`
+
+	result, err := db.Exec(query, booking.ID, booking.Status, booking.HoldExpiresAt, booking.UpdatedAt, previousStatus)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repositories.ErrConflict
+	}
+
+	return nil
+}
+
+// insertStatusChange records a booking status change
+func insertStatusChange(db execer, change *models.BookingStatusChange) error {
+	query := `
+		INSERT INTO booking_status_history (id, booking_id, from_status, to_status, reason, changed_by, created_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, NULLIF($5, ''), $6, $7)
+	`
+
+	_, err := db.Exec(
+		query,
+		change.ID,
+		change.BookingID,
+		change.FromStatus,
+		change.ToStatus,
+		change.Reason,
+		change.ChangedBy,
+		change.CreatedAt,
+	)
+
+	return err
+}
+
+// insertBookingSeat inserts a booking seat and its taxes
+func insertBookingSeat(db execer, seat *models.BookingSeat) error {
+	query := `
+		INSERT INTO booking_seats (id, booking_id, seat_id, passenger_id, base_price, discount, price, currency, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err := db.Exec(
+		query,
+		seat.ID,
+		seat.BookingID,
+		seat.SeatID,
+		seat.PassengerID,
+		seat.BasePrice.Decimal(),
+		seat.Discount.Decimal(),
+		seat.Price.Decimal(),
+		seat.Price.Currency,
+		seat.CreatedAt,
+		seat.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return insertSeatTaxes(db, seat)
+}
+
+// insertSeatTaxes records the taxes charged on a booking seat
+func insertSeatTaxes(db execer, seat *models.BookingSeat) error {
+	query := `
+		INSERT INTO booking_seat_taxes (id, booking_seat_id, code, name, amount, currency, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	for _, tax := range seat.Taxes {
+		_, err := db.Exec(
+			query,
+			tax.ID,
+			tax.BookingSeatID,
+			tax.Code,
+			tax.Name,
+			tax.Amount.Decimal(),
+			tax.Amount.Currency,
+			tax.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateBookingSeat updates the seat, price and taxes of a booking seat that
+// still holds previousSeatID, failing with ErrConflict if it was changed in the
+// meantime
+func updateBookingSeat(db execer, seat *models.BookingSeat, previousSeatID string) error {
+	query := `
+		UPDATE booking_seats
+		SET seat_id = $2, base_price = $3, discount = $4, price = $5, currency = $6, updated_at = $7
+		WHERE id = $1 AND seat_id = $8
+	`
+
+	result, err := db.Exec(
+		query,
+		seat.ID,
+		seat.SeatID,
+		seat.BasePrice.Decimal(),
+		seat.Discount.Decimal(),
+		seat.Price.Decimal(),
+		seat.Price.Currency,
+		seat.UpdatedAt,
+		previousSeatID,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repositories.ErrConflict
+	}
+
+	if _, err := db.Exec(`DELETE FROM booking_seat_taxes WHERE booking_seat_id = $1`, seat.ID); err != nil {
+		return err
+	}
+
+	return insertSeatTaxes(db, seat)
+}
+
+// insertSeatRefund records a seat refund
+func insertSeatRefund(db execer, refund *models.SeatRefund) error {
+	query := `
+		INSERT INTO booking_refunds (
+			id, booking_id, booking_seat_id, seat_id, refund_indicator,
+			price, percent, amount, currency, reason, created_at
+		)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err := db.Exec(
+		query,
+		refund.ID,
+		refund.BookingID,
+		refund.BookingSeatID,
+		refund.SeatID,
+		refund.RefundIndicator,
+		refund.Price.Decimal(),
+		refund.Percent,
+		refund.Amount.Decimal(),
+		refund.Amount.Currency,
+		refund.Reason,
+		refund.CreatedAt,
+	)
+
+	return err
+}
+
+// insertSeatChange records a seat change
+func insertSeatChange(db execer, change *models.SeatChange) error {
+	query := `
+		INSERT INTO booking_seat_changes (
+			id, booking_id, booking_seat_id, type, from_seat_id, to_seat_id,
+			old_price, new_price, price_difference, currency, changed_by, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	_, err := db.Exec(
+		query,
+		change.ID,
+		change.BookingID,
+		change.BookingSeatID,
+		change.Type,
+		change.FromSeatID,
+		change.ToSeatID,
+		change.OldPrice.Decimal(),
+		change.NewPrice.Decimal(),
+		change.PriceDifference.Decimal(),
+		change.NewPrice.Currency,
+		change.ChangedBy,
+		change.CreatedAt,
+	)
+
+	return err
+}
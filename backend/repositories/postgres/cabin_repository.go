@@ -25,7 +25,7 @@ func (r *CabinRepository) Create(cabin *models.Cabin) error {
 			id, aircraft_id, segment_id, deck, first_row, last_row, 
 			seat_columns, created_at, updated_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		VALUES ($1, $2, NULLIF($3, '')::uuid, $4, $5, $6, $7, $8, $9)
 	`
 
 	_, err := r.db.Exec(
@@ -47,7 +47,7 @@ func (r *CabinRepository) Create(cabin *models.Cabin) error {
 // GetByID gets a cabin by its ID
 func (r *CabinRepository) GetByID(id string) (*models.Cabin, error) {
 	query := `
-		SELECT id, aircraft_id, segment_id, deck, first_row, last_row, 
+		SELECT id, aircraft_id, COALESCE(segment_id::text, ''), deck, first_row, last_row, 
 			seat_columns, created_at, updated_at
 		FROM cabins
 		WHERE id = $1
@@ -79,7 +79,7 @@ func (r *CabinRepository) GetByID(id string) (*models.Cabin, error) {
 // GetByAircraftID gets cabins by aircraft ID
 func (r *CabinRepository) GetByAircraftID(aircraftID string) ([]*models.Cabin, error) {
 	query := `
-		SELECT id, aircraft_id, segment_id, deck, first_row, last_row, 
+		SELECT id, aircraft_id, COALESCE(segment_id::text, ''), deck, first_row, last_row, 
 			seat_columns, created_at, updated_at
 		FROM cabins
 		WHERE aircraft_id = $1
@@ -122,7 +122,7 @@ func (r *CabinRepository) GetByAircraftID(aircraftID string) ([]*models.Cabin, e
 // GetBySegmentID gets cabins by segment ID
 func (r *CabinRepository) GetBySegmentID(segmentID string) ([]*models.Cabin, error) {
 	query := `
-		SELECT id, aircraft_id, segment_id, deck, first_row, last_row, 
+		SELECT id, aircraft_id, COALESCE(segment_id::text, ''), deck, first_row, last_row, 
 			seat_columns, created_at, updated_at
 		FROM cabins
 		WHERE segment_id = $1
@@ -165,7 +165,7 @@ func (r *CabinRepository) GetBySegmentID(segmentID string) ([]*models.Cabin, err
 // GetAll gets all cabins
 func (r *CabinRepository) GetAll() ([]*models.Cabin, error) {
 	query := `
-		SELECT id, aircraft_id, segment_id, deck, first_row, last_row, 
+		SELECT id, aircraft_id, COALESCE(segment_id::text, ''), deck, first_row, last_row, 
 			seat_columns, created_at, updated_at
 		FROM cabins
 		ORDER BY aircraft_id, deck, first_row
@@ -208,7 +208,7 @@ func (r *CabinRepository) GetAll() ([]*models.Cabin, error) {
 func (r *CabinRepository) Update(cabin *models.Cabin) error {
 	query := `
 		UPDATE cabins
-		SET aircraft_id = $2, segment_id = $3, deck = $4, first_row = $5, 
+		SET aircraft_id = $2, segment_id = NULLIF($3, '')::uuid, deck = $4, first_row = $5, 
 			last_row = $6, seat_columns = $7, updated_at = $8
 		WHERE id = $1
 	`
@@ -232,5 +232,5 @@ func (r *CabinRepository) Update(cabin *models.Cabin) error {
 func (r *CabinRepository) Delete(id string) error {
 	query := `DELETE FROM cabins WHERE id = $1`
 	_, err := r.db.Exec(query, id)
-	return err
+	return translateError(err)
 }
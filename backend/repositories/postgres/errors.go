@@ -0,0 +1,28 @@
+package postgres
+
+import (
+	"errors"
+
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+	"github.com/lib/pq"
+)
+
+// PostgreSQL error codes
+const (
+	foreignKeyViolation = "23503"
+	uniqueViolation     = "23505"
+)
+
+// translateError maps PostgreSQL errors to repository errors
+func translateError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case foreignKeyViolation:
+			return repositories.ErrReferenced
+		case uniqueViolation:
+			return repositories.ErrDuplicate
+		}
+	}
+	return err
+}
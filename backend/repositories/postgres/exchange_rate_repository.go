@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+)
+
+// ExchangeRateRepository is a PostgreSQL implementation of the ExchangeRateRepository interface
+type ExchangeRateRepository struct {
+	db *sql.DB
+}
+
+// NewExchangeRateRepository creates a new ExchangeRateRepository
+func NewExchangeRateRepository(db *sql.DB) repositories.ExchangeRateRepository {
+	return &ExchangeRateRepository{db: db}
+}
+
+// Create creates a new exchange rate in the database
+func (r *ExchangeRateRepository) Create(rate *models.ExchangeRate) error {
+	query := `
+		INSERT INTO exchange_rates (id, base_currency, quote_currency, rate, effective_from, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.db.Exec(
+		query,
+		rate.ID,
+		rate.BaseCurrency,
+		rate.QuoteCurrency,
+		rate.Rate,
+		rate.EffectiveFrom,
+		rate.CreatedAt,
+	)
+
+	return translateError(err)
+}
+
+// GetAll retrieves all exchange rates, newest first per currency pair
+func (r *ExchangeRateRepository) GetAll() ([]*models.ExchangeRate, error) {
+	query := `
+		SELECT id, base_currency, quote_currency, rate::text, effective_from, created_at
+		FROM exchange_rates
+		ORDER BY base_currency, quote_currency, effective_from DESC
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rates := []*models.ExchangeRate{}
+	for rows.Next() {
+		rate := &models.ExchangeRate{}
+		err := rows.Scan(
+			&rate.ID,
+			&rate.BaseCurrency,
+			&rate.QuoteCurrency,
+			&rate.Rate,
+			&rate.EffectiveFrom,
+			&rate.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+// GetEffective retrieves the rate for a currency pair in effect at a time
+func (r *ExchangeRateRepository) GetEffective(baseCurrency, quoteCurrency string, at time.Time) (*models.ExchangeRate, error) {
+	query := `
+		SELECT id, base_currency, quote_currency, rate::text, effective_from, created_at
+		FROM exchange_rates
+		WHERE base_currency = $1 AND quote_currency = $2 AND effective_from <= $3
+		ORDER BY effective_from DESC
+		LIMIT 1
+	`
+
+	rate := &models.ExchangeRate{}
+	err := r.db.QueryRow(query, baseCurrency, quoteCurrency, at).Scan(
+		&rate.ID,
+		&rate.BaseCurrency,
+		&rate.QuoteCurrency,
+		&rate.Rate,
+		&rate.EffectiveFrom,
+		&rate.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Rate not found
+		}
+		return nil, err
+	}
+
+	return rate, nil
+}
@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+)
+
+// FacilityRepository is a PostgreSQL implementation of the FacilityRepository interface
+type FacilityRepository struct {
+	db *sql.DB
+}
+
+// NewFacilityRepository creates a new FacilityRepository
+func NewFacilityRepository(db *sql.DB) repositories.FacilityRepository {
+	return &FacilityRepository{db: db}
+}
+
+// Create creates a new cabin facility in the database
+func (r *FacilityRepository) Create(facility *models.CabinFacility) error {
+	query := `
+		INSERT INTO cabin_facilities (id, cabin_id, type, row_number, position, columns, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.Exec(
+		query,
+		facility.ID,
+		facility.CabinID,
+		facility.Type,
+		facility.RowNumber,
+		facility.Position,
+		facility.Columns,
+		facility.CreatedAt,
+	)
+
+	return err
+}
+
+// GetByID retrieves a cabin facility by ID
+func (r *FacilityRepository) GetByID(id string) (*models.CabinFacility, error) {
+	query := `
+		SELECT id, cabin_id, type, row_number, position, columns, created_at
+		FROM cabin_facilities
+		WHERE id = $1
+	`
+
+	facility := &models.CabinFacility{}
+	err := r.db.QueryRow(query, id).Scan(
+		&facility.ID,
+		&facility.CabinID,
+		&facility.Type,
+		&facility.RowNumber,
+		&facility.Position,
+		&facility.Columns,
+		&facility.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Facility not found
+		}
+		return nil, err
+	}
+
+	return facility, nil
+}
+
+// GetByCabinID retrieves the facilities of a cabin ordered by row
+func (r *FacilityRepository) GetByCabinID(cabinID string) ([]*models.CabinFacility, error) {
+	query := `
+		SELECT id, cabin_id, type, row_number, position, columns, created_at
+		FROM cabin_facilities
+		WHERE cabin_id = $1
+		ORDER BY row_number, created_at
+	`
+
+	rows, err := r.db.Query(query, cabinID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	facilities := []*models.CabinFacility{}
+	for rows.Next() {
+		facility := &models.CabinFacility{}
+		err := rows.Scan(
+			&facility.ID,
+			&facility.CabinID,
+			&facility.Type,
+			&facility.RowNumber,
+			&facility.Position,
+			&facility.Columns,
+			&facility.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		facilities = append(facilities, facility)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return facilities, nil
+}
+
+// Delete deletes a cabin facility by its ID
+func (r *FacilityRepository) Delete(id string) error {
+	query := `DELETE FROM cabin_facilities WHERE id = $1`
+	_, err := r.db.Exec(query, id)
+	return err
+}
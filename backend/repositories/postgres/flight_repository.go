@@ -3,12 +3,15 @@ package postgres
 import (
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/evaizee/seat-arrangements/backend/models"
 	"github.com/evaizee/seat-arrangements/backend/repositories"
 )
 
-// FlightRepository is a PostgreSQL implementation of the FlightRepository interface
+// FlightRepository is a PostgreSQL implementation of the FlightRepository interface.
+// Flights are stored in the segments table.
 type FlightRepository struct {
 	db *sql.DB
 }
@@ -21,14 +24,15 @@ func NewFlightRepository(db *sql.DB) repositories.FlightRepository {
 // Create creates a new flight in the database
 func (r *FlightRepository) Create(flight *models.Flight) error {
 	query := `
-		INSERT INTO flights (
-			id, itinerary_id, segment_id, flight_number, airline_code,
-			operating_airline, origin, destination, departure, arrival,
+		INSERT INTO segments (
+			id, itinerary_id, flight_number, airline_code, operating_flight_number,
+			operating_airline_code, origin, destination, departure, arrival,
 			departure_terminal, arrival_terminal, cabin_class, equipment,
 			duration, booking_class, created_at, updated_at
 		)
 		VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18
+			$1, NULLIF($2, '')::uuid, $3, $4, $3, NULLIF($5, ''), $6, $7, $8, $9,
+			NULLIF($10, ''), NULLIF($11, ''), $12, NULLIF($13, ''), $14, $15, $16, $17
 		)
 	`
 
@@ -36,7 +40,6 @@ func (r *FlightRepository) Create(flight *models.Flight) error {
 		query,
 		flight.ID,
 		flight.ItineraryID,
-		flight.SegmentID,
 		flight.FlightNumber,
 		flight.AirlineCode,
 		flight.OperatingAirline,
@@ -61,10 +64,10 @@ func (r *FlightRepository) Create(flight *models.Flight) error {
 func (r *FlightRepository) GetByID(id string) (*models.Flight, error) {
 	query := `
 		SELECT
-			id, itinerary_id, flight_number, operating_airline_code,
-			operating_flight_number, origin, destination, departure, arrival,
-			departure_terminal, arrival_terminal, cabin_class, equipment,
-			duration, booking_class, created_at, updated_at
+			id, COALESCE(itinerary_id::text, ''), id, flight_number, airline_code,
+			COALESCE(operating_airline_code, ''), origin, destination, departure, arrival,
+			COALESCE(departure_terminal, ''), COALESCE(arrival_terminal, ''), cabin_class,
+			COALESCE(equipment, ''), duration, booking_class, created_at, updated_at
 		FROM segments
 		WHERE id = $1
 	`
@@ -73,6 +76,7 @@ func (r *FlightRepository) GetByID(id string) (*models.Flight, error) {
 	err := r.db.QueryRow(query, id).Scan(
 		&flight.ID,
 		&flight.ItineraryID,
+		&flight.SegmentID,
 		&flight.FlightNumber,
 		&flight.AirlineCode,
 		&flight.OperatingAirline,
@@ -102,23 +106,87 @@ func (r *FlightRepository) GetByID(id string) (*models.Flight, error) {
 
 // GetAll gets all flights
 func (r *FlightRepository) GetAll() ([]*models.Flight, error) {
+	result, err := r.Search(models.FlightFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Flights, nil
+}
+
+// Search gets the flights matching the filter ordered by departure, along with the total match count
+func (r *FlightRepository) Search(filter models.FlightFilter) (*models.FlightSearchResult, error) {
+	conditions := []string{}
+	args := []interface{}{}
+
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Origin != "" {
+		addCondition("UPPER(origin) = UPPER($%d)", filter.Origin)
+	}
+	if filter.Destination != "" {
+		addCondition("UPPER(destination) = UPPER($%d)", filter.Destination)
+	}
+	if filter.DepartureFrom != nil {
+		addCondition("departure >= $%d", *filter.DepartureFrom)
+	}
+	if filter.DepartureTo != nil {
+		addCondition("departure < $%d", *filter.DepartureTo)
+	}
+	if filter.AirlineCode != "" {
+		args = append(args, filter.AirlineCode)
+		conditions = append(conditions, fmt.Sprintf(
+			"(UPPER(airline_code) = UPPER($%d) OR UPPER(operating_airline_code) = UPPER($%d))", len(args), len(args)))
+	}
+	if filter.FlightNumber != "" {
+		addCondition("flight_number = $%d", filter.FlightNumber)
+	}
+	if filter.ItineraryID != "" {
+		addCondition("itinerary_id = $%d", filter.ItineraryID)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// Count all matches for pagination
+	var total int
+	err := r.db.QueryRow("SELECT COUNT(*) FROM segments "+where, args...).Scan(&total)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
 		SELECT
-			id, itinerary_id, segment_id, flight_number, airline_code,
-			operating_airline, origin, destination, departure, arrival,
-			departure_terminal, arrival_terminal, cabin_class, equipment,
-			duration, booking_class, created_at, updated_at
-		FROM flights
-		ORDER BY departure
+			id, COALESCE(itinerary_id::text, ''), id, flight_number, airline_code,
+			COALESCE(operating_airline_code, ''), origin, destination, departure, arrival,
+			COALESCE(departure_terminal, ''), COALESCE(arrival_terminal, ''), cabin_class,
+			COALESCE(equipment, ''), duration, booking_class, created_at, updated_at
+		FROM segments
+		` + where + `
+		ORDER BY departure, flight_number
 	`
 
-	rows, err := r.db.Query(query)
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var flights []*models.Flight
+	flights := []*models.Flight{}
 	for rows.Next() {
 		var flight models.Flight
 		err := rows.Scan(
@@ -151,30 +219,32 @@ func (r *FlightRepository) GetAll() ([]*models.Flight, error) {
 		return nil, err
 	}
 
-	return flights, nil
+	return &models.FlightSearchResult{
+		Flights: flights,
+		Total:   total,
+	}, nil
 }
 
 // Update updates a flight in the database
 func (r *FlightRepository) Update(flight *models.Flight) error {
 	query := `
-		UPDATE flights
+		UPDATE segments
 		SET
-			itinerary_id = $2,
-			segment_id = $3,
-			flight_number = $4,
-			airline_code = $5,
-			operating_airline = $6,
-			origin = $7,
-			destination = $8,
-			departure = $9,
-			arrival = $10,
-			departure_terminal = $11,
-			arrival_terminal = $12,
-			cabin_class = $13,
-			equipment = $14,
-			duration = $15,
-			booking_class = $16,
-			updated_at = $17
+			itinerary_id = NULLIF($2, '')::uuid,
+			flight_number = $3,
+			airline_code = $4,
+			operating_airline_code = NULLIF($5, ''),
+			origin = $6,
+			destination = $7,
+			departure = $8,
+			arrival = $9,
+			departure_terminal = NULLIF($10, ''),
+			arrival_terminal = NULLIF($11, ''),
+			cabin_class = $12,
+			equipment = NULLIF($13, ''),
+			duration = $14,
+			booking_class = $15,
+			updated_at = $16
 		WHERE id = $1
 	`
 
@@ -182,7 +252,6 @@ func (r *FlightRepository) Update(flight *models.Flight) error {
 		query,
 		flight.ID,
 		flight.ItineraryID,
-		flight.SegmentID,
 		flight.FlightNumber,
 		flight.AirlineCode,
 		flight.OperatingAirline,
@@ -204,7 +273,7 @@ func (r *FlightRepository) Update(flight *models.Flight) error {
 
 // Delete deletes a flight by its ID
 func (r *FlightRepository) Delete(id string) error {
-	query := `DELETE FROM flights WHERE id = $1`
+	query := `DELETE FROM segments WHERE id = $1`
 	_, err := r.db.Exec(query, id)
 	return err
 }
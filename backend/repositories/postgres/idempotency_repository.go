@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+)
+
+// IdempotencyRepository is a PostgreSQL implementation of the IdempotencyRepository interface
+type IdempotencyRepository struct {
+	db *sql.DB
+}
+
+// NewIdempotencyRepository creates a new IdempotencyRepository
+func NewIdempotencyRepository(db *sql.DB) repositories.IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+// Claim stores a record for a new request. It returns false without storing
+// anything when the user already has an unexpired record with the same key.
+func (r *IdempotencyRepository) Claim(record *models.IdempotencyRecord) (bool, error) {
+	query := `
+		INSERT INTO idempotency_keys (key, user_id, method, path, fingerprint, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id, key) DO UPDATE
+		SET method = EXCLUDED.method, path = EXCLUDED.path, fingerprint = EXCLUDED.fingerprint,
+			response_status = NULL, response_body = NULL, content_type = NULL,
+			created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at < EXCLUDED.created_at
+	`
+
+	result, err := r.db.Exec(
+		query,
+		record.Key,
+		record.UserID,
+		record.Method,
+		record.Path,
+		record.Fingerprint,
+		record.CreatedAt,
+		record.ExpiresAt,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// Get retrieves a user's record by key
+func (r *IdempotencyRepository) Get(userID, key string) (*models.IdempotencyRecord, error) {
+	query := `
+		SELECT key, user_id, method, path, fingerprint, COALESCE(response_status, 0),
+			response_body, COALESCE(content_type, ''), created_at, expires_at
+		FROM idempotency_keys
+		WHERE user_id = $1 AND key = $2
+	`
+
+	record := &models.IdempotencyRecord{}
+	err := r.db.QueryRow(query, userID, key).Scan(
+		&record.Key,
+		&record.UserID,
+		&record.Method,
+		&record.Path,
+		&record.Fingerprint,
+		&record.ResponseStatus,
+		&record.ResponseBody,
+		&record.ContentType,
+		&record.CreatedAt,
+		&record.ExpiresAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Record not found
+		}
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// SaveResponse stores the response to a claimed request
+func (r *IdempotencyRepository) SaveResponse(record *models.IdempotencyRecord) error {
+	query := `
+		UPDATE idempotency_keys
+		SET response_status = $3, response_body = $4, content_type = $5
+		WHERE user_id = $1 AND key = $2
+	`
+
+	_, err := r.db.Exec(query, record.UserID, record.Key, record.ResponseStatus, record.ResponseBody, record.ContentType)
+	return err
+}
+
+// Delete removes a record so the request can be retried
+func (r *IdempotencyRepository) Delete(userID, key string) error {
+	_, err := r.db.Exec(`DELETE FROM idempotency_keys WHERE user_id = $1 AND key = $2`, userID, key)
+	return err
+}
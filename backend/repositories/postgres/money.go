@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"github.com/evaizee/seat-arrangements/backend/models"
+)
+
+// moneyColumns collects NUMERIC amount columns scanned as text so they can be
+// parsed exactly once the row's currency column has been read
+type moneyColumns struct {
+	texts   []*string
+	targets []*models.Money
+}
+
+// amount returns the scan destination for an amount column stored into target
+func (m *moneyColumns) amount(target *models.Money) *string {
+	text := new(string)
+	m.texts = append(m.texts, text)
+	m.targets = append(m.targets, target)
+	return text
+}
+
+// parse stores the scanned amounts in their targets as money in currency
+func (m *moneyColumns) parse(currency string) error {
+	for i, text := range m.texts {
+		value, err := models.ParseMoney(*text, currency)
+		if err != nil {
+			return err
+		}
+		*m.targets[i] = value
+	}
+	return nil
+}
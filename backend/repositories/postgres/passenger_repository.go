@@ -25,8 +25,9 @@ func (r *PassengerRepository) Create(passenger *models.Passenger) error {
 		INSERT INTO passengers (
 			segment_id, passenger_index, passenger_name_number, first_name, last_name, 
 			date_of_birth, gender, type, email, phone, street, city, country, postcode, 
-			address_type, document_type, issuing_country, country_of_birth, nationality
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+			address_type, document_type, issuing_country, country_of_birth, nationality, user_id,
+			document_number, document_expiry
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)
 		RETURNING id, created_at, updated_at
 	`
 
@@ -36,6 +37,12 @@ func (r *PassengerRepository) Create(passenger *models.Passenger) error {
 		dateOfBirth = &dateStr
 	}
 
+	var documentExpiry *string
+	if passenger.DocumentExpiry != nil {
+		expiryStr := passenger.DocumentExpiry.Format("2006-01-02")
+		documentExpiry = &expiryStr
+	}
+
 	return r.db.QueryRow(
 		query,
 		passenger.SegmentID,
@@ -57,6 +64,9 @@ func (r *PassengerRepository) Create(passenger *models.Passenger) error {
 		passenger.IssuingCountry,
 		passenger.CountryOfBirth,
 		passenger.Nationality,
+		passenger.UserID,
+		passenger.DocumentNumber,
+		documentExpiry,
 	).Scan(&passenger.ID, &passenger.CreatedAt, &passenger.UpdatedAt)
 }
 
@@ -68,20 +78,22 @@ func (r *PassengerRepository) GetByID(id string) (*models.Passenger, error) {
 	}
 
 	query := `
-		SELECT id, segment_id, passenger_index, passenger_name_number, first_name, last_name, 
+		SELECT id, segment_id, user_id, pnr_id, passenger_index, passenger_name_number, first_name, last_name, 
 			date_of_birth, gender, type, email, phone, street, city, country, postcode, 
-			address_type, document_type, issuing_country, country_of_birth, nationality, 
-			created_at, updated_at
+			address_type, document_type, document_number, document_expiry, issuing_country, 
+			country_of_birth, nationality, created_at, updated_at
 		FROM passengers
 		WHERE id = $1
 	`
 
 	passenger := &models.Passenger{}
-	var dateOfBirth sql.NullTime
+	var dateOfBirth, documentExpiry sql.NullTime
 
 	err = r.db.QueryRow(query, passengerID).Scan(
 		&passenger.ID,
 		&passenger.SegmentID,
+		&passenger.UserID,
+		&passenger.PNRID,
 		&passenger.PassengerIndex,
 		&passenger.PassengerNameNumber,
 		&passenger.FirstName,
@@ -97,6 +109,8 @@ func (r *PassengerRepository) GetByID(id string) (*models.Passenger, error) {
 		&passenger.Postcode,
 		&passenger.AddressType,
 		&passenger.DocumentType,
+		&passenger.DocumentNumber,
+		&documentExpiry,
 		&passenger.IssuingCountry,
 		&passenger.CountryOfBirth,
 		&passenger.Nationality,
@@ -115,27 +129,176 @@ func (r *PassengerRepository) GetByID(id string) (*models.Passenger, error) {
 		passenger.DateOfBirth = &dateOfBirth.Time
 	}
 
+	if documentExpiry.Valid {
+		passenger.DocumentExpiry = &documentExpiry.Time
+	}
+
 	return passenger, nil
 }
 
 // GetBySegmentID retrieves passengers by segment ID
 func (r *PassengerRepository) GetBySegmentID(segmentID string) ([]*models.Passenger, error) {
-	segID, err := strconv.Atoi(segmentID)
+	query := `
+		SELECT id, segment_id, user_id, pnr_id, passenger_index, passenger_name_number, first_name, last_name, 
+			date_of_birth, gender, type, email, phone, street, city, country, postcode, 
+			address_type, document_type, document_number, document_expiry, issuing_country, 
+			country_of_birth, nationality, created_at, updated_at
+		FROM passengers
+		WHERE segment_id = $1
+		ORDER BY passenger_index
+	`
+
+	rows, err := r.db.Query(query, segmentID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid segment ID: %w", err)
+		return nil, fmt.Errorf("error querying passengers: %w", err)
 	}
+	defer rows.Close()
+
+	passengers := []*models.Passenger{}
+
+	for rows.Next() {
+		passenger := &models.Passenger{}
+		var dateOfBirth, documentExpiry sql.NullTime
 
+		err := rows.Scan(
+			&passenger.ID,
+			&passenger.SegmentID,
+			&passenger.UserID,
+			&passenger.PNRID,
+			&passenger.PassengerIndex,
+			&passenger.PassengerNameNumber,
+			&passenger.FirstName,
+			&passenger.LastName,
+			&dateOfBirth,
+			&passenger.Gender,
+			&passenger.Type,
+			&passenger.Email,
+			&passenger.Phone,
+			&passenger.Street,
+			&passenger.City,
+			&passenger.Country,
+			&passenger.Postcode,
+			&passenger.AddressType,
+			&passenger.DocumentType,
+			&passenger.DocumentNumber,
+			&documentExpiry,
+			&passenger.IssuingCountry,
+			&passenger.CountryOfBirth,
+			&passenger.Nationality,
+			&passenger.CreatedAt,
+			&passenger.UpdatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("error scanning passenger: %w", err)
+		}
+
+		if dateOfBirth.Valid {
+			passenger.DateOfBirth = &dateOfBirth.Time
+		}
+
+		if documentExpiry.Valid {
+			passenger.DocumentExpiry = &documentExpiry.Time
+		}
+
+		passengers = append(passengers, passenger)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating passengers: %w", err)
+	}
+
+	return passengers, nil
+}
+
+// GetByPNRID retrieves the passengers of a PNR
+func (r *PassengerRepository) GetByPNRID(pnrID string) ([]*models.Passenger, error) {
 	query := `
-		SELECT id, segment_id, passenger_index, passenger_name_number, first_name, last_name, 
+		SELECT id, segment_id, user_id, pnr_id, passenger_index, passenger_name_number, first_name, last_name, 
 			date_of_birth, gender, type, email, phone, street, city, country, postcode, 
-			address_type, document_type, issuing_country, country_of_birth, nationality, 
-			created_at, updated_at
+			address_type, document_type, document_number, document_expiry, issuing_country, 
+			country_of_birth, nationality, created_at, updated_at
 		FROM passengers
-		WHERE segment_id = $1
-		ORDER BY passenger_index
+		WHERE pnr_id = $1
+		ORDER BY passenger_name_number, segment_id
+	`
+
+	rows, err := r.db.Query(query, pnrID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying passengers: %w", err)
+	}
+	defer rows.Close()
+
+	passengers := []*models.Passenger{}
+
+	for rows.Next() {
+		passenger := &models.Passenger{}
+		var dateOfBirth, documentExpiry sql.NullTime
+
+		err := rows.Scan(
+			&passenger.ID,
+			&passenger.SegmentID,
+			&passenger.UserID,
+			&passenger.PNRID,
+			&passenger.PassengerIndex,
+			&passenger.PassengerNameNumber,
+			&passenger.FirstName,
+			&passenger.LastName,
+			&dateOfBirth,
+			&passenger.Gender,
+			&passenger.Type,
+			&passenger.Email,
+			&passenger.Phone,
+			&passenger.Street,
+			&passenger.City,
+			&passenger.Country,
+			&passenger.Postcode,
+			&passenger.AddressType,
+			&passenger.DocumentType,
+			&passenger.DocumentNumber,
+			&documentExpiry,
+			&passenger.IssuingCountry,
+			&passenger.CountryOfBirth,
+			&passenger.Nationality,
+			&passenger.CreatedAt,
+			&passenger.UpdatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("error scanning passenger: %w", err)
+		}
+
+		if dateOfBirth.Valid {
+			passenger.DateOfBirth = &dateOfBirth.Time
+		}
+
+		if documentExpiry.Valid {
+			passenger.DocumentExpiry = &documentExpiry.Time
+		}
+
+		passengers = append(passengers, passenger)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating passengers: %w", err)
+	}
+
+	return passengers, nil
+}
+
+// GetByUserID retrieves the passengers managed by a user
+func (r *PassengerRepository) GetByUserID(userID string) ([]*models.Passenger, error) {
+	query := `
+		SELECT id, segment_id, user_id, pnr_id, passenger_index, passenger_name_number, first_name, last_name, 
+			date_of_birth, gender, type, email, phone, street, city, country, postcode, 
+			address_type, document_type, document_number, document_expiry, issuing_country, 
+			country_of_birth, nationality, created_at, updated_at
+		FROM passengers
+		WHERE user_id = $1
+		ORDER BY segment_id, passenger_index
 	`
 
-	rows, err := r.db.Query(query, segID)
+	rows, err := r.db.Query(query, userID)
 	if err != nil {
 		return nil, fmt.Errorf("error querying passengers: %w", err)
 	}
@@ -145,11 +308,13 @@ func (r *PassengerRepository) GetBySegmentID(segmentID string) ([]*models.Passen
 
 	for rows.Next() {
 		passenger := &models.Passenger{}
-		var dateOfBirth sql.NullTime
+		var dateOfBirth, documentExpiry sql.NullTime
 
 		err := rows.Scan(
 			&passenger.ID,
 			&passenger.SegmentID,
+			&passenger.UserID,
+			&passenger.PNRID,
 			&passenger.PassengerIndex,
 			&passenger.PassengerNameNumber,
 			&passenger.FirstName,
@@ -165,6 +330,8 @@ func (r *PassengerRepository) GetBySegmentID(segmentID string) ([]*models.Passen
 			&passenger.Postcode,
 			&passenger.AddressType,
 			&passenger.DocumentType,
+			&passenger.DocumentNumber,
+			&documentExpiry,
 			&passenger.IssuingCountry,
 			&passenger.CountryOfBirth,
 			&passenger.Nationality,
@@ -180,6 +347,10 @@ func (r *PassengerRepository) GetBySegmentID(segmentID string) ([]*models.Passen
 			passenger.DateOfBirth = &dateOfBirth.Time
 		}
 
+		if documentExpiry.Valid {
+			passenger.DocumentExpiry = &documentExpiry.Time
+		}
+
 		passengers = append(passengers, passenger)
 	}
 
@@ -193,20 +364,22 @@ func (r *PassengerRepository) GetBySegmentID(segmentID string) ([]*models.Passen
 // GetByPassengerNameNumber retrieves a passenger by name number
 func (r *PassengerRepository) GetByPassengerNameNumber(nameNumber string) (*models.Passenger, error) {
 	query := `
-		SELECT id, segment_id, passenger_index, passenger_name_number, first_name, last_name, 
+		SELECT id, segment_id, user_id, pnr_id, passenger_index, passenger_name_number, first_name, last_name, 
 			date_of_birth, gender, type, email, phone, street, city, country, postcode, 
-			address_type, document_type, issuing_country, country_of_birth, nationality, 
-			created_at, updated_at
+			address_type, document_type, document_number, document_expiry, issuing_country, 
+			country_of_birth, nationality, created_at, updated_at
 		FROM passengers
 		WHERE passenger_name_number = $1
 	`
 
 	passenger := &models.Passenger{}
-	var dateOfBirth sql.NullTime
+	var dateOfBirth, documentExpiry sql.NullTime
 
 	err := r.db.QueryRow(query, nameNumber).Scan(
 		&passenger.ID,
 		&passenger.SegmentID,
+		&passenger.UserID,
+		&passenger.PNRID,
 		&passenger.PassengerIndex,
 		&passenger.PassengerNameNumber,
 		&passenger.FirstName,
@@ -222,6 +395,8 @@ func (r *PassengerRepository) GetByPassengerNameNumber(nameNumber string) (*mode
 		&passenger.Postcode,
 		&passenger.AddressType,
 		&passenger.DocumentType,
+		&passenger.DocumentNumber,
+		&documentExpiry,
 		&passenger.IssuingCountry,
 		&passenger.CountryOfBirth,
 		&passenger.Nationality,
@@ -240,16 +415,20 @@ func (r *PassengerRepository) GetByPassengerNameNumber(nameNumber string) (*mode
 		passenger.DateOfBirth = &dateOfBirth.Time
 	}
 
+	if documentExpiry.Valid {
+		passenger.DocumentExpiry = &documentExpiry.Time
+	}
+
 	return passenger, nil
 }
 
 // GetAll retrieves all passengers
 func (r *PassengerRepository) GetAll() ([]*models.Passenger, error) {
 	query := `
-		SELECT id, segment_id, passenger_index, passenger_name_number, first_name, last_name, 
+		SELECT id, segment_id, user_id, pnr_id, passenger_index, passenger_name_number, first_name, last_name, 
 			date_of_birth, gender, type, email, phone, street, city, country, postcode, 
-			address_type, document_type, issuing_country, country_of_birth, nationality, 
-			created_at, updated_at
+			address_type, document_type, document_number, document_expiry, issuing_country, 
+			country_of_birth, nationality, created_at, updated_at
 		FROM passengers
 		ORDER BY segment_id, passenger_index
 	`
@@ -264,11 +443,13 @@ func (r *PassengerRepository) GetAll() ([]*models.Passenger, error) {
 
 	for rows.Next() {
 		passenger := &models.Passenger{}
-		var dateOfBirth sql.NullTime
+		var dateOfBirth, documentExpiry sql.NullTime
 
 		err := rows.Scan(
 			&passenger.ID,
 			&passenger.SegmentID,
+			&passenger.UserID,
+			&passenger.PNRID,
 			&passenger.PassengerIndex,
 			&passenger.PassengerNameNumber,
 			&passenger.FirstName,
@@ -284,6 +465,8 @@ func (r *PassengerRepository) GetAll() ([]*models.Passenger, error) {
 			&passenger.Postcode,
 			&passenger.AddressType,
 			&passenger.DocumentType,
+			&passenger.DocumentNumber,
+			&documentExpiry,
 			&passenger.IssuingCountry,
 			&passenger.CountryOfBirth,
 			&passenger.Nationality,
@@ -299,6 +482,10 @@ func (r *PassengerRepository) GetAll() ([]*models.Passenger, error) {
 			passenger.DateOfBirth = &dateOfBirth.Time
 		}
 
+		if documentExpiry.Valid {
+			passenger.DocumentExpiry = &documentExpiry.Time
+		}
+
 		passengers = append(passengers, passenger)
 	}
 
@@ -318,7 +505,7 @@ func (r *PassengerRepository) Update(passenger *models.Passenger) error {
 			type = $8, email = $9, phone = $10, street = $11, city = $12, 
 			country = $13, postcode = $14, address_type = $15, document_type = $16, 
 			issuing_country = $17, country_of_birth = $18, nationality = $19, 
-			updated_at = CURRENT_TIMESTAMP
+			user_id = $21, document_number = $22, document_expiry = $23, updated_at = CURRENT_TIMESTAMP
 		WHERE id = $20
 		RETURNING updated_at
 	`
@@ -329,6 +516,12 @@ func (r *PassengerRepository) Update(passenger *models.Passenger) error {
 		dateOfBirth = &dateStr
 	}
 
+	var documentExpiry *string
+	if passenger.DocumentExpiry != nil {
+		expiryStr := passenger.DocumentExpiry.Format("2006-01-02")
+		documentExpiry = &expiryStr
+	}
+
 	return r.db.QueryRow(
 		query,
 		passenger.SegmentID,
@@ -351,6 +544,9 @@ func (r *PassengerRepository) Update(passenger *models.Passenger) error {
 		passenger.CountryOfBirth,
 		passenger.Nationality,
 		passenger.ID,
+		passenger.UserID,
+		passenger.DocumentNumber,
+		documentExpiry,
 	).Scan(&passenger.UpdatedAt)
 }
 
@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+)
+
+// PaymentRepository is a PostgreSQL implementation of the PaymentRepository interface
+type PaymentRepository struct {
+	db *sql.DB
+}
+
+// NewPaymentRepository creates a new PaymentRepository
+func NewPaymentRepository(db *sql.DB) repositories.PaymentRepository {
+	return &PaymentRepository{db: db}
+}
+
+// Create creates a new payment in the database
+func (r *PaymentRepository) Create(payment *models.Payment) error {
+	query := `
+		INSERT INTO payments (
+			id, booking_id, provider, provider_reference, status, amount,
+			refunded_amount, currency, failure_reason, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, NULLIF($9, ''), $10, $11)
+	`
+
+	_, err := r.db.Exec(
+		query,
+		payment.ID,
+		payment.BookingID,
+		payment.Provider,
+		payment.ProviderReference,
+		payment.Status,
+		payment.Amount.Decimal(),
+		payment.RefundedAmount.Decimal(),
+		payment.Amount.Currency,
+		payment.FailureReason,
+		payment.CreatedAt,
+		payment.UpdatedAt,
+	)
+
+	return err
+}
+
+// Update updates the provider reference, status and refunded amount of a payment
+func (r *PaymentRepository) Update(payment *models.Payment) error {
+	query := `
+		UPDATE payments
+		SET provider_reference = NULLIF($2, ''), status = $3, refunded_amount = $4,
+			failure_reason = NULLIF($5, ''), updated_at = $6
+		WHERE id = $1
+	`
+
+	_, err := r.db.Exec(
+		query,
+		payment.ID,
+		payment.ProviderReference,
+		payment.Status,
+		payment.RefundedAmount.Decimal(),
+		payment.FailureReason,
+		payment.UpdatedAt,
+	)
+
+	return err
+}
+
+// GetByBookingID retrieves the payments of a booking, oldest first
+func (r *PaymentRepository) GetByBookingID(bookingID string) ([]*models.Payment, error) {
+	query := `
+		SELECT id, booking_id, provider, COALESCE(provider_reference, ''), status, amount,
+			refunded_amount, currency, COALESCE(failure_reason, ''), created_at, updated_at
+		FROM payments
+		WHERE booking_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(query, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	payments := []*models.Payment{}
+	for rows.Next() {
+		payment := &models.Payment{}
+		var money moneyColumns
+		var currency string
+		err := rows.Scan(
+			&payment.ID,
+			&payment.BookingID,
+			&payment.Provider,
+			&payment.ProviderReference,
+			&payment.Status,
+			money.amount(&payment.Amount),
+			money.amount(&payment.RefundedAmount),
+			&currency,
+			&payment.FailureReason,
+			&payment.CreatedAt,
+			&payment.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := money.parse(currency); err != nil {
+			return nil, err
+		}
+		payments = append(payments, payment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return payments, nil
+}
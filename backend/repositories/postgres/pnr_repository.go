@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+)
+
+// PNRRepository is a PostgreSQL implementation of the PNRRepository interface
+type PNRRepository struct {
+	db *sql.DB
+}
+
+// NewPNRRepository creates a new PNRRepository
+func NewPNRRepository(db *sql.DB) repositories.PNRRepository {
+	return &PNRRepository{db: db}
+}
+
+// pnrColumns are the columns scanned by scanPNR
+const pnrColumns = `id, record_locator, itinerary_id, COALESCE(user_id::text, ''), created_at, updated_at`
+
+// Create creates a new PNR holding the given passengers. It fails with
+// ErrDuplicate if the record locator is taken and with ErrConflict if one of
+// the passengers is already on a PNR.
+func (r *PNRRepository) Create(pnr *models.PNR, passengerIDs []int) error {
+	return withTx(r.db, func(tx *sql.Tx) error {
+		_, err := tx.Exec(`
+			INSERT INTO pnrs (id, record_locator, itinerary_id, user_id, created_at, updated_at)
+			VALUES ($1, $2, $3, NULLIF($4, '')::uuid, $5, $6)
+		`, pnr.ID, pnr.RecordLocator, pnr.ItineraryID, pnr.UserID, pnr.CreatedAt, pnr.UpdatedAt)
+		if err != nil {
+			return translateError(err)
+		}
+
+		for _, passengerID := range passengerIDs {
+			result, err := tx.Exec(`
+				UPDATE passengers
+				SET pnr_id = $2, updated_at = CURRENT_TIMESTAMP
+				WHERE id = $1 AND pnr_id IS NULL
+			`, passengerID, pnr.ID)
+			if err != nil {
+				return err
+			}
+
+			affected, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+
+			if affected == 0 {
+				return repositories.ErrConflict
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetByID retrieves a PNR by ID
+func (r *PNRRepository) GetByID(id string) (*models.PNR, error) {
+	return scanPNR(r.db.QueryRow(`SELECT `+pnrColumns+` FROM pnrs WHERE id = $1`, id))
+}
+
+// GetByRecordLocator retrieves a PNR by its record locator
+func (r *PNRRepository) GetByRecordLocator(locator string) (*models.PNR, error) {
+	return scanPNR(r.db.QueryRow(`SELECT `+pnrColumns+` FROM pnrs WHERE record_locator = $1`, locator))
+}
+
+// scanPNR scans a row of pnrColumns, returning nil if there is no row
+func scanPNR(row rowScanner) (*models.PNR, error) {
+	pnr := &models.PNR{}
+	err := row.Scan(
+		&pnr.ID,
+		&pnr.RecordLocator,
+		&pnr.ItineraryID,
+		&pnr.UserID,
+		&pnr.CreatedAt,
+		&pnr.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // PNR not found
+		}
+		return nil, err
+	}
+
+	return pnr, nil
+}
@@ -0,0 +1,234 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+)
+
+// PromotionRepository is a PostgreSQL implementation of the PromotionRepository interface
+type PromotionRepository struct {
+	db *sql.DB
+}
+
+// NewPromotionRepository creates a new PromotionRepository
+func NewPromotionRepository(db *sql.DB) repositories.PromotionRepository {
+	return &PromotionRepository{db: db}
+}
+
+// promotionColumns are the columns scanned by scanPromotion
+const promotionColumns = `
+	id, code, description, discount_type, COALESCE(percent::text, ''), amount::text,
+	COALESCE(currency, ''), characteristics, routes, valid_from, valid_to,
+	usage_limit, usage_count, active, created_at, updated_at
+`
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// Create creates a new promotion in the database
+func (r *PromotionRepository) Create(promotion *models.Promotion) error {
+	query := `
+		INSERT INTO promotions (
+			id, code, description, discount_type, percent, amount, currency,
+			characteristics, routes, valid_from, valid_to, usage_limit, usage_count,
+			active, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, NULLIF($5, '')::numeric, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+	`
+
+	var amount, currency sql.NullString
+	if promotion.Amount != nil {
+		amount = sql.NullString{String: promotion.Amount.Decimal(), Valid: true}
+		currency = sql.NullString{String: promotion.Amount.Currency, Valid: true}
+	}
+
+	_, err := r.db.Exec(
+		query,
+		promotion.ID,
+		promotion.Code,
+		promotion.Description,
+		promotion.DiscountType,
+		promotion.Percent,
+		amount,
+		currency,
+		strings.Join(promotion.Characteristics, ","),
+		strings.Join(promotion.Routes, ","),
+		promotion.ValidFrom,
+		promotion.ValidTo,
+		promotion.UsageLimit,
+		promotion.UsageCount,
+		promotion.Active,
+		promotion.CreatedAt,
+		promotion.UpdatedAt,
+	)
+
+	return translateError(err)
+}
+
+// Update updates the validity, usage limit and active flag of a promotion
+func (r *PromotionRepository) Update(promotion *models.Promotion) error {
+	query := `
+		UPDATE promotions
+		SET description = $2, valid_from = $3, valid_to = $4, usage_limit = $5, active = $6, updated_at = $7
+		WHERE id = $1
+	`
+
+	_, err := r.db.Exec(
+		query,
+		promotion.ID,
+		promotion.Description,
+		promotion.ValidFrom,
+		promotion.ValidTo,
+		promotion.UsageLimit,
+		promotion.Active,
+		promotion.UpdatedAt,
+	)
+
+	return translateError(err)
+}
+
+// GetAll retrieves all promotions, newest first
+func (r *PromotionRepository) GetAll() ([]*models.Promotion, error) {
+	query := `SELECT ` + promotionColumns + ` FROM promotions ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	promotions := []*models.Promotion{}
+	for rows.Next() {
+		promotion, err := scanPromotion(rows)
+		if err != nil {
+			return nil, err
+		}
+		promotions = append(promotions, promotion)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return promotions, nil
+}
+
+// GetByCode retrieves a promotion by its code
+func (r *PromotionRepository) GetByCode(code string) (*models.Promotion, error) {
+	query := `SELECT ` + promotionColumns + ` FROM promotions WHERE code = $1`
+	return r.getOne(query, code)
+}
+
+// GetByID retrieves a promotion by ID
+func (r *PromotionRepository) GetByID(id string) (*models.Promotion, error) {
+	query := `SELECT ` + promotionColumns + ` FROM promotions WHERE id = $1`
+	return r.getOne(query, id)
+}
+
+// getOne retrieves a single promotion, or nil if there is none
+func (r *PromotionRepository) getOne(query string, arg interface{}) (*models.Promotion, error) {
+	promotion, err := scanPromotion(r.db.QueryRow(query, arg))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Promotion not found
+		}
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+// scanPromotion scans a row of promotionColumns
+func scanPromotion(row rowScanner) (*models.Promotion, error) {
+	promotion := &models.Promotion{}
+	var amount sql.NullString
+	var currency, characteristics, routes string
+	var usageLimit sql.NullInt64
+	err := row.Scan(
+		&promotion.ID,
+		&promotion.Code,
+		&promotion.Description,
+		&promotion.DiscountType,
+		&promotion.Percent,
+		&amount,
+		&currency,
+		&characteristics,
+		&routes,
+		&promotion.ValidFrom,
+		&promotion.ValidTo,
+		&usageLimit,
+		&promotion.UsageCount,
+		&promotion.Active,
+		&promotion.CreatedAt,
+		&promotion.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if amount.Valid {
+		value, err := models.ParseMoney(amount.String, currency)
+		if err != nil {
+			return nil, err
+		}
+		promotion.Amount = &value
+	}
+
+	if usageLimit.Valid {
+		limit := int(usageLimit.Int64)
+		promotion.UsageLimit = &limit
+	}
+
+	promotion.Characteristics = splitList(characteristics)
+	promotion.Routes = splitList(routes)
+	return promotion, nil
+}
+
+// splitList splits a comma-separated column, returning an empty list for an empty column
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// redeemPromotion counts a booking against a promotion's usage limit, failing
+// with ErrPromotionExhausted once the limit is reached
+func redeemPromotion(db execer, promotionID string) error {
+	result, err := db.Exec(`
+		UPDATE promotions SET usage_count = usage_count + 1, updated_at = NOW()
+		WHERE id = $1 AND (usage_limit IS NULL OR usage_count < usage_limit)
+	`, promotionID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repositories.ErrPromotionExhausted
+	}
+
+	return nil
+}
+
+// releasePromotion gives back a booking's use of a promotion
+func releasePromotion(db execer, promotionID string) error {
+	_, err := db.Exec(`
+		UPDATE promotions SET usage_count = usage_count - 1, updated_at = NOW()
+		WHERE id = $1 AND usage_count > 0
+	`, promotionID)
+	return err
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"database/sql"
+	"errors"
 
 	"github.com/evaizee/seat-arrangements/backend/models"
 	"github.com/evaizee/seat-arrangements/backend/repositories"
@@ -62,3 +63,79 @@ func (r *RowRepository) GetByCabinID(cabinID string) ([]*models.SeatRow, error)
 
 	return seatRows, nil
 }
+
+// Create creates a new seat row in the database
+func (r *RowRepository) Create(row *models.SeatRow) error {
+	query := `
+		INSERT INTO seat_rows (id, cabin_id, row_number, seat_codes, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.db.Exec(
+		query,
+		row.ID,
+		row.CabinID,
+		row.RowNumber,
+		row.SeatCodes,
+		row.CreatedAt,
+		row.UpdatedAt,
+	)
+
+	return err
+}
+
+// GetByID retrieves a seat row by ID
+func (r *RowRepository) GetByID(id string) (*models.SeatRow, error) {
+	query := `
+		SELECT 
+			id, cabin_id, row_number, seat_codes, created_at, updated_at
+		FROM seat_rows
+		WHERE id = $1
+	`
+
+	seatRow := &models.SeatRow{}
+	err := r.db.QueryRow(query, id).Scan(
+		&seatRow.ID,
+		&seatRow.CabinID,
+		&seatRow.RowNumber,
+		&seatRow.SeatCodes,
+		&seatRow.CreatedAt,
+		&seatRow.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Row not found
+		}
+		return nil, err
+	}
+
+	return seatRow, nil
+}
+
+// Update updates a seat row in the database
+func (r *RowRepository) Update(row *models.SeatRow) error {
+	query := `
+		UPDATE seat_rows
+		SET cabin_id = $2, row_number = $3, seat_codes = $4, updated_at = $5
+		WHERE id = $1
+	`
+
+	_, err := r.db.Exec(
+		query,
+		row.ID,
+		row.CabinID,
+		row.RowNumber,
+		row.SeatCodes,
+		row.UpdatedAt,
+	)
+
+	return err
+}
+
+// Delete deletes a seat row by its ID
+func (r *RowRepository) Delete(id string) error {
+	query := `DELETE FROM seat_rows WHERE id = $1`
+	_, err := r.db.Exec(query, id)
+	return translateError(err)
+}
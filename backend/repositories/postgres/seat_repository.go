@@ -28,7 +28,7 @@ func (r *SeatRepository) Create(seat *models.Seat) error {
 			seat_characteristics, raw_characteristics, created_at, updated_at
 		)
 		VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
+			$1, $2, NULLIF($3, '')::uuid, $4, NULLIF($5, ''), $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
 		)
 	`
 
@@ -68,8 +68,8 @@ func (r *SeatRepository) CreatePrice(price *models.SeatPrice) error {
 		price.ID,
 		price.SeatID,
 		price.Type,
-		price.Amount,
-		price.Currency,
+		price.Price.Decimal(),
+		price.Price.Currency,
 	)
 
 	return err
@@ -79,10 +79,10 @@ func (r *SeatRepository) CreatePrice(price *models.SeatPrice) error {
 func (r *SeatRepository) GetByID(id string) (*models.Seat, error) {
 	query := `
 		SELECT 
-			id, row_id, segment_id, storefront_slot_code, code, available, 
+			id, row_id, COALESCE(segment_id::text, ''), storefront_slot_code, COALESCE(code, ''), available, 
 			entitled, fee_waived, free_of_charge, originally_selected, 
-			entitled_rule_id, fee_waived_rule_id, refund_indicator, 
-			seat_characteristics, raw_characteristics, created_at, updated_at
+			COALESCE(entitled_rule_id, ''), COALESCE(fee_waived_rule_id, ''), COALESCE(refund_indicator, ''), 
+			COALESCE(seat_characteristics, ''), COALESCE(raw_characteristics, ''), created_at, updated_at
 		FROM seats
 		WHERE id = $1
 	`
@@ -122,10 +122,10 @@ func (r *SeatRepository) GetByID(id string) (*models.Seat, error) {
 func (r *SeatRepository) GetByRowID(rowID string) ([]*models.Seat, error) {
 	query := `
 		SELECT 
-			id, row_id, segment_id, storefront_slot_code, code, available, 
+			id, row_id, COALESCE(segment_id::text, ''), storefront_slot_code, COALESCE(code, ''), available, 
 			entitled, fee_waived, free_of_charge, originally_selected, 
-			entitled_rule_id, fee_waived_rule_id, refund_indicator, 
-			seat_characteristics, raw_characteristics, created_at, updated_at
+			COALESCE(entitled_rule_id, ''), COALESCE(fee_waived_rule_id, ''), COALESCE(refund_indicator, ''), 
+			COALESCE(seat_characteristics, ''), COALESCE(raw_characteristics, ''), created_at, updated_at
 		FROM seats
 		WHERE row_id = $1
 	`
@@ -175,10 +175,10 @@ func (r *SeatRepository) GetByRowID(rowID string) ([]*models.Seat, error) {
 func (r *SeatRepository) GetBySegmentID(segmentID string) ([]*models.Seat, error) {
 	query := `
 		SELECT 
-			id, row_id, segment_id, storefront_slot_code, code, available, 
+			id, row_id, COALESCE(segment_id::text, ''), storefront_slot_code, COALESCE(code, ''), available, 
 			entitled, fee_waived, free_of_charge, originally_selected, 
-			entitled_rule_id, fee_waived_rule_id, refund_indicator, 
-			seat_characteristics, raw_characteristics, created_at, updated_at
+			COALESCE(entitled_rule_id, ''), COALESCE(fee_waived_rule_id, ''), COALESCE(refund_indicator, ''), 
+			COALESCE(seat_characteristics, ''), COALESCE(raw_characteristics, ''), created_at, updated_at
 		FROM seats
 		WHERE segment_id = $1
 	`
@@ -233,12 +233,13 @@ func (r *SeatRepository) GetPriceBySeatID(seatID string) (*models.SeatPrice, err
 	`
 
 	price := &models.SeatPrice{}
+	var amount, currency string
 	err := r.db.QueryRow(query, seatID).Scan(
 		&price.ID,
 		&price.SeatID,
 		&price.Type,
-		&price.Amount,
-		&price.Currency,
+		&amount,
+		&currency,
 	)
 
 	if err != nil {
@@ -248,6 +249,10 @@ func (r *SeatRepository) GetPriceBySeatID(seatID string) (*models.SeatPrice, err
 		return nil, err
 	}
 
+	if price.Price, err = models.ParseMoney(amount, currency); err != nil {
+		return nil, err
+	}
+
 	return price, nil
 }
 
@@ -255,10 +260,10 @@ func (r *SeatRepository) GetPriceBySeatID(seatID string) (*models.SeatPrice, err
 func (r *SeatRepository) GetAll() ([]*models.Seat, error) {
 	query := `
 		SELECT 
-			id, row_id, segment_id, storefront_slot_code, code, available, 
+			id, row_id, COALESCE(segment_id::text, ''), storefront_slot_code, COALESCE(code, ''), available, 
 			entitled, fee_waived, free_of_charge, originally_selected, 
-			entitled_rule_id, fee_waived_rule_id, refund_indicator, 
-			seat_characteristics, raw_characteristics, created_at, updated_at
+			COALESCE(entitled_rule_id, ''), COALESCE(fee_waived_rule_id, ''), COALESCE(refund_indicator, ''), 
+			COALESCE(seat_characteristics, ''), COALESCE(raw_characteristics, ''), created_at, updated_at
 		FROM seats
 	`
 
@@ -309,9 +314,9 @@ func (r *SeatRepository) Update(seat *models.Seat) error {
 		UPDATE seats
 		SET 
 			row_id = $2,
-			segment_id = $3,
+			segment_id = NULLIF($3, '')::uuid,
 			storefront_slot_code = $4,
-			code = $5,
+			code = NULLIF($5, ''),
 			available = $6,
 			entitled = $7,
 			fee_waived = $8,
@@ -362,8 +367,8 @@ func (r *SeatRepository) UpdatePrice(price *models.SeatPrice) error {
 		price.ID,
 		price.SeatID,
 		price.Type,
-		price.Amount,
-		price.Currency,
+		price.Price.Decimal(),
+		price.Price.Currency,
 	)
 
 	return err
@@ -373,7 +378,7 @@ func (r *SeatRepository) UpdatePrice(price *models.SeatPrice) error {
 func (r *SeatRepository) Delete(id string) error {
 	query := `DELETE FROM seats WHERE id = $1`
 	_, err := r.db.Exec(query, id)
-	return err
+	return translateError(err)
 }
 
 // DeletePrice deletes a seat price from the database
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+)
+
+// TaxRuleRepository is a PostgreSQL implementation of the TaxRuleRepository interface
+type TaxRuleRepository struct {
+	db *sql.DB
+}
+
+// NewTaxRuleRepository creates a new TaxRuleRepository
+func NewTaxRuleRepository(db *sql.DB) repositories.TaxRuleRepository {
+	return &TaxRuleRepository{db: db}
+}
+
+// GetApplicable retrieves the tax rules in effect at a time for flights from
+// originCountry to destinationCountry, including rules for any country
+func (r *TaxRuleRepository) GetApplicable(originCountry, destinationCountry string, at time.Time) ([]*models.TaxRule, error) {
+	query := `
+		SELECT id, code, name, COALESCE(origin_country, ''), COALESCE(destination_country, ''),
+			type, COALESCE(rate::text, ''), amount::text, COALESCE(currency, ''),
+			effective_from, effective_to, created_at
+		FROM tax_rules
+		WHERE (origin_country IS NULL OR origin_country = $1)
+			AND (destination_country IS NULL OR destination_country = $2)
+			AND effective_from <= $3
+			AND (effective_to IS NULL OR effective_to > $3)
+		ORDER BY code
+	`
+
+	rows, err := r.db.Query(query, originCountry, destinationCountry, at)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := []*models.TaxRule{}
+	for rows.Next() {
+		rule := &models.TaxRule{}
+		var amount sql.NullString
+		var currency string
+		err := rows.Scan(
+			&rule.ID,
+			&rule.Code,
+			&rule.Name,
+			&rule.OriginCountry,
+			&rule.DestinationCountry,
+			&rule.Type,
+			&rule.Rate,
+			&amount,
+			&currency,
+			&rule.EffectiveFrom,
+			&rule.EffectiveTo,
+			&rule.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if amount.Valid {
+			value, err := models.ParseMoney(amount.String, currency)
+			if err != nil {
+				return nil, err
+			}
+			rule.Amount = &value
+		}
+
+		rules = append(rules, rule)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+)
+
+// withTx runs fn in a transaction, committing when it succeeds and rolling back otherwise
+func withTx(db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// claimSeat marks an available seat as taken, failing with ErrSeatUnavailable if it is not available
+func claimSeat(tx *sql.Tx, seatID string) error {
+	result, err := tx.Exec(`UPDATE seats SET available = false, updated_at = NOW() WHERE id = $1 AND available = true`, seatID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repositories.ErrSeatUnavailable
+	}
+
+	return nil
+}
+
+// releaseSeat makes a seat available again
+func releaseSeat(tx *sql.Tx, seatID string) error {
+	_, err := tx.Exec(`UPDATE seats SET available = true, updated_at = NOW() WHERE id = $1`, seatID)
+	return err
+}
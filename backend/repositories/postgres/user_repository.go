@@ -3,6 +3,7 @@ package postgres
 import (
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/evaizee/seat-arrangements/backend/models"
 	"github.com/evaizee/seat-arrangements/backend/repositories"
@@ -21,8 +22,8 @@ func NewUserRepository(db *sql.DB) repositories.UserRepository {
 // Create creates a new user in the database
 func (r *UserRepository) Create(user *models.User) error {
 	query := `
-		INSERT INTO users (id, email, password, first_name, last_name, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO users (id, email, password, first_name, last_name, role, email_verified_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 
 	_, err := r.db.Exec(
@@ -32,6 +33,8 @@ func (r *UserRepository) Create(user *models.User) error {
 		user.Password,
 		user.FirstName,
 		user.LastName,
+		user.Role,
+		user.EmailVerifiedAt,
 		user.CreatedAt,
 		user.UpdatedAt,
 	)
@@ -42,7 +45,8 @@ func (r *UserRepository) Create(user *models.User) error {
 // GetByID retrieves a user by ID
 func (r *UserRepository) GetByID(id string) (*models.User, error) {
 	query := `
-		SELECT id, email, password, first_name, last_name, created_at, updated_at
+		SELECT id, email, password, first_name, last_name, role, email_verified_at,
+			failed_login_attempts, locked_until, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
@@ -54,6 +58,10 @@ func (r *UserRepository) GetByID(id string) (*models.User, error) {
 		&user.Password,
 		&user.FirstName,
 		&user.LastName,
+		&user.Role,
+		&user.EmailVerifiedAt,
+		&user.FailedLoginAttempts,
+		&user.LockedUntil,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -71,7 +79,8 @@ func (r *UserRepository) GetByID(id string) (*models.User, error) {
 // GetByEmail retrieves a user by email
 func (r *UserRepository) GetByEmail(email string) (*models.User, error) {
 	query := `
-		SELECT id, email, password, first_name, last_name, created_at, updated_at
+		SELECT id, email, password, first_name, last_name, role, email_verified_at,
+			failed_login_attempts, locked_until, created_at, updated_at
 		FROM users
 		WHERE email = $1
 	`
@@ -83,6 +92,10 @@ func (r *UserRepository) GetByEmail(email string) (*models.User, error) {
 		&user.Password,
 		&user.FirstName,
 		&user.LastName,
+		&user.Role,
+		&user.EmailVerifiedAt,
+		&user.FailedLoginAttempts,
+		&user.LockedUntil,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -101,7 +114,8 @@ func (r *UserRepository) GetByEmail(email string) (*models.User, error) {
 func (r *UserRepository) Update(user *models.User) error {
 	query := `
 		UPDATE users
-		SET email = $2, password = $3, first_name = $4, last_name = $5, updated_at = $6
+		SET email = $2, password = $3, first_name = $4, last_name = $5, role = $6,
+			email_verified_at = $7, updated_at = $8
 		WHERE id = $1
 	`
 
@@ -112,6 +126,8 @@ func (r *UserRepository) Update(user *models.User) error {
 		user.Password,
 		user.FirstName,
 		user.LastName,
+		user.Role,
+		user.EmailVerifiedAt,
 		user.UpdatedAt,
 	)
 
@@ -123,4 +139,32 @@ func (r *UserRepository) Delete(id string) error {
 	query := `DELETE FROM users WHERE id = $1`
 	_, err := r.db.Exec(query, id)
 	return err
-}
\ No newline at end of file
+}
+
+// IncrementFailedLogins atomically increments the failed login counter and returns the new value
+func (r *UserRepository) IncrementFailedLogins(id string) (int, error) {
+	query := `
+		UPDATE users
+		SET failed_login_attempts = failed_login_attempts + 1
+		WHERE id = $1
+		RETURNING failed_login_attempts
+	`
+
+	var attempts int
+	err := r.db.QueryRow(query, id).Scan(&attempts)
+	return attempts, err
+}
+
+// LockUntil locks the account until the given time
+func (r *UserRepository) LockUntil(id string, until time.Time) error {
+	query := `UPDATE users SET locked_until = $2 WHERE id = $1`
+	_, err := r.db.Exec(query, id, until)
+	return err
+}
+
+// ResetFailedLogins clears the failed login counter and any lock
+func (r *UserRepository) ResetFailedLogins(id string) error {
+	query := `UPDATE users SET failed_login_attempts = 0, locked_until = NULL WHERE id = $1`
+	_, err := r.db.Exec(query, id)
+	return err
+}
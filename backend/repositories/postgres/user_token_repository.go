@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+)
+
+// UserTokenRepository is a PostgreSQL implementation of the UserTokenRepository interface
+type UserTokenRepository struct {
+	db *sql.DB
+}
+
+// NewUserTokenRepository creates a new UserTokenRepository
+func NewUserTokenRepository(db *sql.DB) repositories.UserTokenRepository {
+	return &UserTokenRepository{db: db}
+}
+
+// Create creates a new user token in the database
+func (r *UserTokenRepository) Create(token *models.UserToken) error {
+	query := `
+		INSERT INTO user_tokens (id, user_id, purpose, token_hash, expires_at, used_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.Exec(
+		query,
+		token.ID,
+		token.UserID,
+		token.Purpose,
+		token.TokenHash,
+		token.ExpiresAt,
+		token.UsedAt,
+		token.CreatedAt,
+	)
+
+	return err
+}
+
+// Consume marks an unused, unexpired token as used and returns it.
+// The check and update happen in one statement so a token can only be consumed once.
+func (r *UserTokenRepository) Consume(purpose, tokenHash string) (*models.UserToken, error) {
+	query := `
+		UPDATE user_tokens
+		SET used_at = $3
+		WHERE purpose = $1 AND token_hash = $2 AND used_at IS NULL AND expires_at > $3
+		RETURNING id, user_id, purpose, token_hash, expires_at, used_at, created_at
+	`
+
+	token := &models.UserToken{}
+	err := r.db.QueryRow(query, purpose, tokenHash, time.Now()).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Purpose,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.UsedAt,
+		&token.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Token not found, used or expired
+		}
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// InvalidateByUserID marks all outstanding tokens of a purpose for a user as used
+func (r *UserTokenRepository) InvalidateByUserID(userID, purpose string) error {
+	query := `
+		UPDATE user_tokens
+		SET used_at = $3
+		WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL
+	`
+	_, err := r.db.Exec(query, userID, purpose, time.Now())
+	return err
+}
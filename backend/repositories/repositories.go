@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"time"
+
 	"github.com/evaizee/seat-arrangements/backend/models"
 )
 
@@ -11,6 +13,30 @@ type UserRepository interface {
 	GetByEmail(email string) (*models.User, error)
 	Update(user *models.User) error
 	Delete(id string) error
+	IncrementFailedLogins(id string) (int, error)
+	LockUntil(id string, until time.Time) error
+	ResetFailedLogins(id string) error
+}
+
+// IdempotencyRepository defines the interface for idempotency key data access
+type IdempotencyRepository interface {
+	Claim(record *models.IdempotencyRecord) (bool, error)
+	Get(userID, key string) (*models.IdempotencyRecord, error)
+	SaveResponse(record *models.IdempotencyRecord) error
+	Delete(userID, key string) error
+}
+
+// AuditLogRepository defines the interface for audit log data access
+type AuditLogRepository interface {
+	Create(entry *models.AuditLog) error
+	GetByUserID(userID string) ([]*models.AuditLog, error)
+}
+
+// UserTokenRepository defines the interface for password reset and email verification token data access
+type UserTokenRepository interface {
+	Create(token *models.UserToken) error
+	Consume(purpose, tokenHash string) (*models.UserToken, error)
+	InvalidateByUserID(userID, purpose string) error
 }
 
 // FlightRepository defines the interface for flight data access
@@ -18,6 +44,7 @@ type FlightRepository interface {
 	Create(flight *models.Flight) error
 	GetByID(id string) (*models.Flight, error)
 	GetAll() ([]*models.Flight, error)
+	Search(filter models.FlightFilter) (*models.FlightSearchResult, error)
 	Update(flight *models.Flight) error
 	Delete(id string) error
 }
@@ -43,6 +70,14 @@ type CabinRepository interface {
 	Delete(id string) error
 }
 
+// FacilityRepository defines the interface for cabin facility data access
+type FacilityRepository interface {
+	Create(facility *models.CabinFacility) error
+	GetByID(id string) (*models.CabinFacility, error)
+	GetByCabinID(cabinID string) ([]*models.CabinFacility, error)
+	Delete(id string) error
+}
+
 // SeatRepository defines the interface for seat repository operations
 type SeatRepository interface {
 	Create(seat *models.Seat) error
@@ -65,10 +100,74 @@ type BookingRepository interface {
 	CreateSeat(bookingSeat *models.BookingSeat) error
 	GetByID(id string) (*models.Booking, error)
 	GetByUserID(userID string) ([]*models.Booking, error)
+	GetExpiredHolds(before time.Time) ([]*models.Booking, error)
 	GetSeatsByBookingID(bookingID string) ([]*models.BookingSeat, error)
 	Update(booking *models.Booking) error
 	Delete(id string) error
 	DeleteSeats(bookingID string) error
+	CreateWithSeats(booking *models.Booking, seats []*models.BookingSeat, changes []*models.BookingStatusChange) error
+	UpdateStatus(booking *models.Booking, change *models.BookingStatusChange) error
+	GetStatusHistory(bookingID string) ([]*models.BookingStatusChange, error)
+	Cancel(booking *models.Booking, change *models.BookingStatusChange, refunds []*models.SeatRefund) error
+	GetRefunds(bookingID string) ([]*models.SeatRefund, error)
+	GetSeatByID(id string) (*models.BookingSeat, error)
+	ChangeSeat(booking *models.Booking, bookingSeat *models.BookingSeat, change *models.SeatChange) error
+	SwapSeats(bookings []*models.Booking, first, second *models.BookingSeat, changes []*models.SeatChange) error
+	GetSeatChanges(bookingID string) ([]*models.SeatChange, error)
+	AddAncillaries(booking *models.Booking, ancillaries []*models.BookingAncillary) error
+	GetAncillaries(bookingID string) ([]*models.BookingAncillary, error)
+	GetByPNRID(pnrID string) ([]*models.Booking, error)
+	LinkPNR(booking *models.Booking, seats []*models.BookingSeat) error
+}
+
+// ExchangeRateRepository defines the interface for exchange rate data access
+type ExchangeRateRepository interface {
+	Create(rate *models.ExchangeRate) error
+	GetAll() ([]*models.ExchangeRate, error)
+	GetEffective(baseCurrency, quoteCurrency string, at time.Time) (*models.ExchangeRate, error)
+}
+
+// AirportRepository defines the interface for airport data access
+type AirportRepository interface {
+	GetByCode(code string) (*models.Airport, error)
+}
+
+// TaxRuleRepository defines the interface for tax rule data access
+type TaxRuleRepository interface {
+	GetApplicable(originCountry, destinationCountry string, at time.Time) ([]*models.TaxRule, error)
+}
+
+// PromotionRepository defines the interface for promotion data access
+type PromotionRepository interface {
+	Create(promotion *models.Promotion) error
+	Update(promotion *models.Promotion) error
+	GetAll() ([]*models.Promotion, error)
+	GetByCode(code string) (*models.Promotion, error)
+	GetByID(id string) (*models.Promotion, error)
+}
+
+// AncillaryRepository defines the interface for ancillary product and bundle data access
+type AncillaryRepository interface {
+	CreateProduct(product *models.AncillaryProduct) error
+	GetProducts() ([]*models.AncillaryProduct, error)
+	GetProductByID(id string) (*models.AncillaryProduct, error)
+	CreateBundle(bundle *models.Bundle) error
+	GetBundles() ([]*models.Bundle, error)
+	GetBundleByID(id string) (*models.Bundle, error)
+}
+
+// PNRRepository defines the interface for passenger name record data access
+type PNRRepository interface {
+	Create(pnr *models.PNR, passengerIDs []int) error
+	GetByID(id string) (*models.PNR, error)
+	GetByRecordLocator(locator string) (*models.PNR, error)
+}
+
+// PaymentRepository defines the interface for payment data access
+type PaymentRepository interface {
+	Create(payment *models.Payment) error
+	Update(payment *models.Payment) error
+	GetByBookingID(bookingID string) ([]*models.Payment, error)
 }
 
 // PassengerRepository defines the interface for passenger data access
@@ -76,7 +175,9 @@ type PassengerRepository interface {
 	Create(passenger *models.Passenger) error
 	GetByID(id string) (*models.Passenger, error)
 	GetBySegmentID(segmentID string) ([]*models.Passenger, error)
+	GetByUserID(userID string) ([]*models.Passenger, error)
 	GetByPassengerNameNumber(nameNumber string) (*models.Passenger, error)
+	GetByPNRID(pnrID string) ([]*models.Passenger, error)
 	GetAll() ([]*models.Passenger, error)
 	Update(passenger *models.Passenger) error
 	Delete(id string) error
@@ -94,5 +195,9 @@ type FrequentFlyerRepository interface {
 
 // RowRepository defines the interface for seat row data access
 type RowRepository interface {
+	Create(row *models.SeatRow) error
+	GetByID(id string) (*models.SeatRow, error)
 	GetByCabinID(cabinID string) ([]*models.SeatRow, error)
+	Update(row *models.SeatRow) error
+	Delete(id string) error
 }
\ No newline at end of file
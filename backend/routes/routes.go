@@ -2,12 +2,16 @@ package routes
 
 import (
 	"github.com/evaizee/seat-arrangements/backend/di"
-	// "github.com/evaizee/seat-arrangements/backend/middleware"
+	"github.com/evaizee/seat-arrangements/backend/middleware"
+	"github.com/evaizee/seat-arrangements/backend/models"
 	"github.com/gofiber/fiber/v2"
 )
 
 // SetupRoutes configures all the routes for the application
 func SetupRoutes(app *fiber.App, container *di.Container) {
+	// Public keys for verifying our tokens
+	app.Get("/.well-known/jwks.json", container.AuthController.JWKS)
+
 	// API group
 	api := app.Group("/api")
 
@@ -18,7 +22,117 @@ func SetupRoutes(app *fiber.App, container *di.Container) {
 		})
 	})
 
+	// Auth routes, rate limited per IP
+	auth := api.Group("/auth", middleware.AuthRateLimit())
+	auth.Post("/register", container.AuthController.Register)
+	auth.Post("/login", container.AuthController.Login)
+	auth.Post("/password/forgot", container.AuthController.ForgotPassword)
+	auth.Post("/password/reset", container.AuthController.ResetPassword)
+	auth.Post("/email/verify", container.AuthController.VerifyEmail)
+	auth.Post("/email/verification", middleware.JWTAuth(container.KeySet), container.AuthController.ResendVerification)
+
+	// Profile routes for the signed-in user
+	me := api.Group("/users/me", middleware.JWTAuth(container.KeySet))
+	me.Get("/", container.UserController.GetMe)
+	me.Put("/", container.UserController.UpdateMe)
+	me.Delete("/", container.UserController.DeleteMe)
+	me.Put("/password", container.UserController.ChangePassword)
+	me.Post("/passengers", container.UserController.LinkPassenger)
+	me.Delete("/passengers/:id", container.UserController.UnlinkPassenger)
+
+	// Passenger routes; passengers manage the passengers linked to their account,
+	// agents and admins may manage any and list the passengers of a segment
+	passenger := api.Group("/passengers", middleware.JWTAuth(container.KeySet), middleware.Idempotency(container.IdempotencyRepository))
+	passenger.Get("/", middleware.RequireRoles(models.RoleCheckInAgent), container.PassengerController.GetBySegment)
+	passenger.Post("/", container.PassengerController.Create)
+	passenger.Get("/:id", container.PassengerController.GetByID)
+	passenger.Put("/:id", container.PassengerController.Update)
+	passenger.Get("/:id/apis", container.PassengerController.GetAPIS)
+	passenger.Get("/:id/frequent-flyers", container.PassengerController.GetFrequentFlyers)
+	passenger.Post("/:id/frequent-flyers", container.PassengerController.AddFrequentFlyer)
+
+	// Reservation routes; a reservation is looked up by record locator and
+	// passenger last name without signing in, so the lookup is rate limited per IP
+	api.Get("/pnrs/:locator", middleware.AuthRateLimit(), container.PNRController.GetByRecordLocator)
+	pnr := api.Group("/pnrs", middleware.JWTAuth(container.KeySet), middleware.Idempotency(container.IdempotencyRepository))
+	pnr.Post("/", container.PNRController.Create)
+
+	// Flight routes
+	flight := api.Group("/flights")
+	flight.Get("/", container.FlightController.Search)
+	flight.Get("/:id", container.FlightController.GetByID)
+
 	// Seat routes
 	seat := api.Group("/seats")
 	seat.Get("/map", container.SeatController.GetSeatMap)
+
+	// Ancillary catalogue and bundle offers
+	api.Get("/ancillaries", container.AncillaryController.GetCatalogue)
+	api.Get("/offers", container.AncillaryController.GetOffers)
+
+	// Booking routes for the signed-in user; agents and admins may act on any booking.
+	// Mutations honour the Idempotency-Key header so retries are safe.
+	booking := api.Group("/bookings", middleware.JWTAuth(container.KeySet), middleware.Idempotency(container.IdempotencyRepository))
+	booking.Post("/", container.BookingController.Create)
+	booking.Post("/holds", container.BookingController.Hold)
+	booking.Get("/", container.BookingController.GetMine)
+	booking.Get("/:id", container.BookingController.GetByID)
+	booking.Post("/:id/confirm", container.BookingController.Confirm)
+	booking.Post("/:id/cancel", container.BookingController.Cancel)
+	booking.Get("/:id/refund", container.BookingController.GetRefund)
+	booking.Get("/:id/history", container.BookingController.GetHistory)
+	booking.Put("/:id/seats/:seatId", container.BookingController.ChangeSeat)
+	booking.Get("/:id/changes", container.BookingController.GetChanges)
+	booking.Post("/:id/ancillaries", container.BookingController.AddAncillary)
+	booking.Post("/:id/bundles", container.BookingController.AddBundle)
+	booking.Post("/:id/pnr", container.PNRController.LinkBooking)
+
+	// Agent routes, restricted to check-in agents and admins
+	agent := api.Group("/agent",
+		middleware.JWTAuth(container.KeySet),
+		middleware.RequireRoles(models.RoleCheckInAgent),
+		middleware.Idempotency(container.IdempotencyRepository),
+	)
+	agent.Put("/seats/:id/availability", container.SeatController.UpdateAvailability)
+	agent.Put("/rows/:id/availability", container.SeatController.UpdateRowAvailability)
+	agent.Post("/bookings/swap", container.BookingController.SwapSeats)
+	agent.Put("/bookings/:id/status", container.BookingController.UpdateStatus)
+
+	// Revenue management routes, restricted to revenue managers and admins
+	revenue := api.Group("/revenue",
+		middleware.JWTAuth(container.KeySet),
+		middleware.RequireRoles(models.RoleRevenueManager),
+	)
+	revenue.Get("/exchange-rates", container.CurrencyController.GetRates)
+	revenue.Post("/exchange-rates", container.CurrencyController.CreateRate)
+	revenue.Get("/promotions", container.PromotionController.GetAll)
+	revenue.Post("/promotions", container.PromotionController.Create)
+	revenue.Patch("/promotions/:code", container.PromotionController.SetActive)
+	revenue.Post("/ancillaries", container.AncillaryController.CreateProduct)
+	revenue.Post("/bundles", container.AncillaryController.CreateBundle)
+
+	// Admin routes for managing aircraft layouts, restricted to admins
+	admin := api.Group("/admin", middleware.JWTAuth(container.KeySet), middleware.RequireRoles(models.RoleAdmin))
+	admin.Get("/aircraft", container.AircraftController.GetAll)
+	admin.Post("/aircraft", container.AircraftController.Create)
+	admin.Get("/aircraft/:id", container.AircraftController.GetByID)
+	admin.Put("/aircraft/:id", container.AircraftController.Update)
+	admin.Delete("/aircraft/:id", container.AircraftController.Delete)
+	admin.Get("/aircraft/:id/cabins", container.AircraftController.GetCabins)
+	admin.Post("/cabins", container.CabinController.Create)
+	admin.Get("/cabins/:id", container.CabinController.GetByID)
+	admin.Put("/cabins/:id", container.CabinController.Update)
+	admin.Delete("/cabins/:id", container.CabinController.Delete)
+	admin.Get("/cabins/:id/rows", container.CabinController.GetRows)
+	admin.Post("/cabins/:id/rows", container.CabinController.CreateRow)
+	admin.Get("/cabins/:id/facilities", container.CabinController.GetFacilities)
+	admin.Post("/cabins/:id/facilities", container.CabinController.AddFacility)
+	admin.Delete("/facilities/:id", container.CabinController.DeleteFacility)
+	admin.Put("/rows/:id", container.CabinController.UpdateRow)
+	admin.Delete("/rows/:id", container.CabinController.DeleteRow)
+	admin.Get("/rows/:id/seats", container.CabinController.GetRowSeats)
+	admin.Post("/rows/:id/seats", container.SeatController.CreateSeat)
+	admin.Put("/seats/:id", container.SeatController.UpdateSeat)
+	admin.Delete("/seats/:id", container.SeatController.DeleteSeat)
+	admin.Put("/seats/:id/price", container.SeatController.SetPrice)
 }
@@ -0,0 +1,77 @@
+package security
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+)
+
+// JWK is a single public key in JSON Web Key format (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKSet is the document served at /.well-known/jwks.json
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public half of every asymmetric key in the set.
+// HMAC secrets are never published.
+func (ks *KeySet) JWKS() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+
+	for _, id := range ks.order {
+		key := ks.keys[id]
+
+		switch publicKey := key.publicKey.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kty: "RSA",
+				Use: "sig",
+				Alg: key.Algorithm,
+				Kid: key.ID,
+				N:   encodeBase64URL(publicKey.N.Bytes()),
+				E:   encodeBase64URL(big.NewInt(int64(publicKey.E)).Bytes()),
+			})
+		case *ecdsa.PublicKey:
+			size := (publicKey.Curve.Params().BitSize + 7) / 8
+			set.Keys = append(set.Keys, JWK{
+				Kty: "EC",
+				Use: "sig",
+				Alg: key.Algorithm,
+				Kid: key.ID,
+				Crv: publicKey.Curve.Params().Name,
+				X:   encodeBase64URL(padBytes(publicKey.X.Bytes(), size)),
+				Y:   encodeBase64URL(padBytes(publicKey.Y.Bytes(), size)),
+			})
+		}
+	}
+
+	return set
+}
+
+// encodeBase64URL encodes bytes as unpadded base64url
+func encodeBase64URL(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// padBytes left-pads data with zeros to size bytes, as required for EC coordinates
+func padBytes(data []byte, size int) []byte {
+	if len(data) >= size {
+		return data
+	}
+
+	padded := make([]byte, size)
+	copy(padded[size-len(data):], data)
+	return padded
+}
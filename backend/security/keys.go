@@ -0,0 +1,264 @@
+package security
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/viper"
+)
+
+// Supported JWT signing algorithms
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmES256 = "ES256"
+)
+
+var (
+	// ErrUnknownKey is returned when a token references a key ID that is not configured
+	ErrUnknownKey = errors.New("unknown signing key")
+	// ErrAlgorithmMismatch is returned when a token's algorithm differs from its key's algorithm
+	ErrAlgorithmMismatch = errors.New("token algorithm does not match key")
+)
+
+// Key is a single JWT signing or verification key
+type Key struct {
+	ID         string
+	Algorithm  string
+	privateKey interface{}
+	publicKey  interface{}
+}
+
+// CanSign reports whether the key holds private material
+func (k *Key) CanSign() bool {
+	return k.privateKey != nil
+}
+
+// KeySet holds the key used to sign new tokens and every key accepted when verifying them
+type KeySet struct {
+	signingKey *Key
+	keys       map[string]*Key
+	order      []string
+}
+
+// keyConfig is a single entry of jwt.keys in the configuration file
+type keyConfig struct {
+	ID             string `mapstructure:"id"`
+	Algorithm      string `mapstructure:"algorithm"`
+	PrivateKeyFile string `mapstructure:"private_key_file"`
+	PublicKeyFile  string `mapstructure:"public_key_file"`
+}
+
+// LoadKeySet builds a KeySet from the jwt.* configuration.
+// With jwt.algorithm set to HS256 (the default) tokens are signed with jwt.secret.
+// With RS256 or ES256 the keys listed under jwt.keys are loaded from PEM files; the
+// one named by jwt.signing_key_id signs new tokens and the rest are verify-only,
+// which allows keys to be rotated without invalidating tokens already issued.
+func LoadKeySet() (*KeySet, error) {
+	algorithm := viper.GetString("jwt.algorithm")
+	if algorithm == "" {
+		algorithm = AlgorithmHS256
+	}
+
+	if algorithm == AlgorithmHS256 {
+		secret := viper.GetString("jwt.secret")
+		if secret == "" {
+			return nil, errors.New("jwt.secret is required for HS256")
+		}
+
+		key := &Key{
+			Algorithm:  AlgorithmHS256,
+			privateKey: []byte(secret),
+			publicKey:  []byte(secret),
+		}
+		return &KeySet{
+			signingKey: key,
+			keys:       map[string]*Key{},
+		}, nil
+	}
+
+	var configs []keyConfig
+	if err := viper.UnmarshalKey("jwt.keys", &configs); err != nil {
+		return nil, fmt.Errorf("failed to read jwt.keys: %w", err)
+	}
+
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("jwt.keys is required for %s", algorithm)
+	}
+
+	keySet := &KeySet{
+		keys: make(map[string]*Key, len(configs)),
+	}
+
+	for _, cfg := range configs {
+		if cfg.Algorithm == "" {
+			cfg.Algorithm = algorithm
+		}
+
+		key, err := loadKey(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, exists := keySet.keys[key.ID]; exists {
+			return nil, fmt.Errorf("duplicate jwt key id %q", key.ID)
+		}
+
+		keySet.keys[key.ID] = key
+		keySet.order = append(keySet.order, key.ID)
+	}
+
+	// Pick the signing key
+	signingKeyID := viper.GetString("jwt.signing_key_id")
+	if signingKeyID == "" {
+		for _, id := range keySet.order {
+			if keySet.keys[id].CanSign() {
+				signingKeyID = id
+				break
+			}
+		}
+	}
+
+	signingKey, ok := keySet.keys[signingKeyID]
+	if !ok || !signingKey.CanSign() {
+		return nil, fmt.Errorf("no private key configured for signing key id %q", signingKeyID)
+	}
+	keySet.signingKey = signingKey
+
+	return keySet, nil
+}
+
+// loadKey reads the PEM files of a single configured key
+func loadKey(cfg keyConfig) (*Key, error) {
+	if cfg.ID == "" {
+		return nil, errors.New("jwt key id is required")
+	}
+
+	if cfg.PrivateKeyFile == "" && cfg.PublicKeyFile == "" {
+		return nil, fmt.Errorf("jwt key %q needs a private_key_file or public_key_file", cfg.ID)
+	}
+
+	key := &Key{
+		ID:        cfg.ID,
+		Algorithm: cfg.Algorithm,
+	}
+
+	switch cfg.Algorithm {
+	case AlgorithmRS256:
+		if cfg.PrivateKeyFile != "" {
+			data, err := os.ReadFile(cfg.PrivateKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read private key for %q: %w", cfg.ID, err)
+			}
+			privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse RSA private key for %q: %w", cfg.ID, err)
+			}
+			key.privateKey = privateKey
+			key.publicKey = &privateKey.PublicKey
+		} else {
+			data, err := os.ReadFile(cfg.PublicKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read public key for %q: %w", cfg.ID, err)
+			}
+			publicKey, err := jwt.ParseRSAPublicKeyFromPEM(data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse RSA public key for %q: %w", cfg.ID, err)
+			}
+			key.publicKey = publicKey
+		}
+	case AlgorithmES256:
+		var publicKey *ecdsa.PublicKey
+		if cfg.PrivateKeyFile != "" {
+			data, err := os.ReadFile(cfg.PrivateKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read private key for %q: %w", cfg.ID, err)
+			}
+			privateKey, err := jwt.ParseECPrivateKeyFromPEM(data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse EC private key for %q: %w", cfg.ID, err)
+			}
+			key.privateKey = privateKey
+			publicKey = &privateKey.PublicKey
+		} else {
+			data, err := os.ReadFile(cfg.PublicKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read public key for %q: %w", cfg.ID, err)
+			}
+			publicKey, err = jwt.ParseECPublicKeyFromPEM(data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse EC public key for %q: %w", cfg.ID, err)
+			}
+		}
+
+		if publicKey.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("jwt key %q must use the P-256 curve for ES256", cfg.ID)
+		}
+		key.publicKey = publicKey
+	default:
+		return nil, fmt.Errorf("unsupported jwt algorithm %q for key %q", cfg.Algorithm, cfg.ID)
+	}
+
+	return key, nil
+}
+
+// SigningKey returns the key used to sign new tokens
+func (ks *KeySet) SigningKey() *Key {
+	return ks.signingKey
+}
+
+// Sign signs the claims with the current signing key, setting the kid header
+func (ks *KeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(ks.signingKey.Algorithm), claims)
+	if ks.signingKey.ID != "" {
+		token.Header["kid"] = ks.signingKey.ID
+	}
+
+	return token.SignedString(ks.signingKey.privateKey)
+}
+
+// Keyfunc resolves the verification key for a token from its kid header.
+// Tokens without a kid are verified against the signing key.
+func (ks *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	key := ks.signingKey
+	if kid, ok := token.Header["kid"].(string); ok && kid != "" {
+		key, ok = ks.keys[kid]
+		if !ok {
+			return nil, ErrUnknownKey
+		}
+	}
+
+	if token.Method.Alg() != key.Algorithm {
+		return nil, ErrAlgorithmMismatch
+	}
+
+	return key.publicKey, nil
+}
+
+// Parse verifies a token string against the key set
+func (ks *KeySet) Parse(tokenString string) (*jwt.Token, error) {
+	return jwt.Parse(tokenString, ks.Keyfunc, jwt.WithValidMethods(ks.algorithms()))
+}
+
+// algorithms lists the algorithms of every key in the set
+func (ks *KeySet) algorithms() []string {
+	if len(ks.keys) == 0 {
+		return []string{ks.signingKey.Algorithm}
+	}
+
+	seen := map[string]bool{}
+	algorithms := []string{}
+	for _, id := range ks.order {
+		algorithm := ks.keys[id].Algorithm
+		if !seen[algorithm] {
+			seen[algorithm] = true
+			algorithms = append(algorithms, algorithm)
+		}
+	}
+
+	return algorithms
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+)
+
+// Errors returned by services that controllers map to specific HTTP status codes
+var (
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrEmailAlreadyExists = errors.New("email already registered")
+	ErrInvalidToken       = errors.New("invalid token")
+	ErrWeakPassword       = errors.New("password must be at least 8 characters")
+	ErrUserNotFound       = errors.New("user not found")
+	ErrAlreadyVerified    = errors.New("email already verified")
+	ErrEmailNotVerified   = errors.New("verify your email address first")
+	ErrContactMismatch    = errors.New("passenger contact email does not match your account")
+	ErrAccountLocked      = errors.New("account temporarily locked")
+	ErrIncorrectPassword  = errors.New("current password is incorrect")
+	ErrPassengerNotFound  = errors.New("passenger not found")
+	ErrPassengerLinked    = errors.New("passenger is managed by another account")
+	ErrNameMismatch       = errors.New("last name does not match passenger")
+	ErrFlightNotFound     = errors.New("flight not found")
+	ErrAircraftNotFound   = errors.New("aircraft not found")
+	ErrCabinNotFound      = errors.New("cabin not found")
+	ErrRowNotFound        = errors.New("row not found")
+	ErrSeatNotFound       = errors.New("seat not found")
+	ErrInvalidLayout      = errors.New("invalid seat layout")
+	ErrInUse              = errors.New("resource is still referenced")
+	ErrOrphanedSeat       = errors.New("seat does not belong to a cabin row of the flight")
+	ErrInvalidDeck        = errors.New("unknown deck")
+	ErrFacilityNotFound   = errors.New("facility not found")
+	ErrBookingNotFound    = errors.New("booking not found")
+	ErrBookingSeatMissing = errors.New("seat is not part of the booking")
+	ErrBookingNotActive   = errors.New("booking is not confirmed")
+	ErrSeatUnavailable    = errors.New("seat is not available")
+	ErrInvalidSeatChange  = errors.New("invalid seat change")
+	ErrConcurrentUpdate   = errors.New("booking was changed by another request, please retry")
+	ErrInvalidTransition  = errors.New("booking status transition is not allowed")
+	ErrInvalidStatus      = errors.New("unknown booking status")
+	ErrInvalidBooking     = errors.New("invalid booking")
+	ErrPaymentRequired    = errors.New("a payment method is required for chargeable seats")
+	ErrPaymentDeclined    = errors.New("payment was declined")
+	ErrPaymentFailed      = errors.New("payment could not be processed")
+	ErrFlightDeparted     = errors.New("flight has already departed")
+	ErrRefundNotFound     = errors.New("booking has no refund")
+	ErrBookingNotHeld     = errors.New("booking is not on hold")
+	ErrHoldExpired        = errors.New("seat hold has expired")
+	ErrInvalidCurrency    = errors.New("invalid currency code")
+	ErrRateNotFound       = errors.New("no exchange rate for the currency pair")
+	ErrInvalidRate        = errors.New("invalid exchange rate")
+	ErrRateExists         = errors.New("an exchange rate for the currency pair already takes effect at this time")
+	ErrInvalidPromotion   = errors.New("invalid promotion")
+	ErrPromotionExists    = errors.New("a promotion with this code already exists")
+	ErrPromotionNotFound  = errors.New("promotion code not found")
+	ErrPromotionNotActive = errors.New("promotion code is not valid at this time")
+	ErrPromotionNotUsable = errors.New("promotion code does not apply to this flight or these seats")
+	ErrPromotionExhausted = errors.New("promotion code has reached its usage limit")
+	ErrProductNotFound    = errors.New("ancillary product not found")
+	ErrBundleNotFound     = errors.New("bundle not found")
+	ErrInvalidAncillary   = errors.New("invalid ancillary product or bundle")
+	ErrAncillaryExists    = errors.New("an ancillary product or bundle with this code already exists")
+	ErrBundleNotOffered   = errors.New("bundle is not offered with this seat")
+	ErrInvalidPassenger   = errors.New("invalid passenger data")
+	ErrFlyerNumberExists  = errors.New("passenger already has this frequent flyer number")
+	ErrPNRNotFound        = errors.New("reservation not found")
+	ErrItineraryNotFound  = errors.New("itinerary not found")
+	ErrInvalidPNR         = errors.New("invalid reservation")
+	ErrPassengerInPNR     = errors.New("passenger is already on a reservation")
+	ErrAPISIncomplete     = errors.New("advance passenger information is incomplete")
+)
+
+// AccountLockedError is returned by login while an account is locked out
+type AccountLockedError struct {
+	Until time.Time
+}
+
+// Error implements the error interface
+func (e *AccountLockedError) Error() string {
+	return ErrAccountLocked.Error()
+}
+
+// Is lets errors.Is match AccountLockedError against ErrAccountLocked
+func (e *AccountLockedError) Is(target error) bool {
+	return target == ErrAccountLocked
+}
+
+// ValidationError is returned when a record fails validation, carrying the error of each invalid field
+type ValidationError struct {
+	Err    error
+	Fields models.ValidationErrors
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	return e.Err.Error() + ": " + e.Fields.Error()
+}
+
+// Unwrap lets errors.Is match ValidationError against its sentinel error
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// NewValidationError wraps the ValidationErrors returned by a model's Validate in a ValidationError
+func NewValidationError(sentinel, err error) error {
+	var fields models.ValidationErrors
+	if errors.As(err, &fields) {
+		return &ValidationError{Err: sentinel, Fields: fields}
+	}
+	return fmt.Errorf("%w: %v", sentinel, err)
+}
@@ -0,0 +1,131 @@
+package impl
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+	"github.com/evaizee/seat-arrangements/backend/services"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// AircraftService is an implementation of the AircraftService interface
+type AircraftService struct {
+	aircraftRepository repositories.AircraftRepository
+}
+
+// NewAircraftService creates a new AircraftService
+func NewAircraftService(aircraftRepository repositories.AircraftRepository) services.AircraftService {
+	return &AircraftService{
+		aircraftRepository: aircraftRepository,
+	}
+}
+
+// GetByID retrieves an aircraft by ID
+func (s *AircraftService) GetByID(id string) (*models.Aircraft, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, services.ErrAircraftNotFound
+	}
+
+	aircraft, err := s.aircraftRepository.GetByID(id)
+	if err != nil {
+		zap.L().Error("Failed to get aircraft", zap.Error(err), zap.String("aircraft_id", id))
+		return nil, err
+	}
+
+	if aircraft == nil {
+		return nil, services.ErrAircraftNotFound
+	}
+
+	return aircraft, nil
+}
+
+// GetAll retrieves all aircraft
+func (s *AircraftService) GetAll() ([]*models.Aircraft, error) {
+	return s.aircraftRepository.GetAll()
+}
+
+// CreateAircraft creates a new aircraft with a unique equipment code
+func (s *AircraftService) CreateAircraft(code, name string) (*models.Aircraft, error) {
+	code, name, err := s.validateAircraft("", code, name)
+	if err != nil {
+		return nil, err
+	}
+
+	aircraft := models.NewAircraft(code, name)
+	if err := s.aircraftRepository.Create(aircraft); err != nil {
+		zap.L().Error("Failed to create aircraft", zap.Error(err), zap.String("code", code))
+		return nil, err
+	}
+
+	return aircraft, nil
+}
+
+// UpdateAircraft updates the code and name of an aircraft
+func (s *AircraftService) UpdateAircraft(id, code, name string) (*models.Aircraft, error) {
+	aircraft, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	code, name, err = s.validateAircraft(id, code, name)
+	if err != nil {
+		return nil, err
+	}
+
+	aircraft.Code = code
+	aircraft.Name = name
+	aircraft.UpdatedAt = time.Now()
+
+	if err := s.aircraftRepository.Update(aircraft); err != nil {
+		zap.L().Error("Failed to update aircraft", zap.Error(err), zap.String("aircraft_id", id))
+		return nil, err
+	}
+
+	return aircraft, nil
+}
+
+// DeleteAircraft deletes an aircraft that has no cabins left
+func (s *AircraftService) DeleteAircraft(id string) error {
+	if _, err := s.GetByID(id); err != nil {
+		return err
+	}
+
+	if err := s.aircraftRepository.Delete(id); err != nil {
+		if errors.Is(err, repositories.ErrReferenced) {
+			return services.ErrInUse
+		}
+		zap.L().Error("Failed to delete aircraft", zap.Error(err), zap.String("aircraft_id", id))
+		return err
+	}
+
+	return nil
+}
+
+// validateAircraft normalizes the aircraft fields and checks the code is not used by another aircraft
+func (s *AircraftService) validateAircraft(id, code, name string) (string, string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	name = strings.TrimSpace(name)
+
+	if code == "" || len(code) > 10 {
+		return "", "", fmt.Errorf("%w: aircraft code must be 1-10 characters", services.ErrInvalidLayout)
+	}
+	if name == "" {
+		return "", "", fmt.Errorf("%w: aircraft name is required", services.ErrInvalidLayout)
+	}
+
+	existing, err := s.aircraftRepository.GetByCode(code)
+	if err != nil {
+		zap.L().Error("Failed to get aircraft by code", zap.Error(err), zap.String("code", code))
+		return "", "", err
+	}
+	if existing != nil && existing.ID != id {
+		return "", "", fmt.Errorf("%w: aircraft code %s is already in use", services.ErrInvalidLayout, code)
+	}
+
+	return code, name, nil
+}
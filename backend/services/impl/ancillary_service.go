@@ -0,0 +1,123 @@
+package impl
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+	"github.com/evaizee/seat-arrangements/backend/services"
+	"go.uber.org/zap"
+)
+
+// AncillaryService is an implementation of the AncillaryService interface
+type AncillaryService struct {
+	ancillaryRepository repositories.AncillaryRepository
+}
+
+// NewAncillaryService creates a new AncillaryService
+func NewAncillaryService(ancillaryRepository repositories.AncillaryRepository) services.AncillaryService {
+	return &AncillaryService{
+		ancillaryRepository: ancillaryRepository,
+	}
+}
+
+// GetProducts retrieves all ancillary products
+func (s *AncillaryService) GetProducts() ([]*models.AncillaryProduct, error) {
+	products, err := s.ancillaryRepository.GetProducts()
+	if err != nil {
+		zap.L().Error("Failed to get ancillary products", zap.Error(err))
+		return nil, err
+	}
+
+	return products, nil
+}
+
+// GetBundles retrieves all bundles with their products
+func (s *AncillaryService) GetBundles() ([]*models.Bundle, error) {
+	bundles, err := s.ancillaryRepository.GetBundles()
+	if err != nil {
+		zap.L().Error("Failed to get bundles", zap.Error(err))
+		return nil, err
+	}
+
+	return bundles, nil
+}
+
+// CreateProduct validates and adds an ancillary product to the catalogue
+func (s *AncillaryService) CreateProduct(product *models.AncillaryProduct) (*models.AncillaryProduct, error) {
+	product.Code = strings.ToUpper(strings.TrimSpace(product.Code))
+	product.Category = strings.ToLower(strings.TrimSpace(product.Category))
+	product.Price.Currency = strings.ToUpper(product.Price.Currency)
+
+	if err := product.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", services.ErrInvalidAncillary, err)
+	}
+
+	if err := s.ancillaryRepository.CreateProduct(product); err != nil {
+		if errors.Is(err, repositories.ErrDuplicate) {
+			return nil, services.ErrAncillaryExists
+		}
+		zap.L().Error("Failed to create ancillary product", zap.Error(err), zap.String("code", product.Code))
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// CreateBundle validates and adds a bundle of existing products to the catalogue
+func (s *AncillaryService) CreateBundle(code, name, description string, seatCharacteristics []string, discountPercent string, productIDs []string) (*models.Bundle, error) {
+	products := make([]*models.AncillaryProduct, 0, len(productIDs))
+	for _, productID := range productIDs {
+		product, err := s.ancillaryRepository.GetProductByID(productID)
+		if err != nil {
+			zap.L().Error("Failed to get ancillary product", zap.Error(err), zap.String("product_id", productID))
+			return nil, err
+		}
+
+		if product == nil {
+			return nil, fmt.Errorf("%w: %s", services.ErrProductNotFound, productID)
+		}
+		products = append(products, product)
+	}
+
+	if strings.TrimSpace(discountPercent) == "" {
+		discountPercent = "0"
+	}
+
+	bundle := models.NewBundle(
+		strings.ToUpper(strings.TrimSpace(code)),
+		strings.TrimSpace(name),
+		description,
+		upperAll(seatCharacteristics),
+		strings.TrimSpace(discountPercent),
+		products,
+	)
+
+	if err := bundle.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", services.ErrInvalidAncillary, err)
+	}
+
+	if err := s.ancillaryRepository.CreateBundle(bundle); err != nil {
+		if errors.Is(err, repositories.ErrDuplicate) {
+			return nil, services.ErrAncillaryExists
+		}
+		zap.L().Error("Failed to create bundle", zap.Error(err), zap.String("code", bundle.Code))
+		return nil, err
+	}
+
+	return bundle, nil
+}
+
+// bundleDiscount returns the bundle discount on the price of one of its products
+func bundleDiscount(bundle *models.Bundle, price models.Money) (models.Money, error) {
+	percent, err := bundle.DiscountValue()
+	if err != nil {
+		zap.L().Error("Invalid bundle", zap.Error(err), zap.String("code", bundle.Code))
+		return models.Money{}, err
+	}
+
+	return price.Multiply(percent.Quo(percent, big.NewRat(100, 1))), nil
+}
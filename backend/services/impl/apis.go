@@ -0,0 +1,115 @@
+package impl
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+	"github.com/evaizee/seat-arrangements/backend/services"
+	"go.uber.org/zap"
+)
+
+// checkAPIS checks a passenger's advance passenger information against the
+// route of their segment, whose airports' countries decide what is required.
+// A passenger on a reservation is also checked against their records on its
+// other segments.
+func checkAPIS(
+	passengerRepository repositories.PassengerRepository,
+	flightRepository repositories.FlightRepository,
+	airportRepository repositories.AirportRepository,
+	passenger *models.Passenger,
+) (*models.APISCheck, error) {
+	flight, err := flightRepository.GetByID(passenger.SegmentID)
+	if err != nil {
+		zap.L().Error("Failed to get flight", zap.Error(err), zap.String("flight_id", passenger.SegmentID))
+		return nil, err
+	}
+
+	if flight == nil {
+		return nil, services.ErrFlightNotFound
+	}
+
+	originCountry, err := airportCountry(airportRepository, flight.Origin)
+	if err != nil {
+		return nil, err
+	}
+
+	destinationCountry, err := airportCountry(airportRepository, flight.Destination)
+	if err != nil {
+		return nil, err
+	}
+
+	records := []*models.Passenger{}
+	if passenger.PNRID != nil {
+		onPNR, err := passengerRepository.GetByPNRID(*passenger.PNRID)
+		if err != nil {
+			zap.L().Error("Failed to get PNR passengers", zap.Error(err), zap.String("pnr_id", *passenger.PNRID))
+			return nil, err
+		}
+
+		for _, record := range onPNR {
+			if record.PassengerNameNumber == passenger.PassengerNameNumber {
+				records = append(records, record)
+			}
+		}
+	}
+
+	check := &models.APISCheck{
+		PassengerID:  passenger.ID,
+		SegmentID:    passenger.SegmentID,
+		Requirements: models.APISRequirementsFor(originCountry, destinationCountry),
+		Complete:     true,
+		Errors:       models.ValidationErrors{},
+	}
+
+	err = passenger.ValidateAPIS(check.Requirements, flight.Departure, records)
+	if errors.As(err, &check.Errors) {
+		check.Complete = false
+	} else if err != nil {
+		return nil, err
+	}
+
+	return check, nil
+}
+
+// checkAPISForSeats checks that every seat of a booking is assigned to a
+// passenger whose advance passenger information is complete, returning a
+// ValidationError that names each missing or invalid field by booking seat
+// and passenger ID.
+func checkAPISForSeats(
+	passengerRepository repositories.PassengerRepository,
+	flightRepository repositories.FlightRepository,
+	airportRepository repositories.AirportRepository,
+	seats []*models.BookingSeat,
+) error {
+	var errs models.ValidationErrors
+
+	for _, seat := range seats {
+		if seat.PassengerID == nil {
+			errs.Add("booking_seats."+seat.ID+".passenger_id", "must be assigned a passenger")
+			continue
+		}
+
+		passenger, err := passengerRepository.GetByID(strconv.Itoa(*seat.PassengerID))
+		if err != nil {
+			zap.L().Error("Failed to get passenger", zap.Error(err), zap.Int("passenger_id", *seat.PassengerID))
+			return err
+		}
+
+		check, err := checkAPIS(passengerRepository, flightRepository, airportRepository, passenger)
+		if err != nil {
+			return err
+		}
+
+		prefix := "passengers." + strconv.Itoa(passenger.ID) + "."
+		for _, fieldError := range check.Errors {
+			errs.Add(prefix+fieldError.Field, "%s", fieldError.Message)
+		}
+	}
+
+	if len(errs) > 0 {
+		return &services.ValidationError{Err: services.ErrAPISIncomplete, Fields: errs}
+	}
+	return nil
+}
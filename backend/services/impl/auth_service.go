@@ -0,0 +1,429 @@
+package impl
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/evaizee/seat-arrangements/backend/mailer"
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+	"github.com/evaizee/seat-arrangements/backend/security"
+	"github.com/evaizee/seat-arrangements/backend/services"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// minPasswordLength is the minimum accepted password length
+const minPasswordLength = 8
+
+// AuthService is an implementation of the AuthService interface
+type AuthService struct {
+	userRepository      repositories.UserRepository
+	userTokenRepository repositories.UserTokenRepository
+	auditLogRepository  repositories.AuditLogRepository
+	keySet              *security.KeySet
+	mailer              mailer.Mailer
+}
+
+// NewAuthService creates a new AuthService
+func NewAuthService(
+	userRepository repositories.UserRepository,
+	userTokenRepository repositories.UserTokenRepository,
+	auditLogRepository repositories.AuditLogRepository,
+	keySet *security.KeySet,
+	mailer mailer.Mailer,
+) services.AuthService {
+	return &AuthService{
+		userRepository:      userRepository,
+		userTokenRepository: userTokenRepository,
+		auditLogRepository:  auditLogRepository,
+		keySet:              keySet,
+		mailer:              mailer,
+	}
+}
+
+// Register creates a new passenger account and sends an email verification link
+func (s *AuthService) Register(email, password, firstName, lastName string) (*models.User, error) {
+	email = normalizeEmail(email)
+
+	if len(password) < minPasswordLength {
+		return nil, services.ErrWeakPassword
+	}
+
+	// Check if the email is already taken
+	existing, err := s.userRepository.GetByEmail(email)
+	if err != nil {
+		zap.L().Error("Failed to get user by email", zap.Error(err))
+		return nil, err
+	}
+
+	if existing != nil {
+		return nil, services.ErrEmailAlreadyExists
+	}
+
+	// Create the user
+	user, err := models.NewUser(email, password, firstName, lastName)
+	if err != nil {
+		return nil, fmt.Errorf("error creating user: %w", err)
+	}
+
+	err = s.userRepository.Create(user)
+	if err != nil {
+		zap.L().Error("Failed to create user", zap.Error(err))
+		return nil, err
+	}
+
+	// The account is usable without verification, so a mail failure is not fatal
+	if err := s.sendEmailVerification(user); err != nil {
+		zap.L().Error("Failed to send verification email", zap.Error(err), zap.String("user_id", user.ID))
+	}
+
+	return user, nil
+}
+
+// Login checks the user's credentials and returns a signed JWT.
+// Consecutive failures lock the account for progressively longer periods.
+func (s *AuthService) Login(email, password, ipAddress string) (string, error) {
+	user, err := s.userRepository.GetByEmail(normalizeEmail(email))
+	if err != nil {
+		zap.L().Error("Failed to get user by email", zap.Error(err))
+		return "", err
+	}
+
+	if user == nil {
+		return "", services.ErrInvalidCredentials
+	}
+
+	now := time.Now()
+	if user.IsLocked(now) {
+		return "", &services.AccountLockedError{Until: *user.LockedUntil}
+	}
+
+	if !user.CheckPassword(password) {
+		return "", s.recordFailedLogin(user, ipAddress, now)
+	}
+
+	if user.FailedLoginAttempts > 0 || user.LockedUntil != nil {
+		err = s.userRepository.ResetFailedLogins(user.ID)
+		if err != nil {
+			zap.L().Error("Failed to reset failed logins", zap.Error(err), zap.String("user_id", user.ID))
+			return "", err
+		}
+	}
+
+	return s.generateToken(user)
+}
+
+// recordFailedLogin counts a failed login and locks the account once the threshold is reached.
+// It returns the error to report to the caller.
+func (s *AuthService) recordFailedLogin(user *models.User, ipAddress string, now time.Time) error {
+	attempts, err := s.userRepository.IncrementFailedLogins(user.ID)
+	if err != nil {
+		zap.L().Error("Failed to record failed login", zap.Error(err), zap.String("user_id", user.ID))
+		return err
+	}
+
+	threshold := viper.GetInt("auth.lockout.threshold")
+	if threshold <= 0 || attempts < threshold {
+		return services.ErrInvalidCredentials
+	}
+
+	duration := lockoutDuration(attempts, threshold)
+	until := now.Add(duration)
+
+	err = s.userRepository.LockUntil(user.ID, until)
+	if err != nil {
+		zap.L().Error("Failed to lock account", zap.Error(err), zap.String("user_id", user.ID))
+		return err
+	}
+
+	zap.L().Warn("Account locked after failed logins",
+		zap.String("user_id", user.ID),
+		zap.Int("attempts", attempts),
+		zap.Duration("duration", duration),
+		zap.String("ip_address", ipAddress))
+
+	userID := user.ID
+	details := fmt.Sprintf("locked for %s after %d failed login attempts", duration, attempts)
+	err = s.auditLogRepository.Create(models.NewAuditLog(&userID, models.AuditActionAccountLocked, ipAddress, details))
+	if err != nil {
+		zap.L().Error("Failed to write audit log", zap.Error(err), zap.String("user_id", user.ID))
+	}
+
+	return &services.AccountLockedError{Until: until}
+}
+
+// lockoutDuration doubles the lock for every failure past the threshold, up to auth.lockout.max_duration
+func lockoutDuration(attempts, threshold int) time.Duration {
+	base := viper.GetDuration("auth.lockout.base_duration")
+	if base <= 0 {
+		base = time.Minute
+	}
+
+	maxDuration := viper.GetDuration("auth.lockout.max_duration")
+	if maxDuration <= 0 {
+		maxDuration = time.Hour
+	}
+
+	duration := base
+	for i := threshold; i < attempts && duration < maxDuration; i++ {
+		duration *= 2
+	}
+
+	if duration > maxDuration {
+		duration = maxDuration
+	}
+
+	return duration
+}
+
+// ValidateToken parses a JWT and returns the user ID it was issued for
+func (s *AuthService) ValidateToken(tokenString string) (string, error) {
+	token, err := s.keySet.Parse(tokenString)
+	if err != nil || !token.Valid {
+		return "", services.ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return "", services.ErrInvalidToken
+	}
+
+	userID, ok := claims["user_id"].(string)
+	if !ok || userID == "" {
+		return "", services.ErrInvalidToken
+	}
+
+	return userID, nil
+}
+
+// RequestPasswordReset emails a password reset link to the user.
+// Unknown emails are ignored so the endpoint cannot be used to discover
+// accounts; for the same reason failures to issue or send the link are only
+// logged, since they can only happen for registered emails.
+func (s *AuthService) RequestPasswordReset(email string) error {
+	user, err := s.userRepository.GetByEmail(normalizeEmail(email))
+	if err != nil {
+		zap.L().Error("Failed to get user by email", zap.Error(err))
+		return err
+	}
+
+	if user == nil {
+		zap.L().Info("Password reset requested for unknown email")
+		return nil
+	}
+
+	token, err := s.issueToken(user.ID, models.TokenPurposePasswordReset, viper.GetDuration("auth.password_reset_ttl"), time.Hour)
+	if err != nil {
+		zap.L().Error("Failed to issue password reset token", zap.Error(err), zap.String("user_id", user.ID))
+		return nil
+	}
+
+	link := fmt.Sprintf("%s/reset-password?token=%s", viper.GetString("app.base_url"), token)
+	err = s.mailer.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nWe received a request to reset your password. "+
+			"Use the link below to choose a new one:\n\n%s\n\n"+
+			"If you did not request this, you can ignore this email.", user.FirstName, link),
+	})
+	if err != nil {
+		zap.L().Error("Failed to send password reset email", zap.Error(err), zap.String("user_id", user.ID))
+	}
+
+	return nil
+}
+
+// ResetPassword consumes a password reset token and sets a new password
+func (s *AuthService) ResetPassword(token, newPassword string) error {
+	if len(newPassword) < minPasswordLength {
+		return services.ErrWeakPassword
+	}
+
+	userToken, err := s.userTokenRepository.Consume(models.TokenPurposePasswordReset, hashToken(token))
+	if err != nil {
+		zap.L().Error("Failed to consume password reset token", zap.Error(err))
+		return err
+	}
+
+	if userToken == nil {
+		return services.ErrInvalidToken
+	}
+
+	user, err := s.userRepository.GetByID(userToken.UserID)
+	if err != nil {
+		zap.L().Error("Failed to get user", zap.Error(err), zap.String("user_id", userToken.UserID))
+		return err
+	}
+
+	if user == nil {
+		return services.ErrUserNotFound
+	}
+
+	if err := user.UpdatePassword(newPassword); err != nil {
+		return fmt.Errorf("error updating password: %w", err)
+	}
+
+	// Receiving the reset link proves ownership of the email address
+	if !user.IsEmailVerified() {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+	}
+
+	err = s.userRepository.Update(user)
+	if err != nil {
+		zap.L().Error("Failed to update user", zap.Error(err), zap.String("user_id", user.ID))
+		return err
+	}
+
+	// A successful reset lifts any lockout
+	err = s.userRepository.ResetFailedLogins(user.ID)
+	if err != nil {
+		zap.L().Error("Failed to reset failed logins", zap.Error(err), zap.String("user_id", user.ID))
+		return err
+	}
+
+	// Any other outstanding reset links are no longer valid
+	return s.userTokenRepository.InvalidateByUserID(user.ID, models.TokenPurposePasswordReset)
+}
+
+// RequestEmailVerification sends a new email verification link to the user
+func (s *AuthService) RequestEmailVerification(userID string) error {
+	user, err := s.userRepository.GetByID(userID)
+	if err != nil {
+		zap.L().Error("Failed to get user", zap.Error(err), zap.String("user_id", userID))
+		return err
+	}
+
+	if user == nil {
+		return services.ErrUserNotFound
+	}
+
+	if user.IsEmailVerified() {
+		return services.ErrAlreadyVerified
+	}
+
+	return s.sendEmailVerification(user)
+}
+
+// VerifyEmail consumes an email verification token and marks the user's email as verified
+func (s *AuthService) VerifyEmail(token string) error {
+	userToken, err := s.userTokenRepository.Consume(models.TokenPurposeEmailVerification, hashToken(token))
+	if err != nil {
+		zap.L().Error("Failed to consume email verification token", zap.Error(err))
+		return err
+	}
+
+	if userToken == nil {
+		return services.ErrInvalidToken
+	}
+
+	user, err := s.userRepository.GetByID(userToken.UserID)
+	if err != nil {
+		zap.L().Error("Failed to get user", zap.Error(err), zap.String("user_id", userToken.UserID))
+		return err
+	}
+
+	if user == nil {
+		return services.ErrUserNotFound
+	}
+
+	if user.IsEmailVerified() {
+		return nil
+	}
+
+	now := time.Now()
+	user.EmailVerifiedAt = &now
+	user.UpdatedAt = now
+
+	err = s.userRepository.Update(user)
+	if err != nil {
+		zap.L().Error("Failed to update user", zap.Error(err), zap.String("user_id", user.ID))
+		return err
+	}
+
+	return nil
+}
+
+// sendEmailVerification issues a verification token and emails the link to the user
+func (s *AuthService) sendEmailVerification(user *models.User) error {
+	token, err := s.issueToken(user.ID, models.TokenPurposeEmailVerification, viper.GetDuration("auth.email_verification_ttl"), 48*time.Hour)
+	if err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s/verify-email?token=%s", viper.GetString("app.base_url"), token)
+	return s.mailer.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nPlease confirm your email address using the link below:\n\n%s",
+			user.FirstName, link),
+	})
+}
+
+// issueToken invalidates the user's outstanding tokens for the purpose and stores a new one.
+// The plain token is returned to be emailed; only its hash is persisted.
+func (s *AuthService) issueToken(userID, purpose string, ttl, defaultTTL time.Duration) (string, error) {
+	if ttl == 0 {
+		ttl = defaultTTL
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("error generating token: %w", err)
+	}
+	token := hex.EncodeToString(raw)
+
+	err := s.userTokenRepository.InvalidateByUserID(userID, purpose)
+	if err != nil {
+		zap.L().Error("Failed to invalidate user tokens", zap.Error(err), zap.String("user_id", userID))
+		return "", err
+	}
+
+	err = s.userTokenRepository.Create(models.NewUserToken(userID, purpose, hashToken(token), ttl))
+	if err != nil {
+		zap.L().Error("Failed to create user token", zap.Error(err), zap.String("user_id", userID))
+		return "", err
+	}
+
+	return token, nil
+}
+
+// generateToken signs a JWT carrying the user's ID and role
+func (s *AuthService) generateToken(user *models.User) (string, error) {
+	expiration := viper.GetDuration("jwt.expiration")
+	if expiration == 0 {
+		expiration = 24 * time.Hour
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"user_id": user.ID,
+		"role":    user.Role,
+		"iat":     now.Unix(),
+		"exp":     now.Add(expiration).Unix(),
+	}
+
+	signed, err := s.keySet.Sign(claims)
+	if err != nil {
+		return "", fmt.Errorf("error signing token: %w", err)
+	}
+
+	return signed, nil
+}
+
+// hashToken returns the hex SHA-256 of a user token
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeEmail lowercases and trims an email address
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
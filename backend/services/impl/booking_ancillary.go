@@ -0,0 +1,180 @@
+package impl
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+	"github.com/evaizee/seat-arrangements/backend/services"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// AddAncillary adds an ancillary product to a seat of a held booking at its
+// current price in the booking's currency. It is paid for when the hold is confirmed.
+func (s *BookingService) AddAncillary(bookingID, bookingSeatID, productID string) (*models.BookingWithDetails, error) {
+	booking, bookingSeat, err := s.getHeldBookingSeat(bookingID, bookingSeatID)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := s.getProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	converter := newCurrencyConverter(s.exchangeRateRepository, time.Now())
+	price, err := converter.convert(product.Price, bookingSeat.Price.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	ancillary := models.NewBookingAncillary(bookingSeat, product.ID, "", price, models.ZeroMoney(price.Currency))
+	return s.addAncillaries(booking, []*models.BookingAncillary{ancillary})
+}
+
+// AddBundle adds the products of a bundle to a seat of a held booking, each
+// at its current price in the booking's currency less the bundle discount. The
+// seat must be one the bundle is offered with and may hold each bundle once.
+func (s *BookingService) AddBundle(bookingID, bookingSeatID, bundleID string) (*models.BookingWithDetails, error) {
+	booking, bookingSeat, err := s.getHeldBookingSeat(bookingID, bookingSeatID)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle, err := s.getBundle(bundleID)
+	if err != nil {
+		return nil, err
+	}
+
+	seat, err := s.getSeat(bookingSeat.SeatID)
+	if err != nil {
+		return nil, err
+	}
+
+	characteristics, err := newSeatLayouts(s.rowRepository, s.cabinRepository).characteristics(seat)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bundle.AppliesToSeat(characteristics) {
+		return nil, services.ErrBundleNotOffered
+	}
+
+	existing, err := s.bookingRepository.GetAncillaries(booking.ID)
+	if err != nil {
+		zap.L().Error("Failed to get booking ancillaries", zap.Error(err), zap.String("booking_id", booking.ID))
+		return nil, err
+	}
+
+	for _, ancillary := range existing {
+		if ancillary.BookingSeatID == bookingSeat.ID && ancillary.BundleID == bundle.ID {
+			return nil, fmt.Errorf("%w: the seat already has bundle %s", services.ErrInvalidBooking, bundle.Code)
+		}
+	}
+
+	converter := newCurrencyConverter(s.exchangeRateRepository, time.Now())
+	ancillaries := make([]*models.BookingAncillary, 0, len(bundle.Products))
+	for _, product := range bundle.Products {
+		price, err := converter.convert(product.Price, bookingSeat.Price.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		discount, err := bundleDiscount(bundle, price)
+		if err != nil {
+			return nil, err
+		}
+
+		ancillaries = append(ancillaries, models.NewBookingAncillary(bookingSeat, product.ID, bundle.ID, price, discount))
+	}
+
+	return s.addAncillaries(booking, ancillaries)
+}
+
+// addAncillaries stores ancillaries of a held booking and returns the booking with its new total
+func (s *BookingService) addAncillaries(booking *models.Booking, ancillaries []*models.BookingAncillary) (*models.BookingWithDetails, error) {
+	if err := s.bookingRepository.AddAncillaries(booking, ancillaries); err != nil {
+		if errors.Is(err, repositories.ErrConflict) {
+			return nil, services.ErrConcurrentUpdate
+		}
+		zap.L().Error("Failed to add ancillaries", zap.Error(err), zap.String("booking_id", booking.ID))
+		return nil, err
+	}
+
+	return s.loadDetails(booking)
+}
+
+// getHeldBookingSeat retrieves a booking that is on hold and one of its seats.
+// An expired hold is released and ErrHoldExpired returned.
+func (s *BookingService) getHeldBookingSeat(bookingID, bookingSeatID string) (*models.Booking, *models.BookingSeat, error) {
+	booking, err := s.getBooking(bookingID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if booking.Status != models.BookingStatusHeld {
+		return nil, nil, services.ErrBookingNotHeld
+	}
+
+	if booking.HoldExpired(time.Now()) {
+		s.releaseHold(booking)
+		return nil, nil, services.ErrHoldExpired
+	}
+
+	if _, err := uuid.Parse(bookingSeatID); err != nil {
+		return nil, nil, services.ErrBookingSeatMissing
+	}
+
+	bookingSeat, err := s.bookingRepository.GetSeatByID(bookingSeatID)
+	if err != nil {
+		zap.L().Error("Failed to get booking seat", zap.Error(err), zap.String("booking_seat_id", bookingSeatID))
+		return nil, nil, err
+	}
+
+	if bookingSeat == nil || bookingSeat.BookingID != booking.ID {
+		return nil, nil, services.ErrBookingSeatMissing
+	}
+
+	return booking, bookingSeat, nil
+}
+
+// getProduct retrieves an ancillary product that is on sale
+func (s *BookingService) getProduct(id string) (*models.AncillaryProduct, error) {
+	if s.ancillaryRepository == nil {
+		return nil, services.ErrProductNotFound
+	}
+
+	product, err := s.ancillaryRepository.GetProductByID(id)
+	if err != nil {
+		zap.L().Error("Failed to get ancillary product", zap.Error(err), zap.String("product_id", id))
+		return nil, err
+	}
+
+	if product == nil || !product.Active {
+		return nil, services.ErrProductNotFound
+	}
+
+	return product, nil
+}
+
+// getBundle retrieves a bundle that is on sale
+func (s *BookingService) getBundle(id string) (*models.Bundle, error) {
+	if s.ancillaryRepository == nil {
+		return nil, services.ErrBundleNotFound
+	}
+
+	bundle, err := s.ancillaryRepository.GetBundleByID(id)
+	if err != nil {
+		zap.L().Error("Failed to get bundle", zap.Error(err), zap.String("bundle_id", id))
+		return nil, err
+	}
+
+	if bundle == nil || !bundle.Active {
+		return nil, services.ErrBundleNotFound
+	}
+
+	return bundle, nil
+}
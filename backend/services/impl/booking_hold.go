@@ -0,0 +1,131 @@
+package impl
+
+import (
+	"errors"
+	"time"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+	"github.com/evaizee/seat-arrangements/backend/services"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// holdTTL returns how long held seats keep their quoted prices, from booking.hold.ttl
+func holdTTL() time.Duration {
+	ttl := viper.GetDuration("booking.hold.ttl")
+	if ttl <= 0 {
+		return 15 * time.Minute
+	}
+	return ttl
+}
+
+// HoldSeats quotes seats on a flight and holds them for a user without taking
+// payment. The seats are taken off sale and their prices, including any
+// promotional discount, locked until the hold expires; ConfirmHold pays for
+// them at the locked prices.
+func (s *BookingService) HoldSeats(userID, flightID string, seatIDs []string, currency, promoCode string) (*models.BookingWithDetails, error) {
+	flight, err := s.getFlight(flightID)
+	if err != nil {
+		return nil, err
+	}
+
+	booking := models.NewBooking(userID, flightID)
+	bookingSeats, err := s.priceSeats(booking, flight, seatIDs, currency, promoCode)
+	if err != nil {
+		return nil, err
+	}
+
+	statusChanges := []*models.BookingStatusChange{
+		booking.InitialStatusChange(optionalUserID(userID)),
+		booking.Hold(holdTTL(), optionalUserID(userID)),
+	}
+	if err := s.createWithSeats(booking, bookingSeats, statusChanges); err != nil {
+		return nil, err
+	}
+
+	return s.withDetails(booking, flight, bookingSeats, nil, nil), nil
+}
+
+// ConfirmHold charges a held booking at its locked prices, with the ancillaries
+// added while it was held, and confirms it. A
+// declined payment leaves the hold in place so another payment method can be
+// tried; an expired hold is released and ErrHoldExpired returned.
+func (s *BookingService) ConfirmHold(bookingID, userID, paymentMethod string) (*models.BookingWithDetails, error) {
+	booking, err := s.getBooking(bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	if booking.Status != models.BookingStatusHeld {
+		return nil, services.ErrBookingNotHeld
+	}
+
+	if booking.HoldExpired(time.Now()) {
+		s.releaseHold(booking)
+		return nil, services.ErrHoldExpired
+	}
+
+	bookingSeats, err := s.bookingRepository.GetSeatsByBookingID(booking.ID)
+	if err != nil {
+		zap.L().Error("Failed to get booking seats", zap.Error(err), zap.String("booking_id", bookingID))
+		return nil, err
+	}
+
+	ancillaries, err := s.bookingRepository.GetAncillaries(booking.ID)
+	if err != nil {
+		zap.L().Error("Failed to get booking ancillaries", zap.Error(err), zap.String("booking_id", bookingID))
+		return nil, err
+	}
+
+	total := bookingTotal(bookingSeats, ancillaries)
+	if total.IsPositive() && paymentMethod == "" {
+		return nil, services.ErrPaymentRequired
+	}
+
+	bookingPayments, err := s.chargeBooking(booking, total, paymentMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.confirmPaidBooking(booking, userID, bookingPayments); err != nil {
+		if errors.Is(err, repositories.ErrConflict) {
+			return nil, services.ErrConcurrentUpdate
+		}
+		return nil, err
+	}
+
+	return s.loadDetails(booking)
+}
+
+// ReleaseExpiredHolds cancels held bookings whose hold has expired, putting
+// their seats back on sale, and returns how many were released
+func (s *BookingService) ReleaseExpiredHolds() (int, error) {
+	bookings, err := s.bookingRepository.GetExpiredHolds(time.Now())
+	if err != nil {
+		zap.L().Error("Failed to get expired holds", zap.Error(err))
+		return 0, err
+	}
+
+	released := 0
+	for _, booking := range bookings {
+		if s.releaseHold(booking) {
+			released++
+		}
+	}
+
+	return released, nil
+}
+
+// releaseHold cancels an expired hold, reporting whether it was released. A
+// hold confirmed or released by another request in the meantime is left alone.
+func (s *BookingService) releaseHold(booking *models.Booking) bool {
+	change := booking.TransitionTo(models.BookingStatusCancelled, nil, "hold expired")
+	if err := s.bookingRepository.Cancel(booking, change, nil); err != nil {
+		if !errors.Is(err, repositories.ErrConflict) {
+			zap.L().Error("Failed to release expired hold", zap.Error(err), zap.String("booking_id", booking.ID))
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,256 @@
+package impl
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/payments"
+	"github.com/evaizee/seat-arrangements/backend/services"
+	"go.uber.org/zap"
+)
+
+// chargeBooking collects the total of a booking, if there is anything to pay,
+// returning the payments recorded
+func (s *BookingService) chargeBooking(booking *models.Booking, total models.Money, paymentMethod string) ([]*models.Payment, error) {
+	if !total.IsPositive() {
+		return nil, nil
+	}
+
+	bookingPayments := []*models.Payment{}
+	payment, err := s.collectPayment(booking, total, paymentMethod)
+	if payment != nil {
+		bookingPayments = append(bookingPayments, payment)
+	}
+	return bookingPayments, err
+}
+
+// confirmPaidBooking confirms a booking whose payments were captured. If the
+// booking cannot be confirmed the payments are refunded and the booking cancelled.
+func (s *BookingService) confirmPaidBooking(booking *models.Booking, userID string, bookingPayments []*models.Payment) error {
+	unconfirmed := *booking
+	change := booking.TransitionTo(models.BookingStatusConfirmed, optionalUserID(userID), "")
+	if err := s.bookingRepository.UpdateStatus(booking, change); err != nil {
+		zap.L().Error("Failed to confirm booking", zap.Error(err), zap.String("booking_id", booking.ID))
+		*booking = unconfirmed
+		for _, payment := range bookingPayments {
+			s.refundPayment(payment, payment.Amount)
+		}
+		s.abandonBooking(booking, userID, "booking could not be confirmed")
+		return err
+	}
+	return nil
+}
+
+// collectPayment authorizes and captures the booking total. Every attempt is
+// recorded, including declined ones; an authorization that cannot be captured
+// is voided. The returned payment is nil only if it could not be recorded.
+func (s *BookingService) collectPayment(booking *models.Booking, amount models.Money, paymentMethod string) (*models.Payment, error) {
+	payment := models.NewPayment(booking.ID, s.paymentProvider.Name(), amount)
+
+	reference, err := s.paymentProvider.Authorize(amount, paymentMethod, booking.ID)
+	if err != nil {
+		if errors.Is(err, payments.ErrDeclined) {
+			payment.Fail(models.PaymentStatusDeclined, err.Error())
+			s.recordPayment(payment)
+			return payment, services.ErrPaymentDeclined
+		}
+
+		zap.L().Error("Failed to authorize payment", zap.Error(err), zap.String("booking_id", booking.ID))
+		payment.Fail(models.PaymentStatusFailed, err.Error())
+		s.recordPayment(payment)
+		return payment, services.ErrPaymentFailed
+	}
+
+	payment.ProviderReference = reference
+	payment.SetStatus(models.PaymentStatusAuthorized)
+	if err := s.paymentRepository.Create(payment); err != nil {
+		zap.L().Error("Failed to create payment", zap.Error(err), zap.String("booking_id", booking.ID))
+		s.voidPayment(payment)
+		return nil, err
+	}
+
+	if err := s.paymentProvider.Capture(reference, amount); err != nil {
+		zap.L().Error("Failed to capture payment", zap.Error(err), zap.String("payment_id", payment.ID))
+		s.voidPayment(payment)
+		return payment, services.ErrPaymentFailed
+	}
+
+	payment.SetStatus(models.PaymentStatusCaptured)
+	s.savePayment(payment)
+
+	return payment, nil
+}
+
+// refundBooking pays the refunds of a cancelled booking back to its captured
+// payments and marks the booking refunded once every refund has been paid.
+// Failures are logged; the booking then stays cancelled for an agent to follow up.
+func (s *BookingService) refundBooking(booking *models.Booking, breakdown *models.RefundBreakdown, refundedBy string) {
+	bookingPayments, err := s.paymentRepository.GetByBookingID(booking.ID)
+	if err != nil {
+		zap.L().Error("Failed to get payments", zap.Error(err), zap.String("booking_id", booking.ID))
+		return
+	}
+
+	refunded := false
+	for _, amount := range breakdown.Totals {
+		if !amount.IsPositive() {
+			continue
+		}
+
+		if !s.refundCaptured(booking, bookingPayments, amount) {
+			return
+		}
+		refunded = true
+	}
+
+	if !refunded || !booking.CanTransitionTo(models.BookingStatusRefunded) {
+		return
+	}
+
+	change := booking.TransitionTo(models.BookingStatusRefunded, optionalUserID(refundedBy), "")
+	if err := s.bookingRepository.UpdateStatus(booking, change); err != nil {
+		zap.L().Error("Failed to mark booking refunded", zap.Error(err), zap.String("booking_id", booking.ID))
+		return
+	}
+
+	breakdown.Status = booking.Status
+}
+
+// refundCaptured refunds amount from the captured payments of a booking in its
+// currency, taking from each payment at most what is left of it, and reports
+// whether anything was refunded. Refunds are capped at the amount captured.
+func (s *BookingService) refundCaptured(booking *models.Booking, bookingPayments []*models.Payment, amount models.Money) bool {
+	remaining := amount
+	for _, payment := range bookingPayments {
+		if payment.Status != models.PaymentStatusCaptured || payment.Amount.Currency != amount.Currency {
+			continue
+		}
+
+		part := payment.Amount.Sub(payment.RefundedAmount)
+		if !part.IsPositive() {
+			continue
+		}
+		if part.Amount > remaining.Amount {
+			part = remaining
+		}
+
+		if !s.refundPayment(payment, part) {
+			return false
+		}
+
+		remaining = remaining.Sub(part)
+		if !remaining.IsPositive() {
+			return true
+		}
+	}
+
+	if remaining.Amount == amount.Amount {
+		zap.L().Warn("No captured payment to refund",
+			zap.String("booking_id", booking.ID),
+			zap.Stringer("amount", amount))
+		return false
+	}
+
+	zap.L().Warn("Refund capped at the amount captured",
+		zap.String("booking_id", booking.ID),
+		zap.Stringer("amount", amount),
+		zap.Stringer("unrefunded", remaining))
+	return true
+}
+
+// chargeSeatChange collects the price difference of a seat change that costs
+// more than the seat it replaces. Nothing is charged for other changes.
+func (s *BookingService) chargeSeatChange(booking *models.Booking, change *models.SeatChange, paymentMethod string) (*models.Payment, error) {
+	if !change.PriceDifference.IsPositive() {
+		return nil, nil
+	}
+
+	if paymentMethod == "" {
+		return nil, services.ErrPaymentRequired
+	}
+
+	payment, err := s.collectPayment(booking, change.PriceDifference, paymentMethod)
+	if err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// refundSeatChange pays back the price difference of a saved seat change to a
+// cheaper seat. Failures are logged for an agent to follow up.
+func (s *BookingService) refundSeatChange(booking *models.Booking, change *models.SeatChange) {
+	if change.PriceDifference.Amount >= 0 {
+		return
+	}
+
+	bookingPayments, err := s.paymentRepository.GetByBookingID(booking.ID)
+	if err != nil {
+		zap.L().Error("Failed to get payments", zap.Error(err), zap.String("booking_id", booking.ID))
+		return
+	}
+
+	s.refundCaptured(booking, bookingPayments, models.ZeroMoney(change.PriceDifference.Currency).Sub(change.PriceDifference))
+}
+
+// refundCharges refunds seat change charges in full when the change is not saved
+func (s *BookingService) refundCharges(charges []*models.Payment) {
+	for _, payment := range charges {
+		if payment != nil {
+			s.refundPayment(payment, payment.Amount)
+		}
+	}
+}
+
+// refundPayment refunds amount of a captured payment, reporting whether it succeeded
+func (s *BookingService) refundPayment(payment *models.Payment, amount models.Money) bool {
+	if err := s.paymentProvider.Refund(payment.ProviderReference, amount); err != nil {
+		zap.L().Error("Failed to refund payment", zap.Error(err),
+			zap.String("payment_id", payment.ID),
+			zap.Stringer("amount", amount))
+		return false
+	}
+
+	payment.RefundedAmount = payment.RefundedAmount.Add(amount)
+	if payment.RefundedAmount.Amount >= payment.Amount.Amount {
+		payment.SetStatus(models.PaymentStatusRefunded)
+	} else {
+		payment.SetStatus(models.PaymentStatusCaptured)
+	}
+	s.savePayment(payment)
+
+	return true
+}
+
+// voidPayment releases an authorization that will not be captured
+func (s *BookingService) voidPayment(payment *models.Payment) {
+	if err := s.paymentProvider.Void(payment.ProviderReference); err != nil {
+		zap.L().Error("Failed to void payment", zap.Error(err), zap.String("payment_id", payment.ID))
+		payment.Fail(models.PaymentStatusFailed, fmt.Sprintf("void failed: %v", err))
+	} else {
+		payment.SetStatus(models.PaymentStatusVoided)
+	}
+	s.savePayment(payment)
+}
+
+// abandonBooking cancels a pending booking whose payment was not taken, releasing its seats
+func (s *BookingService) abandonBooking(booking *models.Booking, userID, reason string) {
+	change := booking.TransitionTo(models.BookingStatusCancelled, optionalUserID(userID), reason)
+	if err := s.bookingRepository.Cancel(booking, change, nil); err != nil {
+		zap.L().Error("Failed to cancel unpaid booking", zap.Error(err), zap.String("booking_id", booking.ID))
+	}
+}
+
+// recordPayment stores a payment attempt, logging failures
+func (s *BookingService) recordPayment(payment *models.Payment) {
+	if err := s.paymentRepository.Create(payment); err != nil {
+		zap.L().Error("Failed to create payment", zap.Error(err), zap.String("booking_id", payment.BookingID))
+	}
+}
+
+// savePayment updates a stored payment, logging failures
+func (s *BookingService) savePayment(payment *models.Payment) {
+	if err := s.paymentRepository.Update(payment); err != nil {
+		zap.L().Error("Failed to update payment", zap.Error(err), zap.String("payment_id", payment.ID))
+	}
+}
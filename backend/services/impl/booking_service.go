@@ -0,0 +1,898 @@
+package impl
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/payments"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+	"github.com/evaizee/seat-arrangements/backend/services"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// defaultCurrency is used for seats that have no price
+const defaultCurrency = "MYR"
+
+// BookingService is an implementation of the BookingService interface
+type BookingService struct {
+	bookingRepository      repositories.BookingRepository
+	seatRepository         repositories.SeatRepository
+	rowRepository          repositories.RowRepository
+	cabinRepository        repositories.CabinRepository
+	flightRepository       repositories.FlightRepository
+	paymentRepository      repositories.PaymentRepository
+	exchangeRateRepository repositories.ExchangeRateRepository
+	airportRepository      repositories.AirportRepository
+	taxRuleRepository      repositories.TaxRuleRepository
+	promotionRepository    repositories.PromotionRepository
+	ancillaryRepository    repositories.AncillaryRepository
+	passengerRepository    repositories.PassengerRepository
+	paymentProvider        payments.Provider
+}
+
+// NewBookingService creates a new BookingService
+func NewBookingService(
+	bookingRepository repositories.BookingRepository,
+	seatRepository repositories.SeatRepository,
+	rowRepository repositories.RowRepository,
+	cabinRepository repositories.CabinRepository,
+	flightRepository repositories.FlightRepository,
+	paymentRepository repositories.PaymentRepository,
+	exchangeRateRepository repositories.ExchangeRateRepository,
+	airportRepository repositories.AirportRepository,
+	taxRuleRepository repositories.TaxRuleRepository,
+	promotionRepository repositories.PromotionRepository,
+	ancillaryRepository repositories.AncillaryRepository,
+	passengerRepository repositories.PassengerRepository,
+	paymentProvider payments.Provider,
+) services.BookingService {
+	return &BookingService{
+		bookingRepository:      bookingRepository,
+		seatRepository:         seatRepository,
+		rowRepository:          rowRepository,
+		cabinRepository:        cabinRepository,
+		flightRepository:       flightRepository,
+		paymentRepository:      paymentRepository,
+		exchangeRateRepository: exchangeRateRepository,
+		airportRepository:      airportRepository,
+		taxRuleRepository:      taxRuleRepository,
+		promotionRepository:    promotionRepository,
+		ancillaryRepository:    ancillaryRepository,
+		passengerRepository:    passengerRepository,
+		paymentProvider:        paymentProvider,
+	}
+}
+
+// CreateBooking books seats on a flight for a user at the seats' current prices,
+// converted to currency, or to the currency of the first seat if it is empty,
+// and discounted by the promotion code if one is given. The seats are held by
+// a pending booking while the total is charged to the payment method; the
+// booking is confirmed once the payment is captured and cancelled, releasing
+// the seats, if it is not.
+func (s *BookingService) CreateBooking(userID, flightID string, seatIDs []string, currency, promoCode, paymentMethod string) (*models.BookingWithDetails, error) {
+	flight, err := s.getFlight(flightID)
+	if err != nil {
+		return nil, err
+	}
+
+	booking := models.NewBooking(userID, flightID)
+	bookingSeats, err := s.priceSeats(booking, flight, seatIDs, currency, promoCode)
+	if err != nil {
+		return nil, err
+	}
+
+	total := seatsTotal(bookingSeats)
+	if total.IsPositive() && paymentMethod == "" {
+		return nil, services.ErrPaymentRequired
+	}
+
+	statusChanges := []*models.BookingStatusChange{booking.InitialStatusChange(optionalUserID(userID))}
+	if err := s.createWithSeats(booking, bookingSeats, statusChanges); err != nil {
+		return nil, err
+	}
+
+	bookingPayments, err := s.chargeBooking(booking, total, paymentMethod)
+	if err != nil {
+		s.abandonBooking(booking, userID, "payment was not captured")
+		return nil, err
+	}
+
+	if err := s.confirmPaidBooking(booking, userID, bookingPayments); err != nil {
+		return nil, err
+	}
+
+	return s.withDetails(booking, flight, bookingSeats, nil, bookingPayments), nil
+}
+
+// GetByID retrieves a booking with its flight and seats
+func (s *BookingService) GetByID(id string) (*models.BookingWithDetails, error) {
+	booking, err := s.getBooking(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.loadDetails(booking)
+}
+
+// GetByUserID retrieves the bookings of a user with their flights and seats
+func (s *BookingService) GetByUserID(userID string) ([]*models.BookingWithDetails, error) {
+	bookings, err := s.bookingRepository.GetByUserID(userID)
+	if err != nil {
+		zap.L().Error("Failed to get bookings by user ID", zap.Error(err), zap.String("user_id", userID))
+		return nil, err
+	}
+
+	result := make([]*models.BookingWithDetails, 0, len(bookings))
+	for _, booking := range bookings {
+		details, err := s.loadDetails(booking)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, details)
+	}
+
+	return result, nil
+}
+
+// CancelBooking cancels a booking before departure, releases its seats and
+// refunds each seat according to its refund indicator and the refund policy.
+// Held bookings have not been paid for and are released without refunds.
+// Ancillaries are not refundable.
+func (s *BookingService) CancelBooking(id, cancelledBy string) (*models.RefundBreakdown, error) {
+	booking, err := s.getBooking(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !booking.CanTransitionTo(models.BookingStatusCancelled) {
+		return nil, services.ErrInvalidTransition
+	}
+
+	flight, err := s.flightRepository.GetByID(booking.FlightID)
+	if err != nil {
+		zap.L().Error("Failed to get flight", zap.Error(err), zap.String("flight_id", booking.FlightID))
+		return nil, err
+	}
+
+	if flight == nil {
+		return nil, services.ErrFlightNotFound
+	}
+
+	hoursBeforeDeparture := time.Until(flight.Departure).Hours()
+	if hoursBeforeDeparture < 0 {
+		return nil, services.ErrFlightDeparted
+	}
+
+	paid := booking.Status != models.BookingStatusHeld
+	refunds := []*models.SeatRefund{}
+	if paid {
+		refunds, err = s.seatRefunds(booking, flight, hoursBeforeDeparture)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	change := booking.TransitionTo(models.BookingStatusCancelled, optionalUserID(cancelledBy), "")
+
+	if err := s.bookingRepository.Cancel(booking, change, refunds); err != nil {
+		if errors.Is(err, repositories.ErrConflict) {
+			return nil, services.ErrConcurrentUpdate
+		}
+		zap.L().Error("Failed to cancel booking", zap.Error(err), zap.String("booking_id", id))
+		return nil, err
+	}
+
+	breakdown := models.NewRefundBreakdown(booking, hoursBeforeDeparture, refunds)
+	if paid {
+		s.refundBooking(booking, breakdown, cancelledBy)
+	}
+
+	return breakdown, nil
+}
+
+// seatRefunds computes the refund of each seat of a booking cancelled hoursBeforeDeparture
+func (s *BookingService) seatRefunds(booking *models.Booking, flight *models.Flight, hoursBeforeDeparture float64) ([]*models.SeatRefund, error) {
+	bookingSeats, err := s.bookingRepository.GetSeatsByBookingID(booking.ID)
+	if err != nil {
+		zap.L().Error("Failed to get booking seats", zap.Error(err), zap.String("booking_id", booking.ID))
+		return nil, err
+	}
+
+	policy := loadRefundPolicy()
+	refunds := make([]*models.SeatRefund, 0, len(bookingSeats))
+	for _, bookingSeat := range bookingSeats {
+		seat, err := s.seatRepository.GetByID(bookingSeat.SeatID)
+		if err != nil {
+			zap.L().Error("Failed to get seat", zap.Error(err), zap.String("seat_id", bookingSeat.SeatID))
+			return nil, err
+		}
+
+		refunds = append(refunds, policy.seatRefund(bookingSeat, seat, flight, hoursBeforeDeparture))
+	}
+
+	return refunds, nil
+}
+
+// UpdateStatus moves a booking along its lifecycle, e.g. to checked in or
+// boarded. Cancellation goes through CancelBooking so seats are released and refunded,
+// and a booking is only marked refunded once its refunds have been paid.
+// A booking is checked in only once each seat is assigned to a passenger whose
+// advance passenger information the route requires is complete.
+func (s *BookingService) UpdateStatus(bookingID string, status models.BookingStatus, changedBy, reason string) (*models.Booking, error) {
+	if !status.IsValid() {
+		return nil, services.ErrInvalidStatus
+	}
+
+	if status == models.BookingStatusCancelled {
+		return nil, fmt.Errorf("%w: cancel the booking to release and refund its seats", services.ErrInvalidTransition)
+	}
+
+	if status == models.BookingStatusRefunded {
+		return nil, fmt.Errorf("%w: bookings are marked refunded when their refunds are paid", services.ErrInvalidTransition)
+	}
+
+	booking, err := s.getBooking(bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !booking.CanTransitionTo(status) {
+		return nil, fmt.Errorf("%w: %s to %s", services.ErrInvalidTransition, booking.Status, status)
+	}
+
+	if status == models.BookingStatusCheckedIn {
+		seats, err := s.bookingRepository.GetSeatsByBookingID(booking.ID)
+		if err != nil {
+			zap.L().Error("Failed to get booking seats", zap.Error(err), zap.String("booking_id", bookingID))
+			return nil, err
+		}
+
+		if err := checkAPISForSeats(s.passengerRepository, s.flightRepository, s.airportRepository, seats); err != nil {
+			return nil, err
+		}
+	}
+
+	change := booking.TransitionTo(status, optionalUserID(changedBy), reason)
+
+	if err := s.bookingRepository.UpdateStatus(booking, change); err != nil {
+		if errors.Is(err, repositories.ErrConflict) {
+			return nil, services.ErrConcurrentUpdate
+		}
+		zap.L().Error("Failed to update booking status", zap.Error(err), zap.String("booking_id", bookingID))
+		return nil, err
+	}
+
+	return booking, nil
+}
+
+// GetStatusHistory retrieves the status changes of a booking, oldest first
+func (s *BookingService) GetStatusHistory(bookingID string) ([]*models.BookingStatusChange, error) {
+	if _, err := s.getBooking(bookingID); err != nil {
+		return nil, err
+	}
+
+	history, err := s.bookingRepository.GetStatusHistory(bookingID)
+	if err != nil {
+		zap.L().Error("Failed to get booking status history", zap.Error(err), zap.String("booking_id", bookingID))
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// GetRefund retrieves the refund breakdown of a cancelled booking
+func (s *BookingService) GetRefund(bookingID string) (*models.RefundBreakdown, error) {
+	booking, err := s.getBooking(bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	refunds, err := s.bookingRepository.GetRefunds(booking.ID)
+	if err != nil {
+		zap.L().Error("Failed to get refunds", zap.Error(err), zap.String("booking_id", bookingID))
+		return nil, err
+	}
+
+	if len(refunds) == 0 {
+		return nil, services.ErrRefundNotFound
+	}
+
+	flight, err := s.flightRepository.GetByID(booking.FlightID)
+	if err != nil {
+		zap.L().Error("Failed to get flight", zap.Error(err), zap.String("flight_id", booking.FlightID))
+		return nil, err
+	}
+
+	hoursBeforeDeparture := 0.0
+	if flight != nil {
+		hoursBeforeDeparture = flight.Departure.Sub(refunds[0].CreatedAt).Hours()
+	}
+
+	return models.NewRefundBreakdown(booking, hoursBeforeDeparture, refunds), nil
+}
+
+// ChangeSeat moves a booked seat to another available seat on the same flight.
+// The booking seat takes the new seat's current price and taxes in the currency
+// it was paid in, discounted by the booking's promotion if it covers the seat,
+// and the change, with the difference against the price paid, is
+// recorded in the booking's history. A dearer seat's difference is charged to
+// the payment method before the change is saved; a cheaper seat's is refunded.
+func (s *BookingService) ChangeSeat(bookingID, bookingSeatID, newSeatID, changedBy, paymentMethod string) (*models.SeatChange, error) {
+	booking, err := s.getBooking(bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	if booking.Status != models.BookingStatusConfirmed {
+		return nil, services.ErrBookingNotActive
+	}
+
+	if _, err := uuid.Parse(bookingSeatID); err != nil {
+		return nil, services.ErrBookingSeatMissing
+	}
+
+	bookingSeat, err := s.bookingRepository.GetSeatByID(bookingSeatID)
+	if err != nil {
+		zap.L().Error("Failed to get booking seat", zap.Error(err), zap.String("booking_seat_id", bookingSeatID))
+		return nil, err
+	}
+
+	if bookingSeat == nil || bookingSeat.BookingID != booking.ID {
+		return nil, services.ErrBookingSeatMissing
+	}
+
+	if bookingSeat.SeatID == newSeatID {
+		return nil, fmt.Errorf("%w: the booking already holds this seat", services.ErrInvalidSeatChange)
+	}
+
+	seat, err := s.getBookableSeat(newSeatID, booking.FlightID)
+	if err != nil {
+		return nil, err
+	}
+
+	flight, err := s.getFlight(booking.FlightID)
+	if err != nil {
+		return nil, err
+	}
+
+	quoter, err := s.newSeatQuoter(flight, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	promotion, err := s.bookingPromotion(booking)
+	if err != nil {
+		return nil, err
+	}
+
+	price, err := quoter.quote(seat, bookingSeat.Price.Currency, promotion)
+	if err != nil {
+		return nil, err
+	}
+
+	change := models.NewSeatChange(bookingSeat, models.SeatChangeTypeChange, seat.ID, price.Total, optionalUserID(changedBy))
+
+	charge, err := s.chargeSeatChange(booking, change, paymentMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	bookingSeat.SeatID = seat.ID
+	bookingSeat.SetPrice(price)
+	bookingSeat.UpdatedAt = time.Now()
+
+	if err := s.bookingRepository.ChangeSeat(booking, bookingSeat, change); err != nil {
+		s.refundCharges([]*models.Payment{charge})
+		return nil, s.translateChangeError(err, bookingID)
+	}
+
+	s.refundSeatChange(booking, change)
+
+	return change, nil
+}
+
+// SwapSeats exchanges the seats of two booking seats on the same flight, which
+// may belong to different bookings. Each booking seat takes the current price and
+// taxes of its new seat in the currency it was paid in, discounted by its own
+// booking's promotion, and both changes are recorded. A booking seat moving to
+// a dearer seat is charged the difference to its payment method before the swap
+// is saved; one moving to a cheaper seat is refunded the difference.
+func (s *BookingService) SwapSeats(firstBookingSeatID, secondBookingSeatID, firstPaymentMethod, secondPaymentMethod, changedBy string) ([]*models.SeatChange, error) {
+	if firstBookingSeatID == secondBookingSeatID {
+		return nil, fmt.Errorf("%w: cannot swap a seat with itself", services.ErrInvalidSeatChange)
+	}
+
+	first, firstBooking, err := s.getActiveBookingSeat(firstBookingSeatID)
+	if err != nil {
+		return nil, err
+	}
+
+	second, secondBooking, err := s.getActiveBookingSeat(secondBookingSeatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if firstBooking.FlightID != secondBooking.FlightID {
+		return nil, fmt.Errorf("%w: both seats must be on the same flight", services.ErrInvalidSeatChange)
+	}
+
+	flight, err := s.getFlight(firstBooking.FlightID)
+	if err != nil {
+		return nil, err
+	}
+
+	firstSeat, err := s.getSeat(first.SeatID)
+	if err != nil {
+		return nil, err
+	}
+
+	secondSeat, err := s.getSeat(second.SeatID)
+	if err != nil {
+		return nil, err
+	}
+
+	quoter, err := s.newSeatQuoter(flight, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	firstPromotion, err := s.bookingPromotion(firstBooking)
+	if err != nil {
+		return nil, err
+	}
+
+	secondPromotion, err := s.bookingPromotion(secondBooking)
+	if err != nil {
+		return nil, err
+	}
+
+	firstPrice, err := quoter.quote(secondSeat, first.Price.Currency, firstPromotion)
+	if err != nil {
+		return nil, err
+	}
+
+	secondPrice, err := quoter.quote(firstSeat, second.Price.Currency, secondPromotion)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []*models.SeatChange{
+		models.NewSeatChange(first, models.SeatChangeTypeSwap, second.SeatID, firstPrice.Total, optionalUserID(changedBy)),
+		models.NewSeatChange(second, models.SeatChangeTypeSwap, first.SeatID, secondPrice.Total, optionalUserID(changedBy)),
+	}
+
+	firstCharge, err := s.chargeSeatChange(firstBooking, changes[0], firstPaymentMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	secondCharge, err := s.chargeSeatChange(secondBooking, changes[1], secondPaymentMethod)
+	if err != nil {
+		s.refundCharges([]*models.Payment{firstCharge})
+		return nil, err
+	}
+
+	now := time.Now()
+	first.SeatID, second.SeatID = second.SeatID, first.SeatID
+	first.SetPrice(firstPrice)
+	second.SetPrice(secondPrice)
+	first.UpdatedAt, second.UpdatedAt = now, now
+
+	if err := s.bookingRepository.SwapSeats([]*models.Booking{firstBooking, secondBooking}, first, second, changes); err != nil {
+		s.refundCharges([]*models.Payment{firstCharge, secondCharge})
+		return nil, s.translateChangeError(err, firstBooking.ID)
+	}
+
+	s.refundSeatChange(firstBooking, changes[0])
+	s.refundSeatChange(secondBooking, changes[1])
+
+	return changes, nil
+}
+
+// GetSeatChanges retrieves the seat change history of a booking
+func (s *BookingService) GetSeatChanges(bookingID string) ([]*models.SeatChange, error) {
+	if _, err := s.getBooking(bookingID); err != nil {
+		return nil, err
+	}
+
+	changes, err := s.bookingRepository.GetSeatChanges(bookingID)
+	if err != nil {
+		zap.L().Error("Failed to get seat changes", zap.Error(err), zap.String("booking_id", bookingID))
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// getBooking retrieves a booking, returning ErrBookingNotFound if it does not exist
+func (s *BookingService) getBooking(id string) (*models.Booking, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, services.ErrBookingNotFound
+	}
+
+	booking, err := s.bookingRepository.GetByID(id)
+	if err != nil {
+		zap.L().Error("Failed to get booking", zap.Error(err), zap.String("booking_id", id))
+		return nil, err
+	}
+
+	if booking == nil {
+		return nil, services.ErrBookingNotFound
+	}
+
+	return booking, nil
+}
+
+// getActiveBookingSeat retrieves a booking seat and its booking, which must be confirmed
+func (s *BookingService) getActiveBookingSeat(id string) (*models.BookingSeat, *models.Booking, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, nil, services.ErrBookingSeatMissing
+	}
+
+	bookingSeat, err := s.bookingRepository.GetSeatByID(id)
+	if err != nil {
+		zap.L().Error("Failed to get booking seat", zap.Error(err), zap.String("booking_seat_id", id))
+		return nil, nil, err
+	}
+
+	if bookingSeat == nil {
+		return nil, nil, services.ErrBookingSeatMissing
+	}
+
+	booking, err := s.getBooking(bookingSeat.BookingID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if booking.Status != models.BookingStatusConfirmed {
+		return nil, nil, services.ErrBookingNotActive
+	}
+
+	return bookingSeat, booking, nil
+}
+
+// getSeat retrieves a seat, returning ErrSeatNotFound if it does not exist
+func (s *BookingService) getSeat(seatID string) (*models.Seat, error) {
+	if _, err := uuid.Parse(seatID); err != nil {
+		return nil, services.ErrSeatNotFound
+	}
+
+	seat, err := s.seatRepository.GetByID(seatID)
+	if err != nil {
+		zap.L().Error("Failed to get seat", zap.Error(err), zap.String("seat_id", seatID))
+		return nil, err
+	}
+
+	if seat == nil {
+		return nil, services.ErrSeatNotFound
+	}
+
+	return seat, nil
+}
+
+// getBookableSeat retrieves a seat that can be booked on a flight
+func (s *BookingService) getBookableSeat(seatID, flightID string) (*models.Seat, error) {
+	seat, err := s.getSeat(seatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if seat.SegmentID != flightID {
+		return nil, fmt.Errorf("%w: seat %s is not on this flight", services.ErrInvalidSeatChange, seat.Code)
+	}
+
+	if seat.StorefrontSlotCode != models.SlotCodeSeat || !seat.Available {
+		return nil, services.ErrSeatUnavailable
+	}
+
+	return seat, nil
+}
+
+// getFlight retrieves a flight, returning ErrFlightNotFound if it does not exist
+func (s *BookingService) getFlight(id string) (*models.Flight, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, services.ErrFlightNotFound
+	}
+
+	flight, err := s.flightRepository.GetByID(id)
+	if err != nil {
+		zap.L().Error("Failed to get flight", zap.Error(err), zap.String("flight_id", id))
+		return nil, err
+	}
+
+	if flight == nil {
+		return nil, services.ErrFlightNotFound
+	}
+
+	return flight, nil
+}
+
+// priceSeats quotes the seats of a new booking with their taxes, converted to
+// currency or to the currency of the first seat if it is empty. A promotion
+// code is redeemed by the booking and must discount at least one of its seats.
+func (s *BookingService) priceSeats(booking *models.Booking, flight *models.Flight, seatIDs []string, currency, promoCode string) ([]*models.BookingSeat, error) {
+	if len(seatIDs) == 0 {
+		return nil, fmt.Errorf("%w: at least one seat is required", services.ErrInvalidBooking)
+	}
+
+	if currency != "" && !models.IsValidCurrency(currency) {
+		return nil, services.ErrInvalidCurrency
+	}
+
+	quoter, err := s.newSeatQuoter(flight, booking.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	promotion, err := findPromotion(s.promotionRepository, promoCode, flight, booking.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	discounted := false
+	bookingSeats := make([]*models.BookingSeat, 0, len(seatIDs))
+	seen := make(map[string]bool, len(seatIDs))
+
+	for _, seatID := range seatIDs {
+		if seen[seatID] {
+			return nil, fmt.Errorf("%w: seat %s is listed more than once", services.ErrInvalidBooking, seatID)
+		}
+		seen[seatID] = true
+
+		seat, err := s.getBookableSeat(seatID, flight.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		price, err := quoter.quote(seat, currency, promotion)
+		if err != nil {
+			return nil, err
+		}
+		currency = price.Total.Currency
+		discounted = discounted || price.Discount.IsPositive()
+
+		bookingSeats = append(bookingSeats, models.NewBookingSeat(booking.ID, seat.ID, price))
+	}
+
+	if promotion != nil {
+		if !discounted {
+			return nil, services.ErrPromotionNotUsable
+		}
+		booking.PromotionID = promotion.ID
+	}
+
+	return bookingSeats, nil
+}
+
+// bookingPromotion retrieves the promotion redeemed by a booking, or nil if it has none
+func (s *BookingService) bookingPromotion(booking *models.Booking) (*models.Promotion, error) {
+	if booking.PromotionID == "" || s.promotionRepository == nil {
+		return nil, nil
+	}
+
+	promotion, err := s.promotionRepository.GetByID(booking.PromotionID)
+	if err != nil {
+		zap.L().Error("Failed to get promotion", zap.Error(err), zap.String("promotion_id", booking.PromotionID))
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+// createWithSeats stores a new booking and claims its seats
+func (s *BookingService) createWithSeats(booking *models.Booking, bookingSeats []*models.BookingSeat, statusChanges []*models.BookingStatusChange) error {
+	if err := s.bookingRepository.CreateWithSeats(booking, bookingSeats, statusChanges); err != nil {
+		if errors.Is(err, repositories.ErrSeatUnavailable) {
+			return services.ErrSeatUnavailable
+		}
+		if errors.Is(err, repositories.ErrPromotionExhausted) {
+			return services.ErrPromotionExhausted
+		}
+		zap.L().Error("Failed to create booking", zap.Error(err), zap.String("flight_id", booking.FlightID))
+		return err
+	}
+	return nil
+}
+
+// seatQuoter prices seats of a flight with their taxes at a point in time
+type seatQuoter struct {
+	pricer    *seatPricer
+	layouts   *seatLayouts
+	converter *currencyConverter
+	taxes     *taxCalculator
+}
+
+// newSeatQuoter creates a seatQuoter for a flight using the rates and tax rules in effect at a time
+func (s *BookingService) newSeatQuoter(flight *models.Flight, at time.Time) (*seatQuoter, error) {
+	converter := newCurrencyConverter(s.exchangeRateRepository, at)
+	taxes, err := newTaxCalculator(s.airportRepository, s.taxRuleRepository, flight, converter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &seatQuoter{
+		pricer:    newSeatPricer(s.seatRepository, s.rowRepository, flight),
+		layouts:   newSeatLayouts(s.rowRepository, s.cabinRepository),
+		converter: converter,
+		taxes:     taxes,
+	}, nil
+}
+
+// quote returns the current price of a seat in currency, or in the seat's own
+// currency if it is empty, with the promotion's discount, if any, and the
+// taxes charged on it
+func (q *seatQuoter) quote(seat *models.Seat, currency string, promotion *models.Promotion) (*models.PriceBreakdown, error) {
+	characteristics, err := q.layouts.characteristics(seat)
+	if err != nil {
+		return nil, err
+	}
+
+	price, err := q.pricer.price(seat, characteristics)
+	if err != nil {
+		return nil, err
+	}
+
+	if currency != "" {
+		price, err = q.converter.convert(price, currency)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	discount, err := promotionDiscount(promotion, characteristics, price, q.converter)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.taxes.breakdown(price, discount)
+}
+
+// seatLayouts finds the cabin layout of seats through their rows, caching the
+// layout of each row
+type seatLayouts struct {
+	rowRepository   repositories.RowRepository
+	cabinRepository repositories.CabinRepository
+	byRow           map[string]*models.CabinLayout
+}
+
+// newSeatLayouts creates an empty seatLayouts
+func newSeatLayouts(rowRepository repositories.RowRepository, cabinRepository repositories.CabinRepository) *seatLayouts {
+	return &seatLayouts{
+		rowRepository:   rowRepository,
+		cabinRepository: cabinRepository,
+		byRow:           make(map[string]*models.CabinLayout),
+	}
+}
+
+// characteristics returns the characteristics of a seat as its seat map shows
+// them, with its position taken from its cabin's layout
+func (l *seatLayouts) characteristics(seat *models.Seat) ([]string, error) {
+	layout, ok := l.byRow[seat.RowID]
+	if !ok {
+		row, err := l.rowRepository.GetByID(seat.RowID)
+		if err != nil {
+			zap.L().Error("Failed to get row", zap.Error(err), zap.String("row_id", seat.RowID))
+			return nil, err
+		}
+		if row == nil {
+			return nil, services.ErrRowNotFound
+		}
+
+		cabin, err := l.cabinRepository.GetByID(row.CabinID)
+		if err != nil {
+			zap.L().Error("Failed to get cabin", zap.Error(err), zap.String("cabin_id", row.CabinID))
+			return nil, err
+		}
+		if cabin == nil {
+			return nil, services.ErrCabinNotFound
+		}
+
+		if layout, err = cabin.Layout(); err != nil {
+			return nil, fmt.Errorf("%w: cabin %s: %v", services.ErrInvalidLayout, cabin.ID, err)
+		}
+		l.byRow[seat.RowID] = layout
+	}
+
+	return layout.SeatCharacteristics(seat), nil
+}
+
+// loadDetails loads the flight and seats of a booking
+func (s *BookingService) loadDetails(booking *models.Booking) (*models.BookingWithDetails, error) {
+	flight, err := s.flightRepository.GetByID(booking.FlightID)
+	if err != nil {
+		zap.L().Error("Failed to get flight", zap.Error(err), zap.String("flight_id", booking.FlightID))
+		return nil, err
+	}
+
+	seats, err := s.bookingRepository.GetSeatsByBookingID(booking.ID)
+	if err != nil {
+		zap.L().Error("Failed to get booking seats", zap.Error(err), zap.String("booking_id", booking.ID))
+		return nil, err
+	}
+
+	ancillaries, err := s.bookingRepository.GetAncillaries(booking.ID)
+	if err != nil {
+		zap.L().Error("Failed to get booking ancillaries", zap.Error(err), zap.String("booking_id", booking.ID))
+		return nil, err
+	}
+
+	bookingPayments, err := s.paymentRepository.GetByBookingID(booking.ID)
+	if err != nil {
+		zap.L().Error("Failed to get payments", zap.Error(err), zap.String("booking_id", booking.ID))
+		return nil, err
+	}
+
+	return s.withDetails(booking, flight, seats, ancillaries, bookingPayments), nil
+}
+
+// withDetails assembles a booking with its flight, seats, ancillaries, payments and total price
+func (s *BookingService) withDetails(
+	booking *models.Booking,
+	flight *models.Flight,
+	seats []*models.BookingSeat,
+	ancillaries []*models.BookingAncillary,
+	bookingPayments []*models.Payment,
+) *models.BookingWithDetails {
+	if ancillaries == nil {
+		ancillaries = []*models.BookingAncillary{}
+	}
+	if bookingPayments == nil {
+		bookingPayments = []*models.Payment{}
+	}
+
+	return &models.BookingWithDetails{
+		Booking:     booking,
+		Flight:      flight,
+		Seats:       seats,
+		Ancillaries: ancillaries,
+		Payments:    bookingPayments,
+		Total:       bookingTotal(seats, ancillaries),
+	}
+}
+
+// seatsTotal sums the prices of booked seats, which share the booking's currency
+func seatsTotal(seats []*models.BookingSeat) models.Money {
+	if len(seats) == 0 {
+		return models.ZeroMoney(defaultCurrency)
+	}
+
+	total := models.ZeroMoney(seats[0].Price.Currency)
+	for _, seat := range seats {
+		total = total.Add(seat.Price)
+	}
+	return total
+}
+
+// bookingTotal sums the prices of booked seats and the totals of their
+// ancillaries, which share the booking's currency
+func bookingTotal(seats []*models.BookingSeat, ancillaries []*models.BookingAncillary) models.Money {
+	total := seatsTotal(seats)
+	for _, ancillary := range ancillaries {
+		total = total.Add(ancillary.Total)
+	}
+	return total
+}
+
+// translateChangeError maps repository errors from seat changes to service errors
+func (s *BookingService) translateChangeError(err error, bookingID string) error {
+	switch {
+	case errors.Is(err, repositories.ErrSeatUnavailable):
+		return services.ErrSeatUnavailable
+	case errors.Is(err, repositories.ErrConflict):
+		return services.ErrConcurrentUpdate
+	}
+
+	zap.L().Error("Failed to change booking seat", zap.Error(err), zap.String("booking_id", bookingID))
+	return err
+}
+
+// optionalUserID returns nil for an empty user ID
+func optionalUserID(userID string) *string {
+	if userID == "" {
+		return nil
+	}
+	return &userID
+}
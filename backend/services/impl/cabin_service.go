@@ -0,0 +1,589 @@
+package impl
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+	"github.com/evaizee/seat-arrangements/backend/services"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// CabinService is an implementation of the CabinService interface
+type CabinService struct {
+	cabinRepository    repositories.CabinRepository
+	aircraftRepository repositories.AircraftRepository
+	flightRepository   repositories.FlightRepository
+	rowRepository      repositories.RowRepository
+	seatRepository     repositories.SeatRepository
+	facilityRepository repositories.FacilityRepository
+}
+
+// NewCabinService creates a new CabinService
+func NewCabinService(
+	cabinRepository repositories.CabinRepository,
+	aircraftRepository repositories.AircraftRepository,
+	flightRepository repositories.FlightRepository,
+	rowRepository repositories.RowRepository,
+	seatRepository repositories.SeatRepository,
+	facilityRepository repositories.FacilityRepository,
+) services.CabinService {
+	return &CabinService{
+		cabinRepository:    cabinRepository,
+		aircraftRepository: aircraftRepository,
+		flightRepository:   flightRepository,
+		rowRepository:      rowRepository,
+		seatRepository:     seatRepository,
+		facilityRepository: facilityRepository,
+	}
+}
+
+// GetByID retrieves a cabin by ID
+func (s *CabinService) GetByID(id string) (*models.Cabin, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, services.ErrCabinNotFound
+	}
+
+	cabin, err := s.cabinRepository.GetByID(id)
+	if err != nil {
+		zap.L().Error("Failed to get cabin", zap.Error(err), zap.String("cabin_id", id))
+		return nil, err
+	}
+
+	if cabin == nil {
+		return nil, services.ErrCabinNotFound
+	}
+
+	return cabin, nil
+}
+
+// GetByAircraftID retrieves the cabins of an aircraft
+func (s *CabinService) GetByAircraftID(aircraftID string) ([]*models.Cabin, error) {
+	if _, err := uuid.Parse(aircraftID); err != nil {
+		return nil, services.ErrAircraftNotFound
+	}
+
+	aircraft, err := s.aircraftRepository.GetByID(aircraftID)
+	if err != nil {
+		zap.L().Error("Failed to get aircraft", zap.Error(err), zap.String("aircraft_id", aircraftID))
+		return nil, err
+	}
+
+	if aircraft == nil {
+		return nil, services.ErrAircraftNotFound
+	}
+
+	cabins, err := s.cabinRepository.GetByAircraftID(aircraftID)
+	if err != nil {
+		zap.L().Error("Failed to get cabins by aircraft ID", zap.Error(err), zap.String("aircraft_id", aircraftID))
+		return nil, err
+	}
+
+	models.SortCabins(cabins)
+	return cabins, nil
+}
+
+// GetWithSeats retrieves a cabin with the seats of all its rows
+func (s *CabinService) GetWithSeats(id string) (*models.CabinWithSeats, error) {
+	cabin, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.GetRows(id)
+	if err != nil {
+		return nil, err
+	}
+
+	seats := []*models.Seat{}
+	for _, row := range rows {
+		rowSeats, err := s.seatRepository.GetByRowID(row.ID)
+		if err != nil {
+			zap.L().Error("Failed to get seats by row ID", zap.Error(err), zap.String("row_id", row.ID))
+			return nil, err
+		}
+		seats = append(seats, rowSeats...)
+	}
+
+	return &models.CabinWithSeats{
+		Cabin: cabin,
+		Seats: seats,
+	}, nil
+}
+
+// CreateCabin validates and creates a cabin
+func (s *CabinService) CreateCabin(cabin *models.Cabin) (*models.Cabin, error) {
+	cabin = models.NewCabin(
+		cabin.AircraftID,
+		strings.TrimSpace(cabin.SegmentID),
+		cabin.Deck,
+		cabin.FirstRow,
+		cabin.LastRow,
+		cabin.SeatColumns,
+	)
+
+	if err := s.validateCabin(cabin); err != nil {
+		return nil, err
+	}
+
+	if err := s.cabinRepository.Create(cabin); err != nil {
+		zap.L().Error("Failed to create cabin", zap.Error(err), zap.String("aircraft_id", cabin.AircraftID))
+		return nil, err
+	}
+
+	return cabin, nil
+}
+
+// UpdateCabin validates and updates a cabin, keeping its existing rows within the new layout
+func (s *CabinService) UpdateCabin(cabin *models.Cabin) (*models.Cabin, error) {
+	existing, err := s.GetByID(cabin.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing.AircraftID = cabin.AircraftID
+	existing.SegmentID = strings.TrimSpace(cabin.SegmentID)
+	existing.Deck = cabin.Deck
+	existing.FirstRow = cabin.FirstRow
+	existing.LastRow = cabin.LastRow
+	existing.SeatColumns = cabin.SeatColumns
+
+	if err := s.validateCabin(existing); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.GetRows(existing.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		if err := validateRowLayout(existing, row); err != nil {
+			return nil, err
+		}
+	}
+
+	existing.UpdatedAt = time.Now()
+	if err := s.cabinRepository.Update(existing); err != nil {
+		zap.L().Error("Failed to update cabin", zap.Error(err), zap.String("cabin_id", existing.ID))
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+// DeleteCabin deletes a cabin that has no rows left
+func (s *CabinService) DeleteCabin(id string) error {
+	if _, err := s.GetByID(id); err != nil {
+		return err
+	}
+
+	if err := s.cabinRepository.Delete(id); err != nil {
+		if errors.Is(err, repositories.ErrReferenced) {
+			return services.ErrInUse
+		}
+		zap.L().Error("Failed to delete cabin", zap.Error(err), zap.String("cabin_id", id))
+		return err
+	}
+
+	return nil
+}
+
+// GetRows retrieves the rows of a cabin ordered by row number
+func (s *CabinService) GetRows(cabinID string) ([]*models.SeatRow, error) {
+	if _, err := uuid.Parse(cabinID); err != nil {
+		return nil, services.ErrCabinNotFound
+	}
+
+	rows, err := s.rowRepository.GetByCabinID(cabinID)
+	if err != nil {
+		zap.L().Error("Failed to get rows by cabin ID", zap.Error(err), zap.String("cabin_id", cabinID))
+		return nil, err
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].RowNumber < rows[j].RowNumber
+	})
+
+	return rows, nil
+}
+
+// CreateRow adds a row to a cabin. An empty seatCodes defaults to all of the cabin's columns
+func (s *CabinService) CreateRow(cabinID string, rowNumber int, seatCodes string) (*models.SeatRow, error) {
+	cabin, err := s.GetByID(cabinID)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(seatCodes) == "" {
+		seatCodes = strings.Join(cabin.Columns(), ",")
+	}
+
+	row := models.NewSeatRow(cabinID, rowNumber, normalizeColumns(seatCodes))
+	if err := s.validateRow(cabin, row); err != nil {
+		return nil, err
+	}
+
+	if err := s.rowRepository.Create(row); err != nil {
+		zap.L().Error("Failed to create row", zap.Error(err), zap.String("cabin_id", cabinID))
+		return nil, err
+	}
+
+	return row, nil
+}
+
+// UpdateRow changes the number or columns of a row, keeping its seats consistent
+func (s *CabinService) UpdateRow(id string, rowNumber int, seatCodes string) (*models.SeatRow, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, services.ErrRowNotFound
+	}
+
+	row, err := s.rowRepository.GetByID(id)
+	if err != nil {
+		zap.L().Error("Failed to get row", zap.Error(err), zap.String("row_id", id))
+		return nil, err
+	}
+
+	if row == nil {
+		return nil, services.ErrRowNotFound
+	}
+
+	cabin, err := s.GetByID(row.CabinID)
+	if err != nil {
+		return nil, err
+	}
+
+	row.RowNumber = rowNumber
+	if strings.TrimSpace(seatCodes) != "" {
+		row.SeatCodes = normalizeColumns(seatCodes)
+	}
+
+	if err := s.validateRow(cabin, row); err != nil {
+		return nil, err
+	}
+
+	// Seats already in the row must still match its number and columns
+	seats, err := s.seatRepository.GetByRowID(row.ID)
+	if err != nil {
+		zap.L().Error("Failed to get seats by row ID", zap.Error(err), zap.String("row_id", row.ID))
+		return nil, err
+	}
+
+	for _, seat := range seats {
+		if seat.Code == "" {
+			continue
+		}
+		if err := validateSeatCode(cabin, row, seat.Code); err != nil {
+			return nil, fmt.Errorf("%w: existing seat %s no longer fits row %d", services.ErrInvalidLayout, seat.Code, row.RowNumber)
+		}
+	}
+
+	row.UpdatedAt = time.Now()
+	if err := s.rowRepository.Update(row); err != nil {
+		zap.L().Error("Failed to update row", zap.Error(err), zap.String("row_id", id))
+		return nil, err
+	}
+
+	return row, nil
+}
+
+// DeleteRow deletes a row that has no seats left
+func (s *CabinService) DeleteRow(id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return services.ErrRowNotFound
+	}
+
+	row, err := s.rowRepository.GetByID(id)
+	if err != nil {
+		zap.L().Error("Failed to get row", zap.Error(err), zap.String("row_id", id))
+		return err
+	}
+
+	if row == nil {
+		return services.ErrRowNotFound
+	}
+
+	if err := s.rowRepository.Delete(id); err != nil {
+		if errors.Is(err, repositories.ErrReferenced) {
+			return services.ErrInUse
+		}
+		zap.L().Error("Failed to delete row", zap.Error(err), zap.String("row_id", id))
+		return err
+	}
+
+	return nil
+}
+
+// GetFacilities retrieves the facilities of a cabin
+func (s *CabinService) GetFacilities(cabinID string) ([]*models.CabinFacility, error) {
+	if _, err := s.GetByID(cabinID); err != nil {
+		return nil, err
+	}
+
+	facilities, err := s.facilityRepository.GetByCabinID(cabinID)
+	if err != nil {
+		zap.L().Error("Failed to get facilities by cabin ID", zap.Error(err), zap.String("cabin_id", cabinID))
+		return nil, err
+	}
+
+	return facilities, nil
+}
+
+// AddFacility validates and adds a facility to a cabin. Row markers such as
+// exits and wings take no columns; space facilities such as lavatories and
+// galleys occupy columns in front of or behind a row, without overlapping
+// another facility there.
+func (s *CabinService) AddFacility(cabinID, facilityType string, rowNumber int, position, columns string) (*models.CabinFacility, error) {
+	cabin, err := s.GetByID(cabinID)
+	if err != nil {
+		return nil, err
+	}
+
+	facilityType = strings.ToUpper(strings.TrimSpace(facilityType))
+	if !models.IsValidFacilityType(facilityType) {
+		return nil, fmt.Errorf("%w: unknown facility type %q", services.ErrInvalidLayout, facilityType)
+	}
+
+	if !cabin.ContainsRow(rowNumber) {
+		return nil, fmt.Errorf("%w: row %d is outside cabin rows %d-%d",
+			services.ErrInvalidLayout, rowNumber, cabin.FirstRow, cabin.LastRow)
+	}
+
+	position = strings.ToUpper(strings.TrimSpace(position))
+	if position == "" {
+		position = models.FacilityPositionFront
+	}
+	if position != models.FacilityPositionFront && position != models.FacilityPositionRear {
+		return nil, fmt.Errorf("%w: facility position must be FRONT or REAR", services.ErrInvalidLayout)
+	}
+
+	facility := models.NewCabinFacility(cabinID, facilityType, rowNumber, position, normalizeColumns(columns))
+
+	existing, err := s.facilityRepository.GetByCabinID(cabinID)
+	if err != nil {
+		zap.L().Error("Failed to get facilities by cabin ID", zap.Error(err), zap.String("cabin_id", cabinID))
+		return nil, err
+	}
+
+	if facility.IsRowMarker() {
+		if facility.Columns != "" {
+			return nil, fmt.Errorf("%w: %s marks a whole row and takes no columns", services.ErrInvalidLayout, facilityType)
+		}
+		for _, other := range existing {
+			if other.Type == facility.Type && other.RowNumber == facility.RowNumber {
+				return nil, fmt.Errorf("%w: row %d is already marked %s", services.ErrInvalidLayout, rowNumber, facilityType)
+			}
+		}
+	} else {
+		occupied := map[string]bool{}
+		for _, other := range existing {
+			if !other.IsRowMarker() && other.RowNumber == rowNumber && other.Position == position {
+				for _, column := range other.ColumnList() {
+					occupied[column] = true
+				}
+			}
+		}
+
+		facilityColumns := facility.ColumnList()
+		if len(facilityColumns) == 0 {
+			return nil, fmt.Errorf("%w: %s must occupy at least one column", services.ErrInvalidLayout, facilityType)
+		}
+		for _, column := range facilityColumns {
+			if !cabin.HasColumn(column) {
+				return nil, fmt.Errorf("%w: column %s is not a cabin seat column", services.ErrInvalidLayout, column)
+			}
+			if occupied[column] {
+				return nil, fmt.Errorf("%w: column %s is already taken by another facility", services.ErrInvalidLayout, column)
+			}
+			occupied[column] = true
+		}
+	}
+
+	if err := s.facilityRepository.Create(facility); err != nil {
+		zap.L().Error("Failed to create facility", zap.Error(err), zap.String("cabin_id", cabinID))
+		return nil, err
+	}
+
+	return facility, nil
+}
+
+// DeleteFacility deletes a cabin facility
+func (s *CabinService) DeleteFacility(id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return services.ErrFacilityNotFound
+	}
+
+	facility, err := s.facilityRepository.GetByID(id)
+	if err != nil {
+		zap.L().Error("Failed to get facility", zap.Error(err), zap.String("facility_id", id))
+		return err
+	}
+
+	if facility == nil {
+		return services.ErrFacilityNotFound
+	}
+
+	if err := s.facilityRepository.Delete(id); err != nil {
+		zap.L().Error("Failed to delete facility", zap.Error(err), zap.String("facility_id", id))
+		return err
+	}
+
+	return nil
+}
+
+// validateCabin checks the cabin's deck, row range and columns, that the
+// aircraft and segment exist and that its rows do not overlap another cabin
+// on the same deck of the same layout. Decks are numbered independently.
+func (s *CabinService) validateCabin(cabin *models.Cabin) error {
+	cabin.Deck = strings.ToUpper(strings.TrimSpace(cabin.Deck))
+	if cabin.Deck == "" {
+		cabin.Deck = models.DeckMain
+	}
+	if !models.IsValidDeck(cabin.Deck) {
+		return fmt.Errorf("%w: unknown deck %q", services.ErrInvalidLayout, cabin.Deck)
+	}
+
+	if cabin.FirstRow < 1 || cabin.LastRow < cabin.FirstRow {
+		return fmt.Errorf("%w: row range %d-%d is not valid", services.ErrInvalidLayout, cabin.FirstRow, cabin.LastRow)
+	}
+
+	cabin.SeatColumns = normalizeColumns(cabin.SeatColumns)
+	if _, err := cabin.Layout(); err != nil {
+		return fmt.Errorf("%w: %v", services.ErrInvalidLayout, err)
+	}
+
+	if _, err := uuid.Parse(cabin.AircraftID); err != nil {
+		return services.ErrAircraftNotFound
+	}
+
+	aircraft, err := s.aircraftRepository.GetByID(cabin.AircraftID)
+	if err != nil {
+		zap.L().Error("Failed to get aircraft", zap.Error(err), zap.String("aircraft_id", cabin.AircraftID))
+		return err
+	}
+	if aircraft == nil {
+		return services.ErrAircraftNotFound
+	}
+
+	if cabin.SegmentID != "" {
+		if _, err := uuid.Parse(cabin.SegmentID); err != nil {
+			return services.ErrFlightNotFound
+		}
+
+		flight, err := s.flightRepository.GetByID(cabin.SegmentID)
+		if err != nil {
+			zap.L().Error("Failed to get flight", zap.Error(err), zap.String("flight_id", cabin.SegmentID))
+			return err
+		}
+		if flight == nil {
+			return services.ErrFlightNotFound
+		}
+	}
+
+	others, err := s.cabinRepository.GetByAircraftID(cabin.AircraftID)
+	if err != nil {
+		zap.L().Error("Failed to get cabins by aircraft ID", zap.Error(err), zap.String("aircraft_id", cabin.AircraftID))
+		return err
+	}
+
+	for _, other := range others {
+		if other.ID == cabin.ID || other.SegmentID != cabin.SegmentID || other.Deck != cabin.Deck {
+			continue
+		}
+		if cabin.OverlapsRows(other) {
+			return fmt.Errorf("%w: rows %d-%d overlap cabin %s (rows %d-%d)",
+				services.ErrInvalidLayout, cabin.FirstRow, cabin.LastRow, other.ID, other.FirstRow, other.LastRow)
+		}
+	}
+
+	return nil
+}
+
+// validateRow checks the row against the cabin layout and the cabin's other rows
+func (s *CabinService) validateRow(cabin *models.Cabin, row *models.SeatRow) error {
+	if err := validateRowLayout(cabin, row); err != nil {
+		return err
+	}
+
+	rows, err := s.rowRepository.GetByCabinID(cabin.ID)
+	if err != nil {
+		zap.L().Error("Failed to get rows by cabin ID", zap.Error(err), zap.String("cabin_id", cabin.ID))
+		return err
+	}
+
+	for _, other := range rows {
+		if other.ID != row.ID && other.RowNumber == row.RowNumber {
+			return fmt.Errorf("%w: row %d already exists in cabin", services.ErrInvalidLayout, row.RowNumber)
+		}
+	}
+
+	return nil
+}
+
+// validateRowLayout checks that a row falls within the cabin's row range and only uses its columns
+func validateRowLayout(cabin *models.Cabin, row *models.SeatRow) error {
+	if !cabin.ContainsRow(row.RowNumber) {
+		return fmt.Errorf("%w: row %d is outside cabin rows %d-%d",
+			services.ErrInvalidLayout, row.RowNumber, cabin.FirstRow, cabin.LastRow)
+	}
+
+	columns := row.Columns()
+	if len(columns) == 0 {
+		return fmt.Errorf("%w: row %d has no seat columns", services.ErrInvalidLayout, row.RowNumber)
+	}
+
+	seen := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		if !cabin.HasColumn(column) {
+			return fmt.Errorf("%w: row %d column %s is not a cabin seat column", services.ErrInvalidLayout, row.RowNumber, column)
+		}
+		if seen[column] {
+			return fmt.Errorf("%w: row %d column %s appears more than once", services.ErrInvalidLayout, row.RowNumber, column)
+		}
+		seen[column] = true
+	}
+
+	return nil
+}
+
+// validateSeatCode checks that a seat code belongs to the row and one of its columns
+func validateSeatCode(cabin *models.Cabin, row *models.SeatRow, code string) error {
+	rowNumber, column, ok := models.ParseSeatCode(code)
+	if !ok {
+		return fmt.Errorf("%w: seat code %q must be a row number followed by a column letter", services.ErrInvalidLayout, code)
+	}
+
+	if rowNumber != row.RowNumber {
+		return fmt.Errorf("%w: seat %s does not belong to row %d", services.ErrInvalidLayout, code, row.RowNumber)
+	}
+
+	if !cabin.HasColumn(column) {
+		return fmt.Errorf("%w: seat %s column %s is not a cabin seat column", services.ErrInvalidLayout, code, column)
+	}
+
+	for _, col := range row.Columns() {
+		if col == column {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: seat %s column %s is not part of row %d", services.ErrInvalidLayout, code, column, row.RowNumber)
+}
+
+// normalizeColumns trims and upper-cases a comma-separated column list
+func normalizeColumns(columns string) string {
+	parts := strings.Split(columns, ",")
+	normalized := make([]string, 0, len(parts))
+	for _, part := range parts {
+		part = strings.ToUpper(strings.TrimSpace(part))
+		if part != "" {
+			normalized = append(normalized, part)
+		}
+	}
+	return strings.Join(normalized, ",")
+}
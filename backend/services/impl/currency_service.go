@@ -0,0 +1,159 @@
+package impl
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+	"github.com/evaizee/seat-arrangements/backend/services"
+	"go.uber.org/zap"
+)
+
+// CurrencyService is an implementation of the CurrencyService interface
+type CurrencyService struct {
+	exchangeRateRepository repositories.ExchangeRateRepository
+}
+
+// NewCurrencyService creates a new CurrencyService
+func NewCurrencyService(exchangeRateRepository repositories.ExchangeRateRepository) services.CurrencyService {
+	return &CurrencyService{
+		exchangeRateRepository: exchangeRateRepository,
+	}
+}
+
+// Convert converts an amount to another currency at the rate in effect at a time
+func (s *CurrencyService) Convert(amount models.Money, currency string, at time.Time) (models.Money, error) {
+	return newCurrencyConverter(s.exchangeRateRepository, at).convert(amount, currency)
+}
+
+// GetRates retrieves all exchange rates
+func (s *CurrencyService) GetRates() ([]*models.ExchangeRate, error) {
+	rates, err := s.exchangeRateRepository.GetAll()
+	if err != nil {
+		zap.L().Error("Failed to get exchange rates", zap.Error(err))
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+// CreateRate adds an exchange rate taking effect at effectiveFrom. Rates are
+// never updated in place so prices quoted earlier can still be explained.
+func (s *CurrencyService) CreateRate(baseCurrency, quoteCurrency, rate string, effectiveFrom time.Time) (*models.ExchangeRate, error) {
+	baseCurrency = strings.ToUpper(baseCurrency)
+	quoteCurrency = strings.ToUpper(quoteCurrency)
+
+	if !models.IsValidCurrency(baseCurrency) || !models.IsValidCurrency(quoteCurrency) {
+		return nil, services.ErrInvalidCurrency
+	}
+
+	if baseCurrency == quoteCurrency {
+		return nil, fmt.Errorf("%w: base and quote currency must differ", services.ErrInvalidRate)
+	}
+
+	exchangeRate := models.NewExchangeRate(baseCurrency, quoteCurrency, strings.TrimSpace(rate), effectiveFrom)
+	if _, err := exchangeRate.Value(); err != nil {
+		return nil, fmt.Errorf("%w: %v", services.ErrInvalidRate, err)
+	}
+
+	if err := s.exchangeRateRepository.Create(exchangeRate); err != nil {
+		if errors.Is(err, repositories.ErrDuplicate) {
+			return nil, services.ErrRateExists
+		}
+		zap.L().Error("Failed to create exchange rate", zap.Error(err),
+			zap.String("base_currency", baseCurrency),
+			zap.String("quote_currency", quoteCurrency))
+		return nil, err
+	}
+
+	return exchangeRate, nil
+}
+
+// currencyConverter converts amounts at the rates in effect at a fixed time,
+// looking each currency pair up once
+type currencyConverter struct {
+	repository repositories.ExchangeRateRepository
+	at         time.Time
+	rates      map[string]*big.Rat
+}
+
+// newCurrencyConverter creates a converter using the rates in effect at a time
+func newCurrencyConverter(repository repositories.ExchangeRateRepository, at time.Time) *currencyConverter {
+	return &currencyConverter{
+		repository: repository,
+		at:         at,
+		rates:      map[string]*big.Rat{},
+	}
+}
+
+// convert converts an amount to currency, rounded to the currency's minor units.
+// Zero amounts convert without a rate.
+func (c *currencyConverter) convert(amount models.Money, currency string) (models.Money, error) {
+	if amount.Currency == currency {
+		return amount, nil
+	}
+
+	if amount.IsZero() {
+		return models.ZeroMoney(currency), nil
+	}
+
+	rate, err := c.rate(amount.Currency, currency)
+	if err != nil {
+		return models.Money{}, err
+	}
+
+	return amount.Convert(currency, rate), nil
+}
+
+// rate returns the rate from base to quote, using the inverse of the quote to
+// base rate when only that one is known
+func (c *currencyConverter) rate(base, quote string) (*big.Rat, error) {
+	key := base + quote
+	if rate, ok := c.rates[key]; ok {
+		return rate, nil
+	}
+
+	rate, err := c.effectiveRate(base, quote)
+	if err != nil {
+		return nil, err
+	}
+
+	if rate == nil {
+		inverse, err := c.effectiveRate(quote, base)
+		if err != nil {
+			return nil, err
+		}
+		if inverse == nil {
+			return nil, fmt.Errorf("%w: %s to %s", services.ErrRateNotFound, base, quote)
+		}
+		rate = new(big.Rat).Inv(inverse)
+	}
+
+	c.rates[key] = rate
+	return rate, nil
+}
+
+// effectiveRate retrieves the stored rate for a currency pair, or nil if there is none
+func (c *currencyConverter) effectiveRate(base, quote string) (*big.Rat, error) {
+	if c.repository == nil {
+		return nil, nil
+	}
+
+	exchangeRate, err := c.repository.GetEffective(base, quote, c.at)
+	if err != nil {
+		zap.L().Error("Failed to get exchange rate", zap.Error(err),
+			zap.String("base_currency", base),
+			zap.String("quote_currency", quote))
+		return nil, err
+	}
+
+	if exchangeRate == nil {
+		return nil, nil
+	}
+
+	return exchangeRate.Value()
+}
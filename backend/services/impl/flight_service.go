@@ -0,0 +1,122 @@
+package impl
+
+import (
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+	"github.com/evaizee/seat-arrangements/backend/services"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultFlightPageSize is used when a search does not specify a page size
+	defaultFlightPageSize = 20
+	// maxFlightPageSize caps the page size of a flight search
+	maxFlightPageSize = 100
+)
+
+// FlightService is an implementation of the FlightService interface
+type FlightService struct {
+	flightRepository   repositories.FlightRepository
+	aircraftRepository repositories.AircraftRepository
+	cabinRepository    repositories.CabinRepository
+}
+
+// NewFlightService creates a new FlightService
+func NewFlightService(
+	flightRepository repositories.FlightRepository,
+	aircraftRepository repositories.AircraftRepository,
+	cabinRepository repositories.CabinRepository,
+) services.FlightService {
+	return &FlightService{
+		flightRepository:   flightRepository,
+		aircraftRepository: aircraftRepository,
+		cabinRepository:    cabinRepository,
+	}
+}
+
+// GetByID retrieves a flight by ID. IDs that are not UUIDs cannot match any flight.
+func (s *FlightService) GetByID(id string) (*models.Flight, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, services.ErrFlightNotFound
+	}
+
+	flight, err := s.flightRepository.GetByID(id)
+	if err != nil {
+		zap.L().Error("Failed to get flight", zap.Error(err), zap.String("flight_id", id))
+		return nil, err
+	}
+
+	if flight == nil {
+		return nil, services.ErrFlightNotFound
+	}
+
+	return flight, nil
+}
+
+// GetAll retrieves all flights
+func (s *FlightService) GetAll() ([]*models.Flight, error) {
+	return s.flightRepository.GetAll()
+}
+
+// GetWithDetails retrieves a flight with the aircraft flying it and its cabins
+func (s *FlightService) GetWithDetails(id string) (*models.FlightWithDetails, error) {
+	flight, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// The flight's equipment code identifies the aircraft type
+	var aircraft *models.Aircraft
+	if flight.Equipment != "" {
+		aircraft, err = s.aircraftRepository.GetByCode(flight.Equipment)
+		if err != nil {
+			zap.L().Error("Failed to get aircraft", zap.Error(err), zap.String("aircraft_code", flight.Equipment))
+			return nil, err
+		}
+	}
+
+	cabins, err := s.cabinRepository.GetBySegmentID(flight.ID)
+	if err != nil {
+		zap.L().Error("Failed to get cabins", zap.Error(err), zap.String("flight_id", flight.ID))
+		return nil, err
+	}
+
+	if cabins == nil {
+		cabins = []*models.Cabin{}
+	}
+
+	return &models.FlightWithDetails{
+		Flight:   flight,
+		Aircraft: aircraft,
+		Cabins:   cabins,
+	}, nil
+}
+
+// Search retrieves a page of flights matching the filter.
+// Pages start at 1; a page size of 0 uses the default.
+func (s *FlightService) Search(filter models.FlightFilter, page, pageSize int) (*models.FlightSearchResult, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = defaultFlightPageSize
+	}
+	if pageSize > maxFlightPageSize {
+		pageSize = maxFlightPageSize
+	}
+
+	filter.Limit = pageSize
+	filter.Offset = (page - 1) * pageSize
+
+	result, err := s.flightRepository.Search(filter)
+	if err != nil {
+		zap.L().Error("Failed to search flights", zap.Error(err))
+		return nil, err
+	}
+
+	result.Page = page
+	result.PageSize = pageSize
+
+	return result, nil
+}
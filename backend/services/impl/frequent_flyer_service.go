@@ -2,10 +2,13 @@ package impl
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/evaizee/seat-arrangements/backend/models"
 	"github.com/evaizee/seat-arrangements/backend/repositories"
 	"github.com/evaizee/seat-arrangements/backend/services"
+	"go.uber.org/zap"
 )
 
 // FrequentFlyerService is an implementation of the FrequentFlyerService interface
@@ -22,11 +25,30 @@ func NewFrequentFlyerService(
 	}
 }
 
-// CreateFrequentFlyer creates a new frequent flyer record
-func (s *FrequentFlyerService) CreateFrequentFlyer(passengerID int, airline, number string) (*models.FrequentFlyer, error) {
-	frequentFlyer := models.NewFrequentFlyer(passengerID, airline, number)
+// CreateFrequentFlyer validates and creates a new frequent flyer record.
+// A passenger may hold each airline's number only once.
+func (s *FrequentFlyerService) CreateFrequentFlyer(frequentFlyer *models.FrequentFlyer) (*models.FrequentFlyer, error) {
+	frequentFlyer.Airline = normalizeCode(frequentFlyer.Airline)
+	frequentFlyer.Number = strings.ReplaceAll(normalizeCode(frequentFlyer.Number), " ", "")
+	frequentFlyer.TierLevel = normalizeOptional(frequentFlyer.TierLevel, normalizeCode)
 
-	err := s.frequentFlyerRepository.Create(frequentFlyer)
+	if err := frequentFlyer.Validate(); err != nil {
+		return nil, services.NewValidationError(services.ErrInvalidPassenger, err)
+	}
+
+	existing, err := s.frequentFlyerRepository.GetByPassengerID(strconv.Itoa(frequentFlyer.PassengerID))
+	if err != nil {
+		zap.L().Error("Failed to get frequent flyers", zap.Error(err), zap.Int("passenger_id", frequentFlyer.PassengerID))
+		return nil, err
+	}
+
+	for _, other := range existing {
+		if other.Airline == frequentFlyer.Airline && other.Number == frequentFlyer.Number {
+			return nil, services.ErrFlyerNumberExists
+		}
+	}
+
+	err = s.frequentFlyerRepository.Create(frequentFlyer)
 	if err != nil {
 		return nil, fmt.Errorf("error creating frequent flyer: %w", err)
 	}
@@ -47,4 +69,4 @@ func (s *FrequentFlyerService) GetByPassengerID(passengerID string) ([]*models.F
 // UpdateFrequentFlyer updates a frequent flyer
 func (s *FrequentFlyerService) UpdateFrequentFlyer(frequentFlyer *models.FrequentFlyer) error {
 	return s.frequentFlyerRepository.Update(frequentFlyer)
-}
\ No newline at end of file
+}
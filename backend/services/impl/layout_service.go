@@ -0,0 +1,382 @@
+package impl
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+	"github.com/evaizee/seat-arrangements/backend/services"
+	"go.uber.org/zap"
+)
+
+// LayoutService is an implementation of the LayoutService interface
+type LayoutService struct {
+	flightRepository   repositories.FlightRepository
+	aircraftRepository repositories.AircraftRepository
+	cabinRepository    repositories.CabinRepository
+	rowRepository      repositories.RowRepository
+	seatRepository     repositories.SeatRepository
+}
+
+// NewLayoutService creates a new LayoutService
+func NewLayoutService(
+	flightRepository repositories.FlightRepository,
+	aircraftRepository repositories.AircraftRepository,
+	cabinRepository repositories.CabinRepository,
+	rowRepository repositories.RowRepository,
+	seatRepository repositories.SeatRepository,
+) services.LayoutService {
+	return &LayoutService{
+		flightRepository:   flightRepository,
+		aircraftRepository: aircraftRepository,
+		cabinRepository:    cabinRepository,
+		rowRepository:      rowRepository,
+		seatRepository:     seatRepository,
+	}
+}
+
+// layoutCheck holds the state of a single consistency check run
+type layoutCheck struct {
+	*LayoutService
+	fix      bool
+	report   *models.LayoutReport
+	flights  map[string]*models.Flight
+	aircraft map[string]*models.Aircraft
+}
+
+// Check scans the cabins of a segment, or of every segment and aircraft when
+// segmentID is empty, and reports layout inconsistencies. When fix is set,
+// issues that can be repaired without losing data are fixed in place.
+func (s *LayoutService) Check(segmentID string, fix bool) (*models.LayoutReport, error) {
+	check := &layoutCheck{
+		LayoutService: s,
+		fix:           fix,
+		report: &models.LayoutReport{
+			SegmentID: segmentID,
+			Issues:    []*models.LayoutIssue{},
+		},
+		flights:  map[string]*models.Flight{},
+		aircraft: map[string]*models.Aircraft{},
+	}
+
+	var cabins []*models.Cabin
+	if segmentID != "" {
+		flight, err := check.getFlight(segmentID)
+		if err != nil {
+			return nil, err
+		}
+		if flight == nil {
+			return nil, services.ErrFlightNotFound
+		}
+		if cabins, err = s.cabinRepository.GetBySegmentID(segmentID); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		if cabins, err = s.cabinRepository.GetAll(); err != nil {
+			return nil, err
+		}
+	}
+
+	check.checkOverlaps(cabins)
+
+	for _, cabin := range cabins {
+		if err := check.checkCabin(cabin); err != nil {
+			zap.L().Error("Failed to check cabin", zap.Error(err), zap.String("cabin_id", cabin.ID))
+			return nil, err
+		}
+	}
+
+	return check.report, nil
+}
+
+// checkOverlaps reports cabins on the same deck of the same aircraft and segment whose row ranges overlap
+func (c *layoutCheck) checkOverlaps(cabins []*models.Cabin) {
+	for i, cabin := range cabins {
+		for _, other := range cabins[i+1:] {
+			if cabin.AircraftID != other.AircraftID || cabin.SegmentID != other.SegmentID || cabin.Deck != other.Deck {
+				continue
+			}
+			if cabin.OverlapsRows(other) {
+				c.add(&models.LayoutIssue{
+					Code: models.IssueCabinOverlap,
+					Message: fmt.Sprintf("cabin rows %d-%d overlap cabin %s rows %d-%d",
+						cabin.FirstRow, cabin.LastRow, other.ID, other.FirstRow, other.LastRow),
+					SegmentID: cabin.SegmentID,
+					CabinID:   cabin.ID,
+				})
+			}
+		}
+	}
+}
+
+// checkCabin checks a cabin against its segment's equipment and then checks its rows
+func (c *layoutCheck) checkCabin(cabin *models.Cabin) error {
+	c.report.CabinsChecked++
+
+	if !models.IsValidDeck(cabin.Deck) {
+		c.add(&models.LayoutIssue{
+			Code:      models.IssueCabinDeckInvalid,
+			Message:   fmt.Sprintf("deck %q is not a known deck", cabin.Deck),
+			SegmentID: cabin.SegmentID,
+			CabinID:   cabin.ID,
+		})
+	}
+
+	if _, err := cabin.Layout(); err != nil {
+		c.add(&models.LayoutIssue{
+			Code:      models.IssueCabinLayoutInvalid,
+			Message:   fmt.Sprintf("seat columns %q are not a valid layout: %v", cabin.SeatColumns, err),
+			SegmentID: cabin.SegmentID,
+			CabinID:   cabin.ID,
+		})
+	}
+
+	if cabin.SegmentID != "" {
+		flight, err := c.getFlight(cabin.SegmentID)
+		if err != nil {
+			return err
+		}
+
+		aircraft, err := c.getAircraft(cabin.AircraftID)
+		if err != nil {
+			return err
+		}
+
+		if flight != nil && aircraft != nil && flight.Equipment != aircraft.Code {
+			c.add(&models.LayoutIssue{
+				Code: models.IssueCabinAircraftMismatch,
+				Message: fmt.Sprintf("cabin belongs to aircraft %s but segment %s %s is flown by %s",
+					aircraft.Code, flight.AirlineCode, flight.FlightNumber, flight.Equipment),
+				SegmentID: cabin.SegmentID,
+				CabinID:   cabin.ID,
+			})
+		}
+	}
+
+	rows, err := c.rowRepository.GetByCabinID(cabin.ID)
+	if err != nil {
+		return err
+	}
+
+	rowNumbers := map[int]string{}
+	for _, row := range rows {
+		c.report.RowsChecked++
+
+		if !cabin.ContainsRow(row.RowNumber) {
+			c.add(&models.LayoutIssue{
+				Code:      models.IssueRowOutOfRange,
+				Message:   fmt.Sprintf("row %d is outside cabin rows %d-%d", row.RowNumber, cabin.FirstRow, cabin.LastRow),
+				SegmentID: cabin.SegmentID,
+				CabinID:   cabin.ID,
+				RowID:     row.ID,
+			})
+		}
+
+		if otherID, ok := rowNumbers[row.RowNumber]; ok {
+			c.add(&models.LayoutIssue{
+				Code:      models.IssueRowDuplicate,
+				Message:   fmt.Sprintf("row %d also exists as row %s", row.RowNumber, otherID),
+				SegmentID: cabin.SegmentID,
+				CabinID:   cabin.ID,
+				RowID:     row.ID,
+			})
+		}
+		rowNumbers[row.RowNumber] = row.ID
+
+		if err := c.checkRow(cabin, row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkRow checks the seats of a row and that the row's seat codes match them
+func (c *layoutCheck) checkRow(cabin *models.Cabin, row *models.SeatRow) error {
+	seats, err := c.seatRepository.GetByRowID(row.ID)
+	if err != nil {
+		return err
+	}
+
+	present := map[string]bool{}
+	seatsValid := true
+	codes := map[string]string{}
+
+	for _, seat := range seats {
+		c.report.SeatsChecked++
+
+		issue := func(code, message string, fixable bool) *models.LayoutIssue {
+			return c.add(&models.LayoutIssue{
+				Code:      code,
+				Message:   message,
+				SegmentID: cabin.SegmentID,
+				CabinID:   cabin.ID,
+				RowID:     row.ID,
+				SeatID:    seat.ID,
+				Fixable:   fixable,
+			})
+		}
+
+		if seat.SegmentID != cabin.SegmentID {
+			found := issue(models.IssueSeatSegmentMismatch,
+				fmt.Sprintf("seat %s is on segment %q but its cabin is on segment %q", seatLabel(seat), seat.SegmentID, cabin.SegmentID), true)
+			if c.fix {
+				seat.SegmentID = cabin.SegmentID
+				c.saveSeat(seat, found)
+			}
+		}
+
+		if seat.StorefrontSlotCode != models.SlotCodeSeat {
+			if seat.Available {
+				found := issue(models.IssueSeatSlotAvailable,
+					fmt.Sprintf("%s slot is marked available", seat.StorefrontSlotCode), true)
+				if c.fix {
+					seat.Available = false
+					c.saveSeat(seat, found)
+				}
+			}
+			continue
+		}
+
+		rowNumber, column, ok := models.ParseSeatCode(seat.Code)
+		if !ok {
+			issue(models.IssueSeatCodeInvalid, fmt.Sprintf("seat code %q is not a row number followed by a column letter", seat.Code), false)
+			seatsValid = false
+			continue
+		}
+
+		if rowNumber != row.RowNumber {
+			issue(models.IssueSeatRowMismatch, fmt.Sprintf("seat %s is in row %d", seat.Code, row.RowNumber), false)
+			seatsValid = false
+		}
+
+		if !cabin.HasColumn(column) {
+			issue(models.IssueSeatColumnUnknown,
+				fmt.Sprintf("seat %s column %s is not in cabin columns %s", seat.Code, column, cabin.SeatColumns), false)
+			seatsValid = false
+		}
+
+		if otherID, ok := codes[seat.Code]; ok {
+			issue(models.IssueSeatDuplicate, fmt.Sprintf("seat %s also exists as seat %s", seat.Code, otherID), false)
+		}
+		codes[seat.Code] = seat.ID
+		present[column] = true
+
+		price, err := c.seatRepository.GetPriceBySeatID(seat.ID)
+		if err != nil {
+			return err
+		}
+		if price == nil {
+			issue(models.IssueSeatMissingPrice, fmt.Sprintf("seat %s has no price", seat.Code), false)
+		}
+	}
+
+	// The row's seat codes should list exactly the columns of its seats, in cabin order
+	expected := []string{}
+	for _, column := range cabin.Columns() {
+		if present[column] {
+			expected = append(expected, column)
+		}
+	}
+
+	if !sameColumns(row.Columns(), expected) {
+		found := c.add(&models.LayoutIssue{
+			Code: models.IssueRowSeatCodesMismatch,
+			Message: fmt.Sprintf("row %d seat codes %q do not match its seats %q",
+				row.RowNumber, row.SeatCodes, strings.Join(expected, ",")),
+			SegmentID: cabin.SegmentID,
+			CabinID:   cabin.ID,
+			RowID:     row.ID,
+			Fixable:   seatsValid && len(expected) > 0,
+		})
+
+		if c.fix && found.Fixable {
+			row.SeatCodes = strings.Join(expected, ",")
+			row.UpdatedAt = time.Now()
+			if err := c.rowRepository.Update(row); err != nil {
+				zap.L().Error("Failed to fix row seat codes", zap.Error(err), zap.String("row_id", row.ID))
+			} else {
+				found.Fixed = true
+			}
+		}
+	}
+
+	return nil
+}
+
+// add records an issue in the report and returns it
+func (c *layoutCheck) add(issue *models.LayoutIssue) *models.LayoutIssue {
+	c.report.Issues = append(c.report.Issues, issue)
+	return issue
+}
+
+// saveSeat persists a fixed seat and marks the issue as fixed on success
+func (c *layoutCheck) saveSeat(seat *models.Seat, issue *models.LayoutIssue) {
+	seat.UpdatedAt = time.Now()
+	if err := c.seatRepository.Update(seat); err != nil {
+		zap.L().Error("Failed to fix seat", zap.Error(err), zap.String("seat_id", seat.ID))
+		return
+	}
+	issue.Fixed = true
+}
+
+// getFlight retrieves a flight, caching it for the rest of the check
+func (c *layoutCheck) getFlight(id string) (*models.Flight, error) {
+	if flight, ok := c.flights[id]; ok {
+		return flight, nil
+	}
+
+	flight, err := c.flightRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	c.flights[id] = flight
+	return flight, nil
+}
+
+// getAircraft retrieves an aircraft, caching it for the rest of the check
+func (c *layoutCheck) getAircraft(id string) (*models.Aircraft, error) {
+	if aircraft, ok := c.aircraft[id]; ok {
+		return aircraft, nil
+	}
+
+	aircraft, err := c.aircraftRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	c.aircraft[id] = aircraft
+	return aircraft, nil
+}
+
+// seatLabel returns the seat code, or the slot code for seats without one
+func seatLabel(seat *models.Seat) string {
+	if seat.Code != "" {
+		return seat.Code
+	}
+	return seat.StorefrontSlotCode
+}
+
+// sameColumns reports whether two column lists contain the same columns, ignoring order
+func sameColumns(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	seen := make(map[string]int, len(a))
+	for _, column := range a {
+		seen[column]++
+	}
+	for _, column := range b {
+		if seen[column] == 0 {
+			return false
+		}
+		seen[column]--
+	}
+
+	return true
+}
@@ -0,0 +1,76 @@
+package impl
+
+import (
+	"testing"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+)
+
+func TestCheckOverlaps(t *testing.T) {
+	cabin := func(id, aircraftID, segmentID, deck string, firstRow, lastRow int) *models.Cabin {
+		return &models.Cabin{ID: id, AircraftID: aircraftID, SegmentID: segmentID, Deck: deck, FirstRow: firstRow, LastRow: lastRow}
+	}
+
+	tests := []struct {
+		name   string
+		cabins []*models.Cabin
+		want   []string
+	}{
+		{
+			name:   "adjacent cabins",
+			cabins: []*models.Cabin{cabin("c1", "a1", "", models.DeckMain, 1, 5), cabin("c2", "a1", "", models.DeckMain, 6, 30)},
+			want:   []string{},
+		},
+		{
+			name:   "shared row",
+			cabins: []*models.Cabin{cabin("c1", "a1", "", models.DeckMain, 1, 5), cabin("c2", "a1", "", models.DeckMain, 5, 30)},
+			want:   []string{"c1"},
+		},
+		{
+			name:   "contained cabin",
+			cabins: []*models.Cabin{cabin("c1", "a1", "", models.DeckMain, 1, 30), cabin("c2", "a1", "", models.DeckMain, 10, 12)},
+			want:   []string{"c1"},
+		},
+		{
+			name:   "other deck",
+			cabins: []*models.Cabin{cabin("c1", "a1", "", models.DeckMain, 1, 5), cabin("c2", "a1", "", models.DeckUpper, 1, 5)},
+			want:   []string{},
+		},
+		{
+			name:   "other aircraft",
+			cabins: []*models.Cabin{cabin("c1", "a1", "", models.DeckMain, 1, 5), cabin("c2", "a2", "", models.DeckMain, 1, 5)},
+			want:   []string{},
+		},
+		{
+			name:   "segment layout and default layout",
+			cabins: []*models.Cabin{cabin("c1", "a1", "", models.DeckMain, 1, 5), cabin("c2", "a1", "s1", models.DeckMain, 1, 5)},
+			want:   []string{},
+		},
+		{
+			name: "each overlapping pair",
+			cabins: []*models.Cabin{
+				cabin("c1", "a1", "s1", models.DeckMain, 1, 10),
+				cabin("c2", "a1", "s1", models.DeckMain, 5, 15),
+				cabin("c3", "a1", "s1", models.DeckMain, 12, 20),
+			},
+			want: []string{"c1", "c2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := &layoutCheck{report: &models.LayoutReport{Issues: []*models.LayoutIssue{}}}
+			check.checkOverlaps(tt.cabins)
+
+			if len(check.report.Issues) != len(tt.want) {
+				t.Fatalf("issues = %d, want %d", len(check.report.Issues), len(tt.want))
+			}
+			for i, issue := range check.report.Issues {
+				if issue.Code != models.IssueCabinOverlap || issue.CabinID != tt.want[i] {
+					t.Errorf("issue %d = %s on cabin %s, want %s on cabin %s",
+						i, issue.Code, issue.CabinID, models.IssueCabinOverlap, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -1,37 +1,58 @@
 package impl
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/evaizee/seat-arrangements/backend/models"
 	"github.com/evaizee/seat-arrangements/backend/repositories"
 	"github.com/evaizee/seat-arrangements/backend/services"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
 // PassengerService is an implementation of the PassengerService interface
 type PassengerService struct {
 	passengerRepository     repositories.PassengerRepository
 	frequentFlyerRepository repositories.FrequentFlyerRepository
+	flightRepository        repositories.FlightRepository
+	airportRepository       repositories.AirportRepository
 }
 
 // NewPassengerService creates a new passenger service
 func NewPassengerService(
 	passengerRepository repositories.PassengerRepository,
 	frequentFlyerRepository repositories.FrequentFlyerRepository,
+	flightRepository repositories.FlightRepository,
+	airportRepository repositories.AirportRepository,
 ) services.PassengerService {
 	return &PassengerService{
 		passengerRepository:     passengerRepository,
 		frequentFlyerRepository: frequentFlyerRepository,
+		flightRepository:        flightRepository,
+		airportRepository:       airportRepository,
 	}
 }
 
-// CreatePassenger creates a new passenger
-func (s *PassengerService) CreatePassenger(segmentID int, passengerIndex int, passengerNameNumber, firstName, lastName string) (*models.Passenger, error) {
-	passenger := models.NewPassenger(segmentID, passengerIndex, passengerNameNumber, firstName, lastName)
+// CreatePassenger validates and creates a new passenger on a segment. Without a
+// name number the passenger gets a name element of their own, e.g. "03.01" for index 3.
+func (s *PassengerService) CreatePassenger(passenger *models.Passenger) (*models.Passenger, error) {
+	normalizePassenger(passenger)
+	if passenger.PassengerNameNumber == "" && passenger.PassengerIndex > 0 {
+		passenger.PassengerNameNumber = fmt.Sprintf("%02d.01", passenger.PassengerIndex)
+	}
+
+	if err := s.validate(passenger); err != nil {
+		return nil, err
+	}
 
 	err := s.passengerRepository.Create(passenger)
 	if err != nil {
+		zap.L().Error("Failed to create passenger", zap.Error(err), zap.String("segment_id", passenger.SegmentID))
 		return nil, fmt.Errorf("error creating passenger: %w", err)
 	}
 
@@ -40,17 +61,51 @@ func (s *PassengerService) CreatePassenger(segmentID int, passengerIndex int, pa
 
 // GetByID retrieves a passenger by ID
 func (s *PassengerService) GetByID(id string) (*models.Passenger, error) {
-	return s.passengerRepository.GetByID(id)
+	if _, err := strconv.Atoi(id); err != nil {
+		return nil, services.ErrPassengerNotFound
+	}
+
+	passenger, err := s.passengerRepository.GetByID(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, services.ErrPassengerNotFound
+		}
+		zap.L().Error("Failed to get passenger", zap.Error(err), zap.String("passenger_id", id))
+		return nil, err
+	}
+
+	return passenger, nil
 }
 
-// GetBySegmentID retrieves passengers by segment ID
+// CheckAPIS checks a passenger's advance passenger information against the
+// route of their segment, listing what must be completed before check-in
+func (s *PassengerService) CheckAPIS(id string) (*models.APISCheck, error) {
+	passenger, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return checkAPIS(s.passengerRepository, s.flightRepository, s.airportRepository, passenger)
+}
+
+// GetBySegmentID retrieves the passengers of a segment
 func (s *PassengerService) GetBySegmentID(segmentID string) ([]*models.Passenger, error) {
-	return s.passengerRepository.GetBySegmentID(segmentID)
+	if err := s.checkSegment(segmentID); err != nil {
+		return nil, err
+	}
+
+	passengers, err := s.passengerRepository.GetBySegmentID(segmentID)
+	if err != nil {
+		zap.L().Error("Failed to get passengers", zap.Error(err), zap.String("segment_id", segmentID))
+		return nil, err
+	}
+
+	return passengers, nil
 }
 
 // GetWithFrequentFlyers retrieves a passenger with their frequent flyer details
 func (s *PassengerService) GetWithFrequentFlyers(id string) (*models.PassengerWithDetails, error) {
-	passenger, err := s.passengerRepository.GetByID(id)
+	passenger, err := s.GetByID(id)
 	if err != nil {
 		return nil, err
 	}
@@ -66,7 +121,98 @@ func (s *PassengerService) GetWithFrequentFlyers(id string) (*models.PassengerWi
 	}, nil
 }
 
-// UpdatePassenger updates a passenger
+// UpdatePassenger validates and updates a passenger
 func (s *PassengerService) UpdatePassenger(passenger *models.Passenger) error {
-	return s.passengerRepository.Update(passenger)
-}
\ No newline at end of file
+	normalizePassenger(passenger)
+	if err := s.validate(passenger); err != nil {
+		return err
+	}
+
+	err := s.passengerRepository.Update(passenger)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return services.ErrPassengerNotFound
+		}
+		zap.L().Error("Failed to update passenger", zap.Error(err), zap.Int("passenger_id", passenger.ID))
+		return err
+	}
+
+	return nil
+}
+
+// validate checks the passenger's fields and that their segment exists
+func (s *PassengerService) validate(passenger *models.Passenger) error {
+	if err := passenger.Validate(time.Now()); err != nil {
+		return services.NewValidationError(services.ErrInvalidPassenger, err)
+	}
+
+	return s.checkSegment(passenger.SegmentID)
+}
+
+// checkSegment returns ErrFlightNotFound unless the segment exists
+func (s *PassengerService) checkSegment(segmentID string) error {
+	if _, err := uuid.Parse(segmentID); err != nil {
+		return services.ErrFlightNotFound
+	}
+
+	flight, err := s.flightRepository.GetByID(segmentID)
+	if err != nil {
+		zap.L().Error("Failed to get flight", zap.Error(err), zap.String("flight_id", segmentID))
+		return err
+	}
+
+	if flight == nil {
+		return services.ErrFlightNotFound
+	}
+
+	return nil
+}
+
+// normalizePassenger trims the passenger's fields, unsets blank optional ones and
+// upper-cases codes so that they are stored consistently
+func normalizePassenger(passenger *models.Passenger) {
+	passenger.SegmentID = strings.TrimSpace(passenger.SegmentID)
+	passenger.PassengerNameNumber = strings.TrimSpace(passenger.PassengerNameNumber)
+	passenger.FirstName = strings.TrimSpace(passenger.FirstName)
+	passenger.LastName = strings.TrimSpace(passenger.LastName)
+
+	passenger.Email = normalizeOptional(passenger.Email, strings.TrimSpace)
+	passenger.Phone = normalizeOptional(passenger.Phone, func(phone string) string {
+		return strings.NewReplacer(" ", "", "-", "", "(", "", ")", "").Replace(phone)
+	})
+	passenger.Street = normalizeOptional(passenger.Street, strings.TrimSpace)
+	passenger.City = normalizeOptional(passenger.City, strings.TrimSpace)
+	passenger.Postcode = normalizeOptional(passenger.Postcode, strings.TrimSpace)
+	passenger.AddressType = normalizeOptional(passenger.AddressType, normalizeCode)
+
+	for _, code := range []**string{
+		&passenger.Gender,
+		&passenger.Type,
+		&passenger.Country,
+		&passenger.DocumentType,
+		&passenger.DocumentNumber,
+		&passenger.IssuingCountry,
+		&passenger.CountryOfBirth,
+		&passenger.Nationality,
+	} {
+		*code = normalizeOptional(*code, normalizeCode)
+	}
+}
+
+// normalizeOptional applies normalize to an optional value, unsetting it when the result is blank
+func normalizeOptional(value *string, normalize func(string) string) *string {
+	if value == nil {
+		return nil
+	}
+
+	normalized := normalize(*value)
+	if normalized == "" {
+		return nil
+	}
+	return &normalized
+}
+
+// normalizeCode trims and upper-cases a code such as a country or document type
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
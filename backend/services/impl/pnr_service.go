@@ -0,0 +1,358 @@
+package impl
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+	"github.com/evaizee/seat-arrangements/backend/services"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// recordLocatorAttempts is how many random record locators are tried before giving up
+const recordLocatorAttempts = 5
+
+// PNRService is an implementation of the PNRService interface
+type PNRService struct {
+	pnrRepository       repositories.PNRRepository
+	passengerRepository repositories.PassengerRepository
+	flightRepository    repositories.FlightRepository
+	bookingRepository   repositories.BookingRepository
+}
+
+// NewPNRService creates a new PNR service
+func NewPNRService(
+	pnrRepository repositories.PNRRepository,
+	passengerRepository repositories.PassengerRepository,
+	flightRepository repositories.FlightRepository,
+	bookingRepository repositories.BookingRepository,
+) services.PNRService {
+	return &PNRService{
+		pnrRepository:       pnrRepository,
+		passengerRepository: passengerRepository,
+		flightRepository:    flightRepository,
+		bookingRepository:   bookingRepository,
+	}
+}
+
+// CreatePNR groups passengers on the segments of an itinerary under a new
+// record locator. Passenger records sharing a name number are the same
+// traveller on different segments, so they must carry the same name and be
+// on different segments.
+func (s *PNRService) CreatePNR(userID, itineraryID string, passengerIDs []int) (*models.PNRWithDetails, error) {
+	segments, err := s.getSegments(itineraryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(segments) == 0 {
+		return nil, services.ErrItineraryNotFound
+	}
+
+	if len(passengerIDs) == 0 {
+		return nil, fmt.Errorf("%w: at least one passenger is required", services.ErrInvalidPNR)
+	}
+
+	onItinerary := make(map[string]bool, len(segments))
+	for _, segment := range segments {
+		onItinerary[segment.ID] = true
+	}
+
+	seen := make(map[int]bool, len(passengerIDs))
+	travellers := make(map[string]*models.Passenger)
+	for _, passengerID := range passengerIDs {
+		if seen[passengerID] {
+			return nil, fmt.Errorf("%w: passenger %d is listed more than once", services.ErrInvalidPNR, passengerID)
+		}
+		seen[passengerID] = true
+
+		passenger, err := s.getPassenger(passengerID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !onItinerary[passenger.SegmentID] {
+			return nil, fmt.Errorf("%w: passenger %d is not on a segment of the itinerary", services.ErrInvalidPNR, passengerID)
+		}
+
+		if passenger.PNRID != nil {
+			return nil, services.ErrPassengerInPNR
+		}
+
+		key := passenger.PassengerNameNumber + "|" + passenger.SegmentID
+		if _, ok := travellers[key]; ok {
+			return nil, fmt.Errorf("%w: name number %s is used twice on segment %s",
+				services.ErrInvalidPNR, passenger.PassengerNameNumber, passenger.SegmentID)
+		}
+		travellers[key] = passenger
+
+		for _, other := range travellers {
+			if other.PassengerNameNumber == passenger.PassengerNameNumber &&
+				(!strings.EqualFold(other.FirstName, passenger.FirstName) || !strings.EqualFold(other.LastName, passenger.LastName)) {
+				return nil, fmt.Errorf("%w: passengers with name number %s have different names",
+					services.ErrInvalidPNR, passenger.PassengerNameNumber)
+			}
+		}
+	}
+
+	for attempt := 0; attempt < recordLocatorAttempts; attempt++ {
+		pnr, err := models.NewPNR(itineraryID, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.pnrRepository.Create(pnr, passengerIDs)
+		if errors.Is(err, repositories.ErrDuplicate) {
+			continue // Record locator taken, try another
+		}
+		if errors.Is(err, repositories.ErrConflict) {
+			return nil, services.ErrPassengerInPNR
+		}
+		if err != nil {
+			zap.L().Error("Failed to create PNR", zap.Error(err), zap.String("itinerary_id", itineraryID))
+			return nil, err
+		}
+
+		return s.getDetails(pnr, segments)
+	}
+
+	zap.L().Error("Failed to allocate a record locator", zap.String("itinerary_id", itineraryID))
+	return nil, fmt.Errorf("no free record locator after %d attempts", recordLocatorAttempts)
+}
+
+// GetByRecordLocator retrieves a PNR by its record locator and the last name
+// of one of its passengers, the lookup used to manage a reservation. A wrong
+// last name is reported as ErrPNRNotFound so that locators cannot be probed.
+func (s *PNRService) GetByRecordLocator(locator, lastName string) (*models.PNRWithDetails, error) {
+	pnr, err := s.findPNR(locator, lastName)
+	if err != nil {
+		return nil, err
+	}
+
+	segments, err := s.getSegments(pnr.ItineraryID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getDetails(pnr, segments)
+}
+
+// LinkBooking puts a booking for a segment of the PNR's itinerary on the PNR and
+// assigns its seats, keyed by booking seat ID, to passengers of the PNR on the
+// booked segment. A passenger holds at most one seat of a booking.
+func (s *PNRService) LinkBooking(bookingID, locator, lastName string, assignments map[string]int) (*models.PNRWithDetails, error) {
+	pnr, err := s.findPNR(locator, lastName)
+	if err != nil {
+		return nil, err
+	}
+
+	booking, err := s.bookingRepository.GetByID(bookingID)
+	if err != nil {
+		zap.L().Error("Failed to get booking", zap.Error(err), zap.String("booking_id", bookingID))
+		return nil, err
+	}
+
+	if booking == nil {
+		return nil, services.ErrBookingNotFound
+	}
+
+	if booking.PNRID != "" && booking.PNRID != pnr.ID {
+		return nil, fmt.Errorf("%w: booking is on another reservation", services.ErrInvalidPNR)
+	}
+
+	segments, err := s.getSegments(pnr.ItineraryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !containsSegment(segments, booking.FlightID) {
+		return nil, fmt.Errorf("%w: booking is not for a segment of the reservation", services.ErrInvalidPNR)
+	}
+
+	passengers, err := s.passengerRepository.GetByPNRID(pnr.ID)
+	if err != nil {
+		zap.L().Error("Failed to get PNR passengers", zap.Error(err), zap.String("pnr_id", pnr.ID))
+		return nil, err
+	}
+
+	onSegment := make(map[int]bool)
+	for _, passenger := range passengers {
+		if passenger.SegmentID == booking.FlightID {
+			onSegment[passenger.ID] = true
+		}
+	}
+
+	seats, err := s.bookingRepository.GetSeatsByBookingID(booking.ID)
+	if err != nil {
+		zap.L().Error("Failed to get booking seats", zap.Error(err), zap.String("booking_id", booking.ID))
+		return nil, err
+	}
+
+	now := time.Now()
+	assigned := []*models.BookingSeat{}
+	for _, seat := range seats {
+		passengerID, ok := assignments[seat.ID]
+		if !ok {
+			continue
+		}
+
+		if !onSegment[passengerID] {
+			return nil, fmt.Errorf("%w: passenger %d is not on the booked segment of the reservation", services.ErrInvalidPNR, passengerID)
+		}
+
+		seat.PassengerID = &passengerID
+		seat.UpdatedAt = now
+		assigned = append(assigned, seat)
+	}
+
+	if len(assigned) != len(assignments) {
+		return nil, services.ErrBookingSeatMissing
+	}
+
+	seatCount := make(map[int]int)
+	for _, seat := range seats {
+		if seat.PassengerID == nil {
+			continue
+		}
+		seatCount[*seat.PassengerID]++
+		if seatCount[*seat.PassengerID] > 1 {
+			return nil, fmt.Errorf("%w: passenger %d would hold more than one seat", services.ErrInvalidPNR, *seat.PassengerID)
+		}
+	}
+
+	booking.PNRID = pnr.ID
+	booking.UpdatedAt = now
+	err = s.bookingRepository.LinkPNR(booking, assigned)
+	if err != nil {
+		if errors.Is(err, repositories.ErrConflict) {
+			return nil, services.ErrConcurrentUpdate
+		}
+		zap.L().Error("Failed to link booking to PNR", zap.Error(err),
+			zap.String("booking_id", booking.ID),
+			zap.String("pnr_id", pnr.ID))
+		return nil, err
+	}
+
+	return s.getDetails(pnr, segments)
+}
+
+// findPNR retrieves a PNR by record locator, provided one of its passengers has the last name
+func (s *PNRService) findPNR(locator, lastName string) (*models.PNR, error) {
+	locator = models.NormalizeRecordLocator(locator)
+	lastName = strings.TrimSpace(lastName)
+	if !models.IsValidRecordLocator(locator) || lastName == "" {
+		return nil, services.ErrPNRNotFound
+	}
+
+	pnr, err := s.pnrRepository.GetByRecordLocator(locator)
+	if err != nil {
+		zap.L().Error("Failed to get PNR", zap.Error(err), zap.String("record_locator", locator))
+		return nil, err
+	}
+
+	if pnr == nil {
+		return nil, services.ErrPNRNotFound
+	}
+
+	passengers, err := s.passengerRepository.GetByPNRID(pnr.ID)
+	if err != nil {
+		zap.L().Error("Failed to get PNR passengers", zap.Error(err), zap.String("pnr_id", pnr.ID))
+		return nil, err
+	}
+
+	for _, passenger := range passengers {
+		if strings.EqualFold(strings.TrimSpace(passenger.LastName), lastName) {
+			return pnr, nil
+		}
+	}
+
+	return nil, services.ErrPNRNotFound
+}
+
+// getDetails loads the passengers and bookings of a PNR. Each traveller's
+// passenger records are listed in the order of the itinerary's segments.
+func (s *PNRService) getDetails(pnr *models.PNR, segments []*models.Flight) (*models.PNRWithDetails, error) {
+	passengers, err := s.passengerRepository.GetByPNRID(pnr.ID)
+	if err != nil {
+		zap.L().Error("Failed to get PNR passengers", zap.Error(err), zap.String("pnr_id", pnr.ID))
+		return nil, err
+	}
+
+	segmentOrder := make(map[string]int, len(segments))
+	for i, segment := range segments {
+		segmentOrder[segment.ID] = i
+	}
+	sort.SliceStable(passengers, func(i, j int) bool {
+		return segmentOrder[passengers[i].SegmentID] < segmentOrder[passengers[j].SegmentID]
+	})
+
+	bookings, err := s.bookingRepository.GetByPNRID(pnr.ID)
+	if err != nil {
+		zap.L().Error("Failed to get PNR bookings", zap.Error(err), zap.String("pnr_id", pnr.ID))
+		return nil, err
+	}
+
+	pnrBookings := make([]*models.PNRBooking, 0, len(bookings))
+	for _, booking := range bookings {
+		seats, err := s.bookingRepository.GetSeatsByBookingID(booking.ID)
+		if err != nil {
+			zap.L().Error("Failed to get booking seats", zap.Error(err), zap.String("booking_id", booking.ID))
+			return nil, err
+		}
+		pnrBookings = append(pnrBookings, &models.PNRBooking{Booking: booking, Seats: seats})
+	}
+
+	return &models.PNRWithDetails{
+		PNR:        pnr,
+		Segments:   segments,
+		Passengers: models.GroupPNRPassengers(passengers),
+		Bookings:   pnrBookings,
+	}, nil
+}
+
+// getSegments retrieves the segments of an itinerary in departure order. IDs
+// that are not UUIDs cannot match any itinerary.
+func (s *PNRService) getSegments(itineraryID string) ([]*models.Flight, error) {
+	if _, err := uuid.Parse(itineraryID); err != nil {
+		return []*models.Flight{}, nil
+	}
+
+	result, err := s.flightRepository.Search(models.FlightFilter{ItineraryID: itineraryID})
+	if err != nil {
+		zap.L().Error("Failed to get itinerary segments", zap.Error(err), zap.String("itinerary_id", itineraryID))
+		return nil, err
+	}
+
+	return result.Flights, nil
+}
+
+// getPassenger retrieves a passenger, mapping missing passengers to ErrPassengerNotFound
+func (s *PNRService) getPassenger(passengerID int) (*models.Passenger, error) {
+	passenger, err := s.passengerRepository.GetByID(strconv.Itoa(passengerID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, services.ErrPassengerNotFound
+		}
+		zap.L().Error("Failed to get passenger", zap.Error(err), zap.Int("passenger_id", passengerID))
+		return nil, err
+	}
+
+	return passenger, nil
+}
+
+// containsSegment reports whether a segment is among segments
+func containsSegment(segments []*models.Flight, segmentID string) bool {
+	for _, segment := range segments {
+		if segment.ID == segmentID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,336 @@
+package impl
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// occupancyTier multiplies seat prices once the cabin's load factor reaches MinLoadFactor
+type occupancyTier struct {
+	MinLoadFactor float64 `mapstructure:"min_load_factor"`
+	Multiplier    float64 `mapstructure:"multiplier"`
+}
+
+// departureTier multiplies seat prices from MaxDays before departure onwards
+type departureTier struct {
+	MaxDays    float64 `mapstructure:"max_days"`
+	Multiplier float64 `mapstructure:"multiplier"`
+}
+
+// pricingConfig is the pricing section of the configuration. Amounts are
+// decimals in the pricing currency.
+type pricingConfig struct {
+	Enabled  bool   `mapstructure:"enabled"`
+	Currency string `mapstructure:"currency"`
+	Base     struct {
+		Default         string            `mapstructure:"default"`
+		Characteristics map[string]string `mapstructure:"characteristics"`
+	} `mapstructure:"base"`
+	Occupancy []occupancyTier `mapstructure:"occupancy"`
+	Departure []departureTier `mapstructure:"departure"`
+	Floor     string          `mapstructure:"floor"`
+	Ceiling   string          `mapstructure:"ceiling"`
+}
+
+// pricingEngine computes seat prices at request time from the pricing rules
+type pricingEngine struct {
+	enabled         bool
+	currency        string
+	base            *models.Money
+	characteristics map[string]models.Money
+	occupancy       []occupancyTier
+	departure       []departureTier
+	floor           *models.Money
+	ceiling         *models.Money
+}
+
+// seatQuote is the input to a seat price: the seat, its characteristics as its
+// cabin layout shows them, its static price if any, the load factor of its
+// cabin and the time left until departure
+type seatQuote struct {
+	seat            *models.Seat
+	characteristics []string
+	staticPrice     *models.SeatPrice
+	loadFactor      float64
+	untilDeparture  time.Duration
+}
+
+// loadPricingEngine reads the pricing rules from pricing. When pricing is
+// disabled or the rules are invalid, seats keep their static prices.
+func loadPricingEngine() *pricingEngine {
+	config := &pricingConfig{}
+	if err := viper.UnmarshalKey("pricing", config); err != nil {
+		zap.L().Warn("Invalid pricing rules, using static seat prices", zap.Error(err))
+		return &pricingEngine{}
+	}
+
+	if !config.Enabled {
+		return &pricingEngine{}
+	}
+
+	engine := &pricingEngine{
+		enabled:         true,
+		currency:        strings.ToUpper(config.Currency),
+		characteristics: make(map[string]models.Money, len(config.Base.Characteristics)),
+		occupancy:       config.Occupancy,
+		departure:       config.Departure,
+	}
+	if engine.currency == "" {
+		engine.currency = defaultCurrency
+	}
+
+	var err error
+	if engine.base, err = engine.amount(config.Base.Default); err != nil {
+		return invalidPricing(err)
+	}
+	if engine.floor, err = engine.amount(config.Floor); err != nil {
+		return invalidPricing(err)
+	}
+	if engine.ceiling, err = engine.amount(config.Ceiling); err != nil {
+		return invalidPricing(err)
+	}
+
+	// Viper lower-cases map keys, characteristics are matched in upper case
+	for characteristic, value := range config.Base.Characteristics {
+		amount, err := engine.amount(value)
+		if err != nil {
+			return invalidPricing(err)
+		}
+		engine.characteristics[strings.ToUpper(characteristic)] = *amount
+	}
+
+	sort.Slice(engine.occupancy, func(i, j int) bool {
+		return engine.occupancy[i].MinLoadFactor > engine.occupancy[j].MinLoadFactor
+	})
+	sort.Slice(engine.departure, func(i, j int) bool {
+		return engine.departure[i].MaxDays < engine.departure[j].MaxDays
+	})
+
+	return engine
+}
+
+// invalidPricing logs invalid pricing rules and falls back to static seat prices
+func invalidPricing(err error) *pricingEngine {
+	zap.L().Warn("Invalid pricing rules, using static seat prices", zap.Error(err))
+	return &pricingEngine{}
+}
+
+// amount parses an optional configured amount in the pricing currency
+func (e *pricingEngine) amount(value string) (*models.Money, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	amount, err := models.ParseMoney(value, e.currency)
+	if err != nil {
+		return nil, err
+	}
+	return &amount, nil
+}
+
+// price computes the price of a seat, or returns nil for a seat without a
+// price. With pricing disabled this is the seat's static price. Otherwise the
+// base is the highest base of the seat's characteristics, the default base or
+// the static price, in that order; it is multiplied by the occupancy and
+// departure multipliers and kept between the floor and the ceiling. Free of
+// charge seats cost nothing.
+func (e *pricingEngine) price(quote seatQuote) *models.SeatPrice {
+	if !e.enabled {
+		return quote.staticPrice
+	}
+
+	if quote.seat.FreeOfCharge {
+		return dynamicPrice(quote.seat, models.ZeroMoney(e.currency))
+	}
+
+	base := e.basePrice(quote)
+	if base == nil {
+		return nil
+	}
+
+	multiplier := new(big.Rat).SetInt64(1)
+	multiplier.Mul(multiplier, ratFromFloat(e.occupancyMultiplier(quote.loadFactor)))
+	multiplier.Mul(multiplier, ratFromFloat(e.departureMultiplier(quote.untilDeparture)))
+	price := base.Multiply(multiplier)
+
+	// Limits are in the pricing currency and do not apply to static prices in other currencies
+	if price.Currency == e.currency {
+		if e.floor != nil && price.Amount < e.floor.Amount {
+			price = *e.floor
+		}
+		if e.ceiling != nil && price.Amount > e.ceiling.Amount {
+			price = *e.ceiling
+		}
+	}
+
+	return dynamicPrice(quote.seat, price)
+}
+
+// dynamicPrice returns a computed price of a seat
+func dynamicPrice(seat *models.Seat, price models.Money) *models.SeatPrice {
+	return &models.SeatPrice{SeatID: seat.ID, Type: models.SeatPriceTypeDynamic, Price: price}
+}
+
+// basePrice returns the base price of a seat before multipliers
+func (e *pricingEngine) basePrice(quote seatQuote) *models.Money {
+	var base *models.Money
+	for _, characteristic := range quote.characteristics {
+		amount, ok := e.characteristics[strings.ToUpper(strings.TrimSpace(characteristic))]
+		if ok && (base == nil || amount.Amount > base.Amount) {
+			matched := amount
+			base = &matched
+		}
+	}
+
+	switch {
+	case base != nil:
+		return base
+	case e.base != nil:
+		return e.base
+	case quote.staticPrice != nil:
+		return &quote.staticPrice.Price
+	}
+	return nil
+}
+
+// occupancyMultiplier returns the multiplier of the highest occupancy tier reached
+func (e *pricingEngine) occupancyMultiplier(loadFactor float64) float64 {
+	for _, tier := range e.occupancy {
+		if loadFactor >= tier.MinLoadFactor {
+			return tier.Multiplier
+		}
+	}
+	return 1
+}
+
+// departureMultiplier returns the multiplier of the closest departure tier reached
+func (e *pricingEngine) departureMultiplier(untilDeparture time.Duration) float64 {
+	days := untilDeparture.Hours() / 24
+	for _, tier := range e.departure {
+		if days <= tier.MaxDays {
+			return tier.Multiplier
+		}
+	}
+	return 1
+}
+
+// cabinLoadFactors returns the share of seats taken in each cabin, given the
+// cabin of each row. Blocked seats count as taken.
+func cabinLoadFactors(seats []*models.Seat, rowCabins map[string]string) map[string]float64 {
+	total := make(map[string]int)
+	taken := make(map[string]int)
+	for _, seat := range seats {
+		cabinID, ok := rowCabins[seat.RowID]
+		if !ok || seat.StorefrontSlotCode != models.SlotCodeSeat {
+			continue
+		}
+
+		total[cabinID]++
+		if !seat.Available {
+			taken[cabinID]++
+		}
+	}
+
+	loadFactors := make(map[string]float64, len(total))
+	for cabinID, count := range total {
+		loadFactors[cabinID] = float64(taken[cabinID]) / float64(count)
+	}
+	return loadFactors
+}
+
+// ratFromFloat converts a configured multiplier to a rational number
+func ratFromFloat(value float64) *big.Rat {
+	rat := new(big.Rat)
+	rat.SetFloat64(value)
+	return rat
+}
+
+// seatPricer quotes seats of a flight for booking, loading the load factors of
+// the flight's cabins on first use
+type seatPricer struct {
+	engine         *pricingEngine
+	seatRepository repositories.SeatRepository
+	rowRepository  repositories.RowRepository
+	flight         *models.Flight
+	rowCabins      map[string]string
+	loadFactors    map[string]float64
+}
+
+// newSeatPricer creates a seatPricer for a flight using the current pricing rules
+func newSeatPricer(seatRepository repositories.SeatRepository, rowRepository repositories.RowRepository, flight *models.Flight) *seatPricer {
+	return &seatPricer{
+		engine:         loadPricingEngine(),
+		seatRepository: seatRepository,
+		rowRepository:  rowRepository,
+		flight:         flight,
+	}
+}
+
+// price returns the current price of a seat with the given characteristics.
+// Seats without a price are free.
+func (p *seatPricer) price(seat *models.Seat, characteristics []string) (models.Money, error) {
+	staticPrice, err := p.seatRepository.GetPriceBySeatID(seat.ID)
+	if err != nil {
+		zap.L().Error("Failed to get seat price", zap.Error(err), zap.String("seat_id", seat.ID))
+		return models.Money{}, err
+	}
+
+	quote := seatQuote{
+		seat:            seat,
+		characteristics: characteristics,
+		staticPrice:     staticPrice,
+		untilDeparture:  time.Until(p.flight.Departure),
+	}
+
+	if p.engine.enabled {
+		if err := p.loadCabins(); err != nil {
+			return models.Money{}, err
+		}
+		quote.loadFactor = p.loadFactors[p.rowCabins[seat.RowID]]
+	}
+
+	price := p.engine.price(quote)
+	if price == nil {
+		return models.ZeroMoney(defaultCurrency), nil
+	}
+	return price.Price, nil
+}
+
+// loadCabins computes the load factor of each cabin of the flight
+func (p *seatPricer) loadCabins() error {
+	if p.loadFactors != nil {
+		return nil
+	}
+
+	seats, err := p.seatRepository.GetByFlightID(p.flight.ID)
+	if err != nil {
+		zap.L().Error("Failed to get seats by flight ID", zap.Error(err), zap.String("flight_id", p.flight.ID))
+		return err
+	}
+
+	p.rowCabins = make(map[string]string)
+	for _, seat := range seats {
+		if _, ok := p.rowCabins[seat.RowID]; ok {
+			continue
+		}
+
+		row, err := p.rowRepository.GetByID(seat.RowID)
+		if err != nil {
+			zap.L().Error("Failed to get row", zap.Error(err), zap.String("row_id", seat.RowID))
+			return err
+		}
+		if row != nil {
+			p.rowCabins[seat.RowID] = row.CabinID
+		}
+	}
+
+	p.loadFactors = cabinLoadFactors(seats, p.rowCabins)
+	return nil
+}
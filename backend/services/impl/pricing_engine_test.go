@@ -0,0 +1,111 @@
+package impl
+
+import (
+	"testing"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+)
+
+func TestBasePrice(t *testing.T) {
+	defaultBase := models.NewMoney(2000, "MYR")
+	engine := &pricingEngine{
+		enabled:  true,
+		currency: "MYR",
+		base:     &defaultBase,
+		characteristics: map[string]models.Money{
+			models.CharacteristicWindow: models.NewMoney(3000, "MYR"),
+			models.CharacteristicAisle:  models.NewMoney(2500, "MYR"),
+			"E":                         models.NewMoney(5000, "MYR"),
+		},
+	}
+	noDefault := &pricingEngine{
+		enabled:         true,
+		currency:        "MYR",
+		characteristics: engine.characteristics,
+	}
+
+	layout, err := models.ParseCabinLayout("ABC|DEF")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	staticPrice := &models.SeatPrice{Price: models.NewMoney(1500, "USD")}
+
+	tests := []struct {
+		name        string
+		engine      *pricingEngine
+		seat        *models.Seat
+		staticPrice *models.SeatPrice
+		want        *models.Money
+	}{
+		{
+			name:   "window from the layout",
+			engine: engine,
+			seat:   &models.Seat{Code: "3A"},
+			want:   &models.Money{Amount: 3000, Currency: "MYR"},
+		},
+		{
+			name:   "aisle from the layout",
+			engine: engine,
+			seat:   &models.Seat{Code: "3C"},
+			want:   &models.Money{Amount: 2500, Currency: "MYR"},
+		},
+		{
+			name:   "layout window wins over a stored aisle",
+			engine: engine,
+			seat:   &models.Seat{Code: "3F", SeatCharacteristics: "A"},
+			want:   &models.Money{Amount: 3000, Currency: "MYR"},
+		},
+		{
+			name:   "stored window ignored for a middle seat",
+			engine: engine,
+			seat:   &models.Seat{Code: "3B", SeatCharacteristics: "W"},
+			want:   &models.Money{Amount: 2000, Currency: "MYR"},
+		},
+		{
+			name:   "highest matching characteristic",
+			engine: engine,
+			seat:   &models.Seat{Code: "3A", SeatCharacteristics: "e,CH"},
+			want:   &models.Money{Amount: 5000, Currency: "MYR"},
+		},
+		{
+			name:        "default base before the static price",
+			engine:      engine,
+			seat:        &models.Seat{Code: "3E"},
+			staticPrice: staticPrice,
+			want:        &models.Money{Amount: 2000, Currency: "MYR"},
+		},
+		{
+			name:        "static price without a default base",
+			engine:      noDefault,
+			seat:        &models.Seat{Code: "3E"},
+			staticPrice: staticPrice,
+			want:        &models.Money{Amount: 1500, Currency: "USD"},
+		},
+		{
+			name:   "no price",
+			engine: noDefault,
+			seat:   &models.Seat{Code: "3E"},
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.engine.basePrice(seatQuote{
+				seat:            tt.seat,
+				characteristics: layout.SeatCharacteristics(tt.seat),
+				staticPrice:     tt.staticPrice,
+			})
+
+			switch {
+			case tt.want == nil && got != nil:
+				t.Errorf("basePrice = %v, want no price", *got)
+			case tt.want != nil && got == nil:
+				t.Errorf("basePrice = no price, want %v", *tt.want)
+			case tt.want != nil && *got != *tt.want:
+				t.Errorf("basePrice = %v, want %v", *got, *tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package impl
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+	"github.com/evaizee/seat-arrangements/backend/services"
+	"go.uber.org/zap"
+)
+
+// PromotionService is an implementation of the PromotionService interface
+type PromotionService struct {
+	promotionRepository repositories.PromotionRepository
+}
+
+// NewPromotionService creates a new PromotionService
+func NewPromotionService(promotionRepository repositories.PromotionRepository) services.PromotionService {
+	return &PromotionService{
+		promotionRepository: promotionRepository,
+	}
+}
+
+// GetAll retrieves all promotions
+func (s *PromotionService) GetAll() ([]*models.Promotion, error) {
+	promotions, err := s.promotionRepository.GetAll()
+	if err != nil {
+		zap.L().Error("Failed to get promotions", zap.Error(err))
+		return nil, err
+	}
+
+	return promotions, nil
+}
+
+// CreatePromotion validates and stores a new promotion. Codes, routes and
+// characteristics are stored in upper case.
+func (s *PromotionService) CreatePromotion(promotion *models.Promotion) (*models.Promotion, error) {
+	promotion.Code = models.NormalizePromoCode(promotion.Code)
+	promotion.Routes = upperAll(promotion.Routes)
+	promotion.Characteristics = upperAll(promotion.Characteristics)
+	if promotion.Amount != nil {
+		promotion.Amount.Currency = strings.ToUpper(promotion.Amount.Currency)
+	}
+
+	if err := promotion.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", services.ErrInvalidPromotion, err)
+	}
+
+	if err := s.promotionRepository.Create(promotion); err != nil {
+		if errors.Is(err, repositories.ErrDuplicate) {
+			return nil, services.ErrPromotionExists
+		}
+		zap.L().Error("Failed to create promotion", zap.Error(err), zap.String("code", promotion.Code))
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+// SetActive enables or disables a promotion code
+func (s *PromotionService) SetActive(code string, active bool) (*models.Promotion, error) {
+	promotion, err := s.promotionRepository.GetByCode(models.NormalizePromoCode(code))
+	if err != nil {
+		zap.L().Error("Failed to get promotion", zap.Error(err), zap.String("code", code))
+		return nil, err
+	}
+
+	if promotion == nil {
+		return nil, services.ErrPromotionNotFound
+	}
+
+	promotion.Active = active
+	promotion.UpdatedAt = time.Now()
+
+	if err := s.promotionRepository.Update(promotion); err != nil {
+		zap.L().Error("Failed to update promotion", zap.Error(err), zap.String("code", promotion.Code))
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+// upperAll trims and upper-cases each value, dropping empty ones
+func upperAll(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.ToUpper(strings.TrimSpace(value)); value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// findPromotion retrieves the promotion for a code supplied on a flight at a
+// time, checking that it can be used. An empty code finds no promotion.
+func findPromotion(promotionRepository repositories.PromotionRepository, code string, flight *models.Flight, at time.Time) (*models.Promotion, error) {
+	code = models.NormalizePromoCode(code)
+	if code == "" {
+		return nil, nil
+	}
+
+	if promotionRepository == nil {
+		return nil, services.ErrPromotionNotFound
+	}
+
+	promotion, err := promotionRepository.GetByCode(code)
+	if err != nil {
+		zap.L().Error("Failed to get promotion", zap.Error(err), zap.String("code", code))
+		return nil, err
+	}
+
+	switch {
+	case promotion == nil:
+		return nil, services.ErrPromotionNotFound
+	case !promotion.IsValidAt(at):
+		return nil, services.ErrPromotionNotActive
+	case promotion.IsExhausted():
+		return nil, services.ErrPromotionExhausted
+	case !promotion.AppliesToRoute(flight.Origin, flight.Destination):
+		return nil, services.ErrPromotionNotUsable
+	}
+
+	return promotion, nil
+}
+
+// promotionDiscount returns the discount a promotion gives on the base price
+// of a seat with the given characteristics. Percent discounts take a share of the base price; amount discounts
+// are converted to its currency and never exceed it.
+func promotionDiscount(promotion *models.Promotion, characteristics []string, base models.Money, converter *currencyConverter) (models.Money, error) {
+	if promotion == nil || !base.IsPositive() || !promotion.AppliesToSeat(characteristics) {
+		return models.ZeroMoney(base.Currency), nil
+	}
+
+	switch promotion.DiscountType {
+	case models.DiscountTypePercent:
+		percent, err := promotion.PercentValue()
+		if err != nil {
+			zap.L().Error("Invalid promotion", zap.Error(err), zap.String("code", promotion.Code))
+			return models.Money{}, err
+		}
+		return base.Multiply(percent.Quo(percent, big.NewRat(100, 1))), nil
+	case models.DiscountTypeAmount:
+		if promotion.Amount == nil {
+			return models.Money{}, fmt.Errorf("promotion %s has no amount", promotion.Code)
+		}
+		discount, err := converter.convert(*promotion.Amount, base.Currency)
+		if err != nil {
+			return models.Money{}, err
+		}
+		if discount.Amount > base.Amount {
+			return base, nil
+		}
+		return discount, nil
+	}
+
+	return models.Money{}, fmt.Errorf("promotion %s has unknown discount type %q", promotion.Code, promotion.DiscountType)
+}
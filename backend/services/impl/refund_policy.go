@@ -0,0 +1,105 @@
+package impl
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// refundTier refunds Percent of the seat price when a booking is cancelled at
+// least MinHours before departure
+type refundTier struct {
+	MinHours float64 `mapstructure:"min_hours"`
+	Percent  float64 `mapstructure:"percent"`
+}
+
+// refundPolicy holds the refund tiers, optionally overridden per fare class
+type refundPolicy struct {
+	Tiers       []refundTier            `mapstructure:"tiers"`
+	FareClasses map[string][]refundTier `mapstructure:"fare_classes"`
+}
+
+// loadRefundPolicy reads the refund policy from booking.refund. Without tiers
+// seats are refunded in full until departure.
+func loadRefundPolicy() *refundPolicy {
+	policy := &refundPolicy{}
+	if err := viper.UnmarshalKey("booking.refund", policy); err != nil {
+		zap.L().Warn("Invalid refund policy, refunding in full", zap.Error(err))
+		policy = &refundPolicy{}
+	}
+
+	if len(policy.Tiers) == 0 {
+		policy.Tiers = []refundTier{{MinHours: 0, Percent: 100}}
+	}
+	policy.Tiers = sortTiers(policy.Tiers)
+
+	// Viper lower-cases map keys, fare classes are matched in upper case
+	fareClasses := make(map[string][]refundTier, len(policy.FareClasses))
+	for class, tiers := range policy.FareClasses {
+		fareClasses[strings.ToUpper(class)] = sortTiers(tiers)
+	}
+	policy.FareClasses = fareClasses
+
+	return policy
+}
+
+// percent returns the share of the price refunded for a flight's fare class when
+// cancelling hoursBeforeDeparture before departure. The booking class is matched
+// before the cabin class, falling back to the default tiers.
+func (p *refundPolicy) percent(flight *models.Flight, hoursBeforeDeparture float64) float64 {
+	tiers := p.Tiers
+	for _, class := range []string{flight.BookingClass, flight.CabinClass} {
+		if classTiers, ok := p.FareClasses[strings.ToUpper(class)]; ok && class != "" {
+			tiers = classTiers
+			break
+		}
+	}
+
+	for _, tier := range tiers {
+		if hoursBeforeDeparture >= tier.MinHours {
+			return tier.Percent
+		}
+	}
+	return 0
+}
+
+// seatRefund computes the refund of a booked seat. Non-refundable seats get
+// nothing, other seats the policy percentage of the price paid.
+func (p *refundPolicy) seatRefund(bookingSeat *models.BookingSeat, seat *models.Seat, flight *models.Flight, hoursBeforeDeparture float64) *models.SeatRefund {
+	indicator := ""
+	if seat != nil {
+		indicator = strings.ToUpper(seat.RefundIndicator)
+	}
+
+	switch {
+	case indicator == models.RefundIndicatorNonRefundable:
+		return models.NewSeatRefund(bookingSeat, indicator, 0, models.RefundReasonNonRefundable)
+	case bookingSeat.Price.IsZero():
+		return models.NewSeatRefund(bookingSeat, indicator, 0, models.RefundReasonFreeOfCharge)
+	}
+
+	return models.NewSeatRefund(bookingSeat, indicator, p.percent(flight, hoursBeforeDeparture), models.RefundReasonPolicy)
+}
+
+// sortTiers orders tiers from the longest to the shortest time before departure,
+// clamping percentages to 0-100
+func sortTiers(tiers []refundTier) []refundTier {
+	sorted := make([]refundTier, len(tiers))
+	for i, tier := range tiers {
+		if tier.Percent < 0 {
+			tier.Percent = 0
+		}
+		if tier.Percent > 100 {
+			tier.Percent = 100
+		}
+		sorted[i] = tier
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].MinHours > sorted[j].MinHours
+	})
+	return sorted
+}
@@ -0,0 +1,141 @@
+package impl
+
+import (
+	"testing"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/spf13/viper"
+)
+
+func TestRefundPolicyPercent(t *testing.T) {
+	policy := &refundPolicy{
+		Tiers: sortTiers([]refundTier{
+			{MinHours: 24, Percent: 50},
+			{MinHours: 168, Percent: 100},
+			{MinHours: 0, Percent: 0},
+		}),
+		FareClasses: map[string][]refundTier{
+			"J": sortTiers([]refundTier{{MinHours: 0, Percent: 100}}),
+			"ECONOMY": sortTiers([]refundTier{
+				{MinHours: 72, Percent: 75},
+				{MinHours: 2, Percent: 25},
+			}),
+		},
+	}
+
+	tests := []struct {
+		name                 string
+		bookingClass         string
+		cabinClass           string
+		hoursBeforeDeparture float64
+		want                 float64
+	}{
+		{"default tier far out", "", "", 200, 100},
+		{"default tier on the boundary", "", "", 168, 100},
+		{"default tier just below the boundary", "", "", 167.9, 50},
+		{"default middle tier", "", "", 24, 50},
+		{"default last tier", "", "", 1, 0},
+		{"unknown class uses default tiers", "Y", "PREMIUM", 30, 50},
+		{"booking class tiers", "J", "ECONOMY", 1, 100},
+		{"booking class matched case insensitively", "j", "", 1, 100},
+		{"cabin class tiers", "Y", "ECONOMY", 80, 75},
+		{"cabin class matched case insensitively", "", "economy", 3, 25},
+		{"below every tier refunds nothing", "", "ECONOMY", 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flight := &models.Flight{BookingClass: tt.bookingClass, CabinClass: tt.cabinClass}
+			if got := policy.percent(flight, tt.hoursBeforeDeparture); got != tt.want {
+				t.Errorf("percent(%q/%q, %v) = %v, want %v",
+					tt.bookingClass, tt.cabinClass, tt.hoursBeforeDeparture, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortTiers(t *testing.T) {
+	got := sortTiers([]refundTier{
+		{MinHours: 0, Percent: -10},
+		{MinHours: 72, Percent: 150},
+		{MinHours: 24, Percent: 40},
+	})
+
+	want := []refundTier{
+		{MinHours: 72, Percent: 100},
+		{MinHours: 24, Percent: 40},
+		{MinHours: 0, Percent: 0},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("sortTiers returned %d tiers, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("tier %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestLoadRefundPolicy(t *testing.T) {
+	defer viper.Reset()
+
+	viper.Reset()
+	policy := loadRefundPolicy()
+	if len(policy.Tiers) != 1 || policy.Tiers[0] != (refundTier{MinHours: 0, Percent: 100}) {
+		t.Errorf("default tiers = %+v, want a full refund until departure", policy.Tiers)
+	}
+
+	viper.Set("booking.refund", map[string]interface{}{
+		"tiers": []map[string]interface{}{
+			{"min_hours": 24, "percent": 50},
+			{"min_hours": 72, "percent": 90},
+		},
+		"fare_classes": map[string]interface{}{
+			"business": []map[string]interface{}{
+				{"min_hours": 0, "percent": 100},
+			},
+		},
+	})
+
+	policy = loadRefundPolicy()
+	if len(policy.Tiers) != 2 || policy.Tiers[0].MinHours != 72 || policy.Tiers[1].MinHours != 24 {
+		t.Errorf("tiers = %+v, want them ordered from 72 to 24 hours", policy.Tiers)
+	}
+	if _, ok := policy.FareClasses["BUSINESS"]; !ok {
+		t.Errorf("fare classes = %+v, want BUSINESS in upper case", policy.FareClasses)
+	}
+}
+
+func TestSeatRefund(t *testing.T) {
+	policy := &refundPolicy{Tiers: []refundTier{{MinHours: 24, Percent: 50}}}
+	flight := &models.Flight{}
+
+	tests := []struct {
+		name        string
+		price       models.Money
+		indicator   string
+		hours       float64
+		wantPercent float64
+		wantAmount  int64
+		wantReason  string
+	}{
+		{"policy tier", models.NewMoney(6501, "MYR"), models.RefundIndicatorRefundable, 48, 50, 3251, models.RefundReasonPolicy},
+		{"below every tier", models.NewMoney(6500, "MYR"), "", 2, 0, 0, models.RefundReasonPolicy},
+		{"non-refundable", models.NewMoney(6500, "MYR"), "n", 48, 0, 0, models.RefundReasonNonRefundable},
+		{"free of charge", models.ZeroMoney("MYR"), models.RefundIndicatorRefundable, 48, 0, 0, models.RefundReasonFreeOfCharge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bookingSeat := &models.BookingSeat{Price: tt.price}
+			seat := &models.Seat{RefundIndicator: tt.indicator}
+
+			refund := policy.seatRefund(bookingSeat, seat, flight, tt.hours)
+			if refund.Percent != tt.wantPercent || refund.Amount.Amount != tt.wantAmount || refund.Reason != tt.wantReason {
+				t.Errorf("seatRefund = %v%% %v (%s), want %v%% %d (%s)",
+					refund.Percent, refund.Amount, refund.Reason, tt.wantPercent, tt.wantAmount, tt.wantReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package impl
+
+import (
+	"strings"
+	"time"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"go.uber.org/zap"
+)
+
+// GetBundleOffers prices the bundles on sale for a passenger on a flight from
+// the passenger's seat map. Each offer lists the available seats the bundle is
+// offered with, priced as the seat's total from the seat map plus the bundle's
+// products less the bundle discount, and the cheapest of them. Prices are in
+// currency, or in the default currency if it is empty.
+func (s *SeatService) GetBundleOffers(flightID, passengerID, currency string) (*models.BundleOffers, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		currency = defaultCurrency
+	}
+
+	seatMap, err := s.GetSeatMap(flightID, passengerID, "", currency, "")
+	if err != nil {
+		return nil, err
+	}
+
+	offers := &models.BundleOffers{
+		FlightID: flightID,
+		Currency: currency,
+		Offers:   []*models.BundleOffer{},
+	}
+
+	var seats []models.SeatMapItem
+	for _, part := range seatMap.SeatsItineraryParts {
+		for _, segment := range part.SegmentSeatMaps {
+			for _, passengerSeatMap := range segment.PassengerSeatMaps {
+				offers.Passenger = passengerSeatMap.Passenger
+				seats = append(seats, availableSeats(passengerSeatMap.SeatMap)...)
+			}
+		}
+	}
+
+	if s.ancillaryRepository == nil {
+		return offers, nil
+	}
+
+	bundles, err := s.ancillaryRepository.GetBundles()
+	if err != nil {
+		zap.L().Error("Failed to get bundles", zap.Error(err))
+		return nil, err
+	}
+
+	converter := newCurrencyConverter(s.exchangeRateRepository, time.Now())
+	for _, bundle := range bundles {
+		if !bundle.Active {
+			continue
+		}
+
+		offer, err := bundleOffer(bundle, seats, currency, converter)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(offer.Seats) > 0 {
+			offers.Offers = append(offers.Offers, offer)
+		}
+	}
+
+	return offers, nil
+}
+
+// bundleOffer prices a bundle with each of the seats it is offered with
+func bundleOffer(bundle *models.Bundle, seats []models.SeatMapItem, currency string, converter *currencyConverter) (*models.BundleOffer, error) {
+	offer := &models.BundleOffer{
+		Bundle:   bundle,
+		Products: models.ZeroMoney(currency),
+		Discount: models.ZeroMoney(currency),
+		Seats:    []*models.BundleSeatOffer{},
+	}
+
+	for _, product := range bundle.Products {
+		price, err := converter.convert(product.Price, currency)
+		if err != nil {
+			return nil, err
+		}
+
+		discount, err := bundleDiscount(bundle, price)
+		if err != nil {
+			return nil, err
+		}
+
+		offer.Products = offer.Products.Add(price)
+		offer.Discount = offer.Discount.Add(discount)
+	}
+
+	products := offer.Products.Sub(offer.Discount)
+	for _, seat := range seats {
+		if !bundle.AppliesToSeat(seat.SlotCharacteristics) {
+			continue
+		}
+
+		seatTotal := seatMapTotal(seat, currency)
+		seatOffer := &models.BundleSeatOffer{
+			Code:      seat.Code,
+			SeatTotal: seatTotal,
+			Total:     seatTotal.Add(products),
+		}
+		offer.Seats = append(offer.Seats, seatOffer)
+
+		if offer.From == nil || seatOffer.Total.Amount < offer.From.Amount {
+			from := seatOffer.Total
+			offer.From = &from
+		}
+	}
+
+	return offer, nil
+}
+
+// availableSeats returns the seats of a seat map that can be selected
+func availableSeats(seatMap models.SeatMap) []models.SeatMapItem {
+	seats := []models.SeatMapItem{}
+	for _, cabin := range seatMap.Cabins {
+		for _, row := range cabin.SeatRows {
+			for _, seat := range row.Seats {
+				if seat.StorefrontSlotCode == models.SlotCodeSeat && seat.Available {
+					seats = append(seats, seat)
+				}
+			}
+		}
+	}
+	return seats
+}
+
+// seatMapTotal returns the offered total of a seat map slot, which is free when it has no price
+func seatMapTotal(seat models.SeatMapItem, currency string) models.Money {
+	if seat.Total == nil || len(seat.Total.Alternatives) == 0 || len(seat.Total.Alternatives[0]) == 0 {
+		return models.ZeroMoney(currency)
+	}
+	return seat.Total.Alternatives[0][0]
+}
@@ -3,23 +3,31 @@ package impl
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/evaizee/seat-arrangements/backend/models"
 	"github.com/evaizee/seat-arrangements/backend/repositories"
 	"github.com/evaizee/seat-arrangements/backend/services"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
 // SeatService is an implementation of the SeatService interface
 type SeatService struct {
-	seatRepository      repositories.SeatRepository
-	flightRepository    repositories.FlightRepository
-	aircraftRepository  repositories.AircraftRepository
-	cabinRepository     repositories.CabinRepository
-	passengerRepository repositories.PassengerRepository
-	rowRepository       repositories.RowRepository
+	seatRepository         repositories.SeatRepository
+	flightRepository       repositories.FlightRepository
+	aircraftRepository     repositories.AircraftRepository
+	cabinRepository        repositories.CabinRepository
+	passengerRepository    repositories.PassengerRepository
+	rowRepository          repositories.RowRepository
+	facilityRepository     repositories.FacilityRepository
+	exchangeRateRepository repositories.ExchangeRateRepository
+	airportRepository      repositories.AirportRepository
+	taxRuleRepository      repositories.TaxRuleRepository
+	promotionRepository    repositories.PromotionRepository
+	ancillaryRepository    repositories.AncillaryRepository
 }
 
 // NewSeatService creates a new SeatService
@@ -45,6 +53,18 @@ func NewSeatService(
 			service.passengerRepository = r
 		case repositories.RowRepository:
 			service.rowRepository = r
+		case repositories.FacilityRepository:
+			service.facilityRepository = r
+		case repositories.ExchangeRateRepository:
+			service.exchangeRateRepository = r
+		case repositories.AirportRepository:
+			service.airportRepository = r
+		case repositories.TaxRuleRepository:
+			service.taxRuleRepository = r
+		case repositories.PromotionRepository:
+			service.promotionRepository = r
+		case repositories.AncillaryRepository:
+			service.ancillaryRepository = r
 		}
 	}
 
@@ -53,6 +73,10 @@ func NewSeatService(
 
 // GetByID retrieves a seat with its price by ID
 func (s *SeatService) GetByID(id string) (*models.SeatWithPrice, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, services.ErrSeatNotFound
+	}
+
 	// Get the seat
 	seat, err := s.seatRepository.GetByID(id)
 	if err != nil {
@@ -61,7 +85,7 @@ func (s *SeatService) GetByID(id string) (*models.SeatWithPrice, error) {
 	}
 
 	if seat == nil {
-		return nil, errors.New("seat not found")
+		return nil, services.ErrSeatNotFound
 	}
 
 	// Get the seat price
@@ -80,6 +104,10 @@ func (s *SeatService) GetByID(id string) (*models.SeatWithPrice, error) {
 
 // GetByCabinID retrieves seats with their prices by cabin ID
 func (s *SeatService) GetByRowID(rowID string) ([]*models.SeatWithPrice, error) {
+	if _, err := uuid.Parse(rowID); err != nil {
+		return nil, services.ErrRowNotFound
+	}
+
 	// Get the seats
 	seats, err := s.seatRepository.GetByRowID(rowID)
 	if err != nil {
@@ -133,6 +161,10 @@ func (s *SeatService) GetByFlightID(flightID string) ([]*models.SeatWithPrice, e
 
 // UpdateAvailability updates the availability of a seat
 func (s *SeatService) UpdateAvailability(seatID string, available bool) error {
+	if _, err := uuid.Parse(seatID); err != nil {
+		return services.ErrSeatNotFound
+	}
+
 	// Get the seat
 	seat, err := s.seatRepository.GetByID(seatID)
 	if err != nil {
@@ -141,7 +173,7 @@ func (s *SeatService) UpdateAvailability(seatID string, available bool) error {
 	}
 
 	if seat == nil {
-		return errors.New("seat not found")
+		return services.ErrSeatNotFound
 	}
 
 	// Update the seat
@@ -158,6 +190,269 @@ func (s *SeatService) UpdateAvailability(seatID string, available bool) error {
 	return nil
 }
 
+// UpdateRowAvailability blocks or releases every seat in a row
+func (s *SeatService) UpdateRowAvailability(rowID string, available bool) error {
+	if _, err := uuid.Parse(rowID); err != nil {
+		return services.ErrRowNotFound
+	}
+
+	seats, err := s.seatRepository.GetByRowID(rowID)
+	if err != nil {
+		zap.L().Error("Failed to get seats by row ID", zap.Error(err), zap.String("row_id", rowID))
+		return err
+	}
+
+	if len(seats) == 0 {
+		return services.ErrRowNotFound
+	}
+
+	for _, seat := range seats {
+		// Skip non-seat slots such as blanks and aisles
+		if seat.Code == "" {
+			continue
+		}
+
+		seat.Available = available
+		seat.UpdatedAt = time.Now()
+
+		err = s.seatRepository.Update(seat)
+		if err != nil {
+			zap.L().Error("Failed to update seat", zap.Error(err), zap.String("seat_id", seat.ID))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CreateSeat validates and adds a seat or layout slot to a row
+func (s *SeatService) CreateSeat(seat *models.Seat) (*models.Seat, error) {
+	cabin, row, err := s.getRowLayout(seat.RowID)
+	if err != nil {
+		return nil, err
+	}
+
+	seat.ID = uuid.New().String()
+	seat.CreatedAt = time.Now()
+	seat.UpdatedAt = seat.CreatedAt
+	if err := s.validateSeat(cabin, row, seat); err != nil {
+		return nil, err
+	}
+
+	if err := s.seatRepository.Create(seat); err != nil {
+		zap.L().Error("Failed to create seat", zap.Error(err), zap.String("row_id", seat.RowID))
+		return nil, err
+	}
+
+	return seat, nil
+}
+
+// UpdateSeat validates and updates a seat, which may move to another row
+func (s *SeatService) UpdateSeat(seat *models.Seat) (*models.Seat, error) {
+	if _, err := uuid.Parse(seat.ID); err != nil {
+		return nil, services.ErrSeatNotFound
+	}
+
+	existing, err := s.seatRepository.GetByID(seat.ID)
+	if err != nil {
+		zap.L().Error("Failed to get seat", zap.Error(err), zap.String("seat_id", seat.ID))
+		return nil, err
+	}
+
+	if existing == nil {
+		return nil, services.ErrSeatNotFound
+	}
+
+	cabin, row, err := s.getRowLayout(seat.RowID)
+	if err != nil {
+		return nil, err
+	}
+
+	seat.CreatedAt = existing.CreatedAt
+	seat.UpdatedAt = time.Now()
+	if err := s.validateSeat(cabin, row, seat); err != nil {
+		return nil, err
+	}
+
+	if err := s.seatRepository.Update(seat); err != nil {
+		zap.L().Error("Failed to update seat", zap.Error(err), zap.String("seat_id", seat.ID))
+		return nil, err
+	}
+
+	return seat, nil
+}
+
+// DeleteSeat deletes a seat together with its price
+func (s *SeatService) DeleteSeat(id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return services.ErrSeatNotFound
+	}
+
+	seat, err := s.seatRepository.GetByID(id)
+	if err != nil {
+		zap.L().Error("Failed to get seat", zap.Error(err), zap.String("seat_id", id))
+		return err
+	}
+
+	if seat == nil {
+		return services.ErrSeatNotFound
+	}
+
+	price, err := s.seatRepository.GetPriceBySeatID(id)
+	if err != nil {
+		zap.L().Error("Failed to get seat price", zap.Error(err), zap.String("seat_id", id))
+		return err
+	}
+
+	if price != nil {
+		if err := s.seatRepository.DeletePrice(price.ID); err != nil {
+			zap.L().Error("Failed to delete seat price", zap.Error(err), zap.String("seat_id", id))
+			return err
+		}
+	}
+
+	if err := s.seatRepository.Delete(id); err != nil {
+		if errors.Is(err, repositories.ErrReferenced) {
+			return services.ErrInUse
+		}
+		zap.L().Error("Failed to delete seat", zap.Error(err), zap.String("seat_id", id))
+		return err
+	}
+
+	return nil
+}
+
+// SetPrice creates or replaces the price of a seat. The amount is a decimal in
+// the currency's major units and is rounded to its minor units.
+func (s *SeatService) SetPrice(seatID, priceType, amount, currency string) (*models.SeatPrice, error) {
+	if _, err := uuid.Parse(seatID); err != nil {
+		return nil, services.ErrSeatNotFound
+	}
+
+	seat, err := s.seatRepository.GetByID(seatID)
+	if err != nil {
+		zap.L().Error("Failed to get seat", zap.Error(err), zap.String("seat_id", seatID))
+		return nil, err
+	}
+
+	if seat == nil {
+		return nil, services.ErrSeatNotFound
+	}
+
+	priceType = strings.ToLower(strings.TrimSpace(priceType))
+	if priceType == "" {
+		priceType = models.SeatPriceTypeStandard
+	}
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+
+	if !models.IsValidCurrency(currency) {
+		return nil, fmt.Errorf("%w: currency must be a 3-letter ISO code", services.ErrInvalidLayout)
+	}
+
+	money, err := models.ParseMoney(amount, currency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: price amount must be a decimal number", services.ErrInvalidLayout)
+	}
+	if money.Amount < 0 {
+		return nil, fmt.Errorf("%w: price amount must not be negative", services.ErrInvalidLayout)
+	}
+
+	price, err := s.seatRepository.GetPriceBySeatID(seatID)
+	if err != nil {
+		zap.L().Error("Failed to get seat price", zap.Error(err), zap.String("seat_id", seatID))
+		return nil, err
+	}
+
+	if price == nil {
+		price = models.NewSeatPrice(seatID, priceType, money)
+		err = s.seatRepository.CreatePrice(price)
+	} else {
+		price.Type = priceType
+		price.Price = money
+		err = s.seatRepository.UpdatePrice(price)
+	}
+
+	if err != nil {
+		zap.L().Error("Failed to save seat price", zap.Error(err), zap.String("seat_id", seatID))
+		return nil, err
+	}
+
+	return price, nil
+}
+
+// getRowLayout loads a row and the cabin it belongs to
+func (s *SeatService) getRowLayout(rowID string) (*models.Cabin, *models.SeatRow, error) {
+	if s.rowRepository == nil || s.cabinRepository == nil {
+		return nil, nil, errors.New("row and cabin repositories are required to manage seats")
+	}
+
+	if _, err := uuid.Parse(rowID); err != nil {
+		return nil, nil, services.ErrRowNotFound
+	}
+
+	row, err := s.rowRepository.GetByID(rowID)
+	if err != nil {
+		zap.L().Error("Failed to get row", zap.Error(err), zap.String("row_id", rowID))
+		return nil, nil, err
+	}
+
+	if row == nil {
+		return nil, nil, services.ErrRowNotFound
+	}
+
+	cabin, err := s.cabinRepository.GetByID(row.CabinID)
+	if err != nil {
+		zap.L().Error("Failed to get cabin", zap.Error(err), zap.String("cabin_id", row.CabinID))
+		return nil, nil, err
+	}
+
+	if cabin == nil {
+		return nil, nil, services.ErrCabinNotFound
+	}
+
+	return cabin, row, nil
+}
+
+// validateSeat checks the seat's slot and code against the row layout and
+// the row's other seats, and copies the segment from the cabin
+func (s *SeatService) validateSeat(cabin *models.Cabin, row *models.SeatRow, seat *models.Seat) error {
+	seat.StorefrontSlotCode = strings.ToUpper(strings.TrimSpace(seat.StorefrontSlotCode))
+	if seat.StorefrontSlotCode == "" {
+		seat.StorefrontSlotCode = models.SlotCodeSeat
+	}
+	seat.Code = strings.ToUpper(strings.TrimSpace(seat.Code))
+	seat.SegmentID = cabin.SegmentID
+
+	switch seat.StorefrontSlotCode {
+	case models.SlotCodeSeat:
+		if err := validateSeatCode(cabin, row, seat.Code); err != nil {
+			return err
+		}
+	case models.SlotCodeBlank, models.SlotCodeAisle:
+		if seat.Code != "" {
+			return fmt.Errorf("%w: %s slots must not have a seat code", services.ErrInvalidLayout, seat.StorefrontSlotCode)
+		}
+		seat.Available = false
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown slot code %q", services.ErrInvalidLayout, seat.StorefrontSlotCode)
+	}
+
+	seats, err := s.seatRepository.GetByRowID(row.ID)
+	if err != nil {
+		zap.L().Error("Failed to get seats by row ID", zap.Error(err), zap.String("row_id", row.ID))
+		return err
+	}
+
+	for _, other := range seats {
+		if other.ID != seat.ID && other.Code == seat.Code {
+			return fmt.Errorf("%w: seat %s already exists in row %d", services.ErrInvalidLayout, seat.Code, row.RowNumber)
+		}
+	}
+
+	return nil
+}
+
 // getMockSeatMap provides sample seat map data for development
 func (s *SeatService) getMockSeatMap(flightID string, passengerID string) (*models.SeatMapResponse, error) {
 	// Get passenger info if available
@@ -186,7 +481,7 @@ func (s *SeatService) getMockSeatMap(flightID string, passengerID string) (*mode
 									Aircraft:           "B737",
 									Cabins: []models.CabinMap{
 										{
-											Deck:        "MAIN",
+											Deck:        models.DeckMain,
 											SeatColumns: []string{"A", "B", "C", "D", "E", "F"},
 											FirstRow:    1,
 											LastRow:     30,
@@ -307,10 +602,24 @@ func generateMockSeatRows(firstRow, lastRow int) []models.SeatMapRow {
 	return rows
 }
 
-// GetSeatMap generates a seat map for a flight and passenger
-func (s *SeatService) GetSeatMap(flightID string, passengerID string) (*models.SeatMapResponse, error) {
+// GetSeatMap generates a seat map for a flight and passenger. Cabins are ordered
+// by deck and first row; a non-empty deck limits the map to that deck. Seats
+// are quoted by the pricing engine and their prices converted to currency at
+// today's rates unless it is empty, with the taxes and fees of the route. A
+// promotion code discounts the seats it covers.
+func (s *SeatService) GetSeatMap(flightID, passengerID, deck, currency, promoCode string) (*models.SeatMapResponse, error) {
+	deck = strings.ToUpper(strings.TrimSpace(deck))
+	if deck != "" && !models.IsValidDeck(deck) {
+		return nil, services.ErrInvalidDeck
+	}
+
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency != "" && !models.IsValidCurrency(currency) {
+		return nil, services.ErrInvalidCurrency
+	}
+
 	// Check if we have all required repositories
-	if s.flightRepository == nil || s.aircraftRepository == nil || s.cabinRepository == nil {
+	if s.flightRepository == nil || s.aircraftRepository == nil || s.cabinRepository == nil || s.rowRepository == nil {
 		// If repositories are missing, return mock data for development
 		zap.L().Warn("Using mock data for seat map as some repositories are not initialized")
 		return s.getMockSeatMap(flightID, passengerID)
@@ -318,6 +627,10 @@ func (s *SeatService) GetSeatMap(flightID string, passengerID string) (*models.S
 		zap.L().Warn("Using real data for seat map, cool")
 	}
 
+	if _, err := uuid.Parse(flightID); err != nil {
+		return nil, services.ErrFlightNotFound
+	}
+
 	// Get flight details
 	flight, err := s.flightRepository.GetByID(flightID)
 	if err != nil {
@@ -326,7 +639,7 @@ func (s *SeatService) GetSeatMap(flightID string, passengerID string) (*models.S
 	}
 
 	if flight == nil {
-		return nil, errors.New("flight not found")
+		return nil, services.ErrFlightNotFound
 	}
 
 	// Get aircraft details using the equipment field from flight
@@ -337,19 +650,57 @@ func (s *SeatService) GetSeatMap(flightID string, passengerID string) (*models.S
 	}
 
 	if aircraft == nil {
-		return nil, errors.New("aircraft not found for flight")
+		return nil, services.ErrAircraftNotFound
 	}
 
 	// Get cabins for this flight
-	cabins, err := s.cabinRepository.GetBySegmentID(flightID)
+	segmentCabins, err := s.cabinRepository.GetBySegmentID(flightID)
 	if err != nil {
 		zap.L().Error("Failed to get cabins", zap.Error(err), zap.String("flight_id", flightID))
 		return nil, err
 	}
 
-	// Get seat rows for each cabin
-	if s.rowRepository == nil {
-		zap.L().Warn("Row repository not initialized, skipping row retrieval")
+	// Only the cabins of the requested deck are rendered
+	cabins := make([]*models.Cabin, 0, len(segmentCabins))
+	rendered := make(map[string]bool, len(segmentCabins))
+	for _, cabin := range segmentCabins {
+		if deck == "" || cabin.Deck == deck {
+			cabins = append(cabins, cabin)
+			rendered[cabin.ID] = true
+		}
+	}
+	models.SortCabins(cabins)
+
+	// Seats are priced and discounted by their characteristics as the cabin layout shows them
+	layouts := make(map[string]*models.CabinLayout, len(cabins))
+	for _, cabin := range cabins {
+		layout, err := cabin.Layout()
+		if err != nil {
+			zap.L().Error("Invalid cabin layout", zap.Error(err), zap.String("cabin_id", cabin.ID))
+			return nil, fmt.Errorf("%w: cabin %s: %v", services.ErrInvalidLayout, cabin.ID, err)
+		}
+		layouts[cabin.ID] = layout
+	}
+
+	// Get seat rows for every cabin of the flight, indexed by row ID so seats can
+	// be placed by their row relation even when a deck is filtered out
+	rowsByCabin := make(map[string][]*models.SeatRow, len(segmentCabins))
+	rowIndex := make(map[string]*models.SeatRow)
+	for _, cabin := range segmentCabins {
+		cabinRows, err := s.rowRepository.GetByCabinID(cabin.ID)
+		if err != nil {
+			zap.L().Error("Failed to get rows for cabin", zap.Error(err), zap.String("cabin_id", cabin.ID))
+			return nil, err
+		}
+
+		sort.Slice(cabinRows, func(i, j int) bool {
+			return cabinRows[i].RowNumber < cabinRows[j].RowNumber
+		})
+
+		rowsByCabin[cabin.ID] = cabinRows
+		for _, row := range cabinRows {
+			rowIndex[row.ID] = row
+		}
 	}
 
 	// Get all seats for this flight
@@ -359,6 +710,78 @@ func (s *SeatService) GetSeatMap(flightID string, passengerID string) (*models.S
 		return nil, err
 	}
 
+	// Quote each seat from the load factor of its cabin and the time left until departure
+	rowCabins := make(map[string]string, len(rowIndex))
+	for rowID, row := range rowIndex {
+		rowCabins[rowID] = row.CabinID
+	}
+	flightSeats := make([]*models.Seat, 0, len(seats))
+	for _, seat := range seats {
+		flightSeats = append(flightSeats, seat.Seat)
+	}
+	loadFactors := cabinLoadFactors(flightSeats, rowCabins)
+	engine := loadPricingEngine()
+	untilDeparture := time.Until(flight.Departure)
+
+	// Group seats by row, refusing seats that do not belong to a row of this flight's cabins
+	converter := newCurrencyConverter(s.exchangeRateRepository, time.Now())
+	taxes, err := newTaxCalculator(s.airportRepository, s.taxRuleRepository, flight, converter)
+	if err != nil {
+		return nil, err
+	}
+
+	promotion, err := findPromotion(s.promotionRepository, promoCode, flight, converter.at)
+	if err != nil {
+		return nil, err
+	}
+
+	seatsByRow := make(map[string][]*models.SeatWithPrice)
+	for _, seat := range seats {
+		if _, ok := rowIndex[seat.Seat.RowID]; !ok {
+			zap.L().Error("Seat does not belong to any cabin row of the flight",
+				zap.String("seat_id", seat.Seat.ID),
+				zap.String("row_id", seat.Seat.RowID),
+				zap.String("flight_id", flightID))
+			return nil, fmt.Errorf("%w: seat %s references row %s", services.ErrOrphanedSeat, seat.Seat.ID, seat.Seat.RowID)
+		}
+
+		if !rendered[rowCabins[seat.Seat.RowID]] {
+			continue
+		}
+
+		characteristics := layouts[rowCabins[seat.Seat.RowID]].SeatCharacteristics(seat.Seat)
+		seat = &models.SeatWithPrice{
+			Seat: seat.Seat,
+			Price: engine.price(seatQuote{
+				seat:            seat.Seat,
+				characteristics: characteristics,
+				staticPrice:     seat.Price,
+				loadFactor:      loadFactors[rowCabins[seat.Seat.RowID]],
+				untilDeparture:  untilDeparture,
+			}),
+		}
+
+		if currency != "" && seat.Price != nil {
+			seat, err = s.convertSeatPrice(seat, currency, converter)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if seat.Price != nil {
+			discount, err := promotionDiscount(promotion, characteristics, seat.Price.Price, converter)
+			if err != nil {
+				return nil, err
+			}
+
+			seat.Breakdown, err = taxes.breakdown(seat.Price.Price, discount)
+			if err != nil {
+				return nil, err
+			}
+		}
+		seatsByRow[seat.Seat.RowID] = append(seatsByRow[seat.Seat.RowID], seat)
+	}
+
 	// Get passenger details if provided
 	var passenger *models.Passenger
 	if passengerID != "" {
@@ -435,156 +858,309 @@ func (s *SeatService) GetSeatMap(flightID string, passengerID string) (*models.S
 
 	// Process each cabin
 	for _, cabin := range cabins {
-		// Parse seat columns from the stored string
-		columns := strings.Split(cabin.SeatColumns, ",")
-
-		// Create cabin map
-		cabinMap := models.CabinMap{
-			Deck:        cabin.Deck,
-			SeatColumns: append([]string{"LEFT_SIDE"}, append(columns, "RIGHT_SIDE")...),
-			SeatRows:    []models.SeatMapRow{},
-			FirstRow:    cabin.FirstRow,
-			LastRow:     cabin.LastRow,
-		}
-
-		// Get rows for this cabin
-		var cabinRows []*models.SeatRow
-		if s.rowRepository != nil {
-			cabinRows, err = s.rowRepository.GetByCabinID(cabin.ID)
+		var facilities []*models.CabinFacility
+		if s.facilityRepository != nil {
+			facilities, err = s.facilityRepository.GetByCabinID(cabin.ID)
 			if err != nil {
-				zap.L().Error("Failed to get rows for cabin", zap.Error(err), zap.String("cabin_id", cabin.ID))
-				// Continue with empty rows
-				cabinRows = []*models.SeatRow{}
+				zap.L().Error("Failed to get facilities for cabin", zap.Error(err), zap.String("cabin_id", cabin.ID))
+				return nil, err
 			}
 		}
 
-		// Group seats by row
-		seatsByRow := make(map[int][]models.SeatWithPrice)
-		for _, seat := range seats {
-			// Check if this seat belongs to a row in this cabin
-			belongsToCabin := false
-			for _, row := range cabinRows {
-				if seat.Seat.RowID == row.ID {
-					belongsToCabin = true
-					break
-				}
-			}
+		cabinMap, err := buildCabinMap(cabin, rowsByCabin[cabin.ID], seatsByRow, facilities)
+		if err != nil {
+			zap.L().Error("Failed to build cabin map", zap.Error(err), zap.String("cabin_id", cabin.ID))
+			return nil, err
+		}
+		passengerSeatMap.SeatMap.Cabins = append(passengerSeatMap.SeatMap.Cabins, cabinMap)
+	}
+
+	// Add the passenger seat map to the response
+	seatMapResponse.SeatsItineraryParts[0].SegmentSeatMaps[0].PassengerSeatMaps = append(
+		seatMapResponse.SeatsItineraryParts[0].SegmentSeatMaps[0].PassengerSeatMaps,
+		passengerSeatMap,
+	)
+
+	return seatMapResponse, nil
+}
 
-			if belongsToCabin || len(cabinRows) == 0 { // If no rows found, include all seats as fallback
-				// Extract row number from seat code (e.g., "4A" -> 4)
-				rowNum := 0
-				fmt.Sscanf(seat.Seat.Code, "%d", &rowNum)
+// buildCabinMap lays out the rows of a cabin that exist in the database, so
+// skipped row numbers stay absent. Column groups are separated by aisle slots,
+// and each row only offers the columns listed in its seat codes; the cabin's
+// other columns are rendered as blanks. Space facilities are rendered as
+// facility rows (row number 0) in front of or behind their row, and row
+// markers such as exits and wings are added to the row's side slots. Facilities
+// on a row number without seats are rendered too, with a seatless row carrying
+// the row's markers.
+func buildCabinMap(
+	cabin *models.Cabin,
+	rows []*models.SeatRow,
+	seatsByRow map[string][]*models.SeatWithPrice,
+	facilities []*models.CabinFacility,
+) (models.CabinMap, error) {
+	layout, err := cabin.Layout()
+	if err != nil {
+		return models.CabinMap{}, fmt.Errorf("%w: cabin %s: %v", services.ErrInvalidLayout, cabin.ID, err)
+	}
+
+	cabinMap := models.CabinMap{
+		Deck:        cabin.Deck,
+		Facilities:  []string{},
+		SeatColumns: layout.SeatColumns(),
+		SeatRows:    []models.SeatMapRow{},
+		FirstRow:    cabin.FirstRow,
+		LastRow:     cabin.LastRow,
+	}
 
-				if _, ok := seatsByRow[rowNum]; !ok {
-					seatsByRow[rowNum] = []models.SeatWithPrice{}
-				}
-				seatsByRow[rowNum] = append(seatsByRow[rowNum], *seat)
+	// Index rows and facilities by row number: markers decorate the row, space
+	// facilities get their own rows
+	rowsByNumber := make(map[int]*models.SeatRow)
+	rowNumbers := []int{}
+	addRowNumber := func(rowNumber int) {
+		for _, number := range rowNumbers {
+			if number == rowNumber {
+				return
 			}
 		}
+		rowNumbers = append(rowNumbers, rowNumber)
+	}
+	for _, row := range rows {
+		rowsByNumber[row.RowNumber] = row
+		addRowNumber(row.RowNumber)
+	}
+
+	markers := make(map[int][]string)
+	spaces := make(map[string][]*models.CabinFacility)
+	for _, facility := range facilities {
+		addRowNumber(facility.RowNumber)
+		if facility.IsRowMarker() {
+			markers[facility.RowNumber] = append(markers[facility.RowNumber], facility.Type)
+			continue
+		}
+		if facility.RowNumber == cabin.FirstRow && facility.Position == models.FacilityPositionFront {
+			cabinMap.Facilities = append(cabinMap.Facilities, facility.Type)
+		}
+		key := fmt.Sprintf("%d/%s", facility.RowNumber, facility.Position)
+		spaces[key] = append(spaces[key], facility)
+	}
+	sort.Ints(rowNumbers)
+
+	for _, rowNumber := range rowNumbers {
+		if front, ok := spaces[fmt.Sprintf("%d/%s", rowNumber, models.FacilityPositionFront)]; ok {
+			cabinMap.SeatRows = append(cabinMap.SeatRows, facilityRow(layout, front))
+		}
 
-		// Create seat rows
-		for rowNum := cabin.FirstRow; rowNum <= cabin.LastRow; rowNum++ {
-			seatRow := models.SeatMapRow{
-				RowNumber: rowNum,
-				SeatCodes: []string{},
-				Seats:     []models.SeatMapItem{},
+		left, right := sideCharacteristics(markers[rowNumber])
+		row, ok := rowsByNumber[rowNumber]
+		if ok {
+			cabinMap.SeatRows = append(cabinMap.SeatRows, seatMapRow(layout, row, seatsByRow[row.ID], left, right))
+		} else if len(markers[rowNumber]) > 0 {
+			cabinMap.SeatRows = append(cabinMap.SeatRows, seatMapRow(layout, &models.SeatRow{RowNumber: rowNumber}, nil, left, right))
+		}
+
+		if rear, ok := spaces[fmt.Sprintf("%d/%s", rowNumber, models.FacilityPositionRear)]; ok {
+			cabinMap.SeatRows = append(cabinMap.SeatRows, facilityRow(layout, rear))
+		}
+	}
+
+	return cabinMap, nil
+}
+
+// seatMapRow renders a seat row with the given side slot characteristics.
+// Columns the row has no seat in are rendered as blanks.
+func seatMapRow(layout *models.CabinLayout, row *models.SeatRow, seats []*models.SeatWithPrice, left, right []string) models.SeatMapRow {
+	rowColumns := seatRowColumns(layout, row)
+
+	// Index the row's seats by column
+	seatsByColumn := make(map[string]*models.SeatWithPrice)
+	for _, seat := range seats {
+		if column := seatColumn(seat.Seat.Code); column != "" {
+			seatsByColumn[column] = seat
+		}
+	}
+
+	seatRow := models.SeatMapRow{
+		RowNumber: row.RowNumber,
+		SeatCodes: []string{},
+		Seats:     []models.SeatMapItem{blankSlot(left...)},
+	}
+
+	for g, group := range layout.Groups {
+		if g > 0 {
+			seatRow.Seats = append(seatRow.Seats, aisleSlot())
+		}
+
+		for _, column := range group {
+			seat, ok := seatsByColumn[column]
+			if !ok || !rowColumns[column] {
+				seatRow.Seats = append(seatRow.Seats, blankSlot())
+				continue
 			}
 
-			// Add left side placeholder
-			seatRow.Seats = append(seatRow.Seats, models.SeatMapItem{
-				SlotCharacteristics: []string{"LEFT_SIDE"},
-				StorefrontSlotCode:  "BLANK",
-				Available:           false,
-				Entitled:            false,
-				FeeWaived:           false,
-				FreeOfCharge:        true,
-				OriginallySelected:  false,
-			})
+			seatRow.SeatCodes = append(seatRow.SeatCodes, seat.Seat.Code)
+			seatRow.Seats = append(seatRow.Seats, seatMapItem(seat, layout))
+		}
+	}
+
+	seatRow.Seats = append(seatRow.Seats, blankSlot(right...))
+	return seatRow
+}
+
+// seatRowColumns returns the cabin columns a row has seats in. Rows whose seat
+// codes name none of the cabin's columns predate the row layout rules, so all
+// of the cabin's columns are rendered for them.
+func seatRowColumns(layout *models.CabinLayout, row *models.SeatRow) map[string]bool {
+	cabinColumns := make(map[string]bool)
+	for _, column := range layout.Columns() {
+		cabinColumns[column] = true
+	}
+
+	rowColumns := make(map[string]bool)
+	for _, column := range row.Columns() {
+		if cabinColumns[column] {
+			rowColumns[column] = true
+		}
+	}
+
+	if len(rowColumns) == 0 {
+		return cabinColumns
+	}
+	return rowColumns
+}
+
+// facilityRow renders space facilities such as lavatories and galleys as a row
+// without seats, with each facility's type on the columns it occupies
+func facilityRow(layout *models.CabinLayout, facilities []*models.CabinFacility) models.SeatMapRow {
+	occupied := make(map[string]string)
+	for _, facility := range facilities {
+		for _, column := range facility.ColumnList() {
+			occupied[column] = facility.Type
+		}
+	}
+
+	row := models.SeatMapRow{
+		SeatCodes: []string{},
+		Seats:     []models.SeatMapItem{blankSlot(models.ColumnLeftSide)},
+	}
+
+	for g, group := range layout.Groups {
+		if g > 0 {
+			row.Seats = append(row.Seats, aisleSlot())
+		}
 
-			// Add seats for this row
-			rowSeats, hasSeats := seatsByRow[rowNum]
-			if hasSeats {
-				// Add actual seats
-				for _, col := range columns {
-					seatCode := fmt.Sprintf("%d%s", rowNum, col)
-					found := false
-
-					// Find the seat with this code
-					for _, s := range rowSeats {
-						if s.Seat.Code == seatCode {
-							// Parse seat characteristics
-							characteristics := []string{}
-							if s.Seat.SeatCharacteristics != "" {
-								characteristics = strings.Split(s.Seat.SeatCharacteristics, ",")
-							}
-
-							// Add the seat
-							seatRow.Seats = append(seatRow.Seats, models.SeatMapItem{
-								StorefrontSlotCode:  s.Seat.StorefrontSlotCode,
-								Available:           s.Seat.Available,
-								Code:                s.Seat.Code,
-								Entitled:            s.Seat.Entitled,
-								FeeWaived:           s.Seat.FeeWaived,
-								FreeOfCharge:        s.Seat.FreeOfCharge,
-								OriginallySelected:  s.Seat.OriginallySelected,
-								SlotCharacteristics: characteristics,
-								Designations:        []string{},
-							})
-							found = true
-							break
-						}
-					}
-
-					// If seat not found, add a blank seat
-					if !found {
-						seatRow.Seats = append(seatRow.Seats, models.SeatMapItem{
-							StorefrontSlotCode: "BLANK",
-							Available:          false,
-							Entitled:           false,
-							FeeWaived:          false,
-							FreeOfCharge:       true,
-							OriginallySelected: false,
-						})
-					}
-				}
+		for _, column := range group {
+			if facilityType, ok := occupied[column]; ok {
+				row.Seats = append(row.Seats, blankSlot(facilityType))
 			} else {
-				// No seats for this row, add blanks
-				for range columns {
-					seatRow.Seats = append(seatRow.Seats, models.SeatMapItem{
-						StorefrontSlotCode: "BLANK",
-						Available:          false,
-						Entitled:           false,
-						FeeWaived:          false,
-						FreeOfCharge:       true,
-						OriginallySelected: false,
-					})
-				}
+				row.Seats = append(row.Seats, blankSlot())
 			}
+		}
+	}
 
-			// Add right side placeholder
-			seatRow.Seats = append(seatRow.Seats, models.SeatMapItem{
-				SlotCharacteristics: []string{"RIGHT_SIDE"},
-				StorefrontSlotCode:  "BLANK",
-				Available:           false,
-				Entitled:            false,
-				FeeWaived:           false,
-				FreeOfCharge:        true,
-				OriginallySelected:  false,
-			})
+	row.Seats = append(row.Seats, blankSlot(models.ColumnRightSide))
+	return row
+}
 
-			// Add the row to the cabin
-			cabinMap.SeatRows = append(cabinMap.SeatRows, seatRow)
+// sideCharacteristics returns the characteristics of a row's left and right side slots
+func sideCharacteristics(markers []string) ([]string, []string) {
+	left := []string{models.ColumnLeftSide}
+	right := []string{models.ColumnRightSide}
+
+	for _, marker := range markers {
+		switch marker {
+		case models.FacilityTypeExitLeft:
+			left = append(left, marker)
+		case models.FacilityTypeExitRight:
+			right = append(right, marker)
+		default:
+			left = append(left, marker)
+			right = append(right, marker)
 		}
+	}
 
-		// Add the cabin to the seat map
-		passengerSeatMap.SeatMap.Cabins = append(passengerSeatMap.SeatMap.Cabins, cabinMap)
+	return left, right
+}
+
+// convertSeatPrice returns a copy of a seat with its price converted to currency
+func (s *SeatService) convertSeatPrice(seat *models.SeatWithPrice, currency string, converter *currencyConverter) (*models.SeatWithPrice, error) {
+	if s.exchangeRateRepository == nil && seat.Price.Price.Currency != currency {
+		return nil, fmt.Errorf("%w: %s to %s", services.ErrRateNotFound, seat.Price.Price.Currency, currency)
 	}
 
-	// Add the passenger seat map to the response
-	seatMapResponse.SeatsItineraryParts[0].SegmentSeatMaps[0].PassengerSeatMaps = append(
-		seatMapResponse.SeatsItineraryParts[0].SegmentSeatMaps[0].PassengerSeatMaps,
-		passengerSeatMap,
-	)
+	amount, err := converter.convert(seat.Price.Price, currency)
+	if err != nil {
+		return nil, err
+	}
 
-	return seatMapResponse, nil
+	price := *seat.Price
+	price.Price = amount
+	return &models.SeatWithPrice{Seat: seat.Seat, Price: &price}, nil
+}
+
+// seatMapItem converts a seat into a seat map slot. The window, aisle and middle
+// characteristics come from the cabin layout rather than the stored characteristics.
+// Priced seats carry their price after any discount, taxes and total, with the
+// price before a discount; without a breakdown the price is also the total.
+func seatMapItem(seatWithPrice *models.SeatWithPrice, layout *models.CabinLayout) models.SeatMapItem {
+	seat := seatWithPrice.Seat
+
+	item := models.SeatMapItem{
+		StorefrontSlotCode:  seat.StorefrontSlotCode,
+		Available:           seat.Available,
+		Code:                seat.Code,
+		Entitled:            seat.Entitled,
+		FeeWaived:           seat.FeeWaived,
+		FreeOfCharge:        seat.FreeOfCharge,
+		OriginallySelected:  seat.OriginallySelected,
+		SlotCharacteristics: layout.SeatCharacteristics(seat),
+		Designations:        []string{},
+	}
+
+	switch {
+	case seatWithPrice.Breakdown != nil:
+		item.Prices = models.NewSeatPricing(seatWithPrice.Breakdown.Net())
+		if seatWithPrice.Breakdown.Discount.IsPositive() {
+			item.OriginalPrices = models.NewSeatPricing(seatWithPrice.Breakdown.Base)
+			item.Discount = models.NewSeatPricing(seatWithPrice.Breakdown.Discount)
+		}
+		item.Taxes = models.NewSeatPricing(seatWithPrice.Breakdown.TaxTotal())
+		item.Total = models.NewSeatPricing(seatWithPrice.Breakdown.Total)
+		item.TaxBreakdown = seatWithPrice.Breakdown.Taxes
+	case seatWithPrice.Price != nil:
+		item.Prices = models.NewSeatPricing(seatWithPrice.Price.Price)
+		item.Total = models.NewSeatPricing(seatWithPrice.Price.Price)
+	}
+
+	return item
+}
+
+// blankSlot returns an empty seat map slot with the given characteristics
+func blankSlot(characteristics ...string) models.SeatMapItem {
+	return models.SeatMapItem{
+		SlotCharacteristics: characteristics,
+		StorefrontSlotCode:  models.SlotCodeBlank,
+		FreeOfCharge:        true,
+	}
+}
+
+// aisleSlot returns the empty slot placed between two column groups
+func aisleSlot() models.SeatMapItem {
+	return models.SeatMapItem{
+		StorefrontSlotCode: models.SlotCodeAisle,
+		FreeOfCharge:       true,
+	}
+}
+
+// seatColumn returns the column letter at the end of a seat code, or "" if there is none
+func seatColumn(code string) string {
+	if code == "" {
+		return ""
+	}
+
+	column := code[len(code)-1:]
+	if column < "A" || column > "Z" {
+		return ""
+	}
+
+	return column
 }
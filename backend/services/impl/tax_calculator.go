@@ -0,0 +1,110 @@
+package impl
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+	"go.uber.org/zap"
+)
+
+// taxCalculator computes the taxes and fees charged on the seats of a flight
+// from the tax rules for the countries of its origin and destination airports
+type taxCalculator struct {
+	rules     []*models.TaxRule
+	converter *currencyConverter
+}
+
+// newTaxCalculator loads the tax rules in effect at the converter's time for a
+// flight. Airports that are not known match only rules for any country; without
+// the repositories no taxes are charged.
+func newTaxCalculator(
+	airportRepository repositories.AirportRepository,
+	taxRuleRepository repositories.TaxRuleRepository,
+	flight *models.Flight,
+	converter *currencyConverter,
+) (*taxCalculator, error) {
+	calculator := &taxCalculator{converter: converter}
+	if airportRepository == nil || taxRuleRepository == nil {
+		return calculator, nil
+	}
+
+	originCountry, err := airportCountry(airportRepository, flight.Origin)
+	if err != nil {
+		return nil, err
+	}
+
+	destinationCountry, err := airportCountry(airportRepository, flight.Destination)
+	if err != nil {
+		return nil, err
+	}
+
+	calculator.rules, err = taxRuleRepository.GetApplicable(originCountry, destinationCountry, converter.at)
+	if err != nil {
+		zap.L().Error("Failed to get tax rules", zap.Error(err),
+			zap.String("origin_country", originCountry),
+			zap.String("destination_country", destinationCountry))
+		return nil, err
+	}
+
+	return calculator, nil
+}
+
+// airportCountry returns the country of an airport, or an empty string if the airport is not known
+func airportCountry(airportRepository repositories.AirportRepository, code string) (string, error) {
+	airport, err := airportRepository.GetByCode(code)
+	if err != nil {
+		zap.L().Error("Failed to get airport", zap.Error(err), zap.String("airport_code", code))
+		return "", err
+	}
+
+	if airport == nil {
+		zap.L().Warn("Unknown airport, its country is not known", zap.String("airport_code", code))
+		return "", nil
+	}
+
+	return airport.CountryCode, nil
+}
+
+// breakdown computes the taxes on a base price less its discount and the
+// total. Percent rules charge a share of the discounted price, fixed rules
+// their amount converted to the base price's currency. Seats that cost nothing
+// after the discount are not taxed.
+func (c *taxCalculator) breakdown(base, discount models.Money) (*models.PriceBreakdown, error) {
+	net := base.Sub(discount)
+	if !net.IsPositive() {
+		return models.NewPriceBreakdown(base, discount, nil), nil
+	}
+
+	taxes := make([]models.TaxLine, 0, len(c.rules))
+	for _, rule := range c.rules {
+		amount, err := c.tax(rule, net)
+		if err != nil {
+			return nil, err
+		}
+		taxes = append(taxes, models.TaxLine{Code: rule.Code, Name: rule.Name, Amount: amount})
+	}
+
+	return models.NewPriceBreakdown(base, discount, taxes), nil
+}
+
+// tax computes the amount a rule charges on a price
+func (c *taxCalculator) tax(rule *models.TaxRule, price models.Money) (models.Money, error) {
+	switch rule.Type {
+	case models.TaxTypePercent:
+		rate, err := rule.RateValue()
+		if err != nil {
+			zap.L().Error("Invalid tax rule", zap.Error(err), zap.String("tax_rule_id", rule.ID))
+			return models.Money{}, err
+		}
+		return price.Multiply(rate.Quo(rate, big.NewRat(100, 1))), nil
+	case models.TaxTypeFixed:
+		if rule.Amount == nil {
+			return models.Money{}, fmt.Errorf("tax rule %s has no amount", rule.ID)
+		}
+		return c.converter.convert(*rule.Amount, price.Currency)
+	}
+
+	return models.Money{}, fmt.Errorf("tax rule %s has unknown type %q", rule.ID, rule.Type)
+}
@@ -0,0 +1,72 @@
+package impl
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+)
+
+func TestTaxBreakdown(t *testing.T) {
+	fixedMYR := models.NewMoney(1000, "MYR")
+	fixedUSD := models.NewMoney(500, "USD")
+
+	vat := &models.TaxRule{ID: "vat", Code: "VAT", Type: models.TaxTypePercent, Rate: "6"}
+	service := &models.TaxRule{ID: "svc", Code: "SVC", Type: models.TaxTypePercent, Rate: "6.5"}
+	airport := &models.TaxRule{ID: "apt", Code: "APT", Type: models.TaxTypeFixed, Amount: &fixedMYR}
+	security := &models.TaxRule{ID: "sec", Code: "SEC", Type: models.TaxTypeFixed, Amount: &fixedUSD}
+
+	tests := []struct {
+		name     string
+		rules    []*models.TaxRule
+		base     int64
+		discount int64
+		taxes    []int64
+		total    int64
+		wantErr  bool
+	}{
+		{name: "no rules", base: 10000, discount: 0, taxes: []int64{}, total: 10000},
+		{name: "percent of the discounted price", rules: []*models.TaxRule{vat}, base: 10000, discount: 2000, taxes: []int64{480}, total: 8480},
+		{name: "percent rounded to minor units", rules: []*models.TaxRule{service}, base: 1001, discount: 0, taxes: []int64{65}, total: 1066},
+		{name: "fixed in the price's currency", rules: []*models.TaxRule{airport}, base: 10000, discount: 2000, taxes: []int64{1000}, total: 9000},
+		{name: "fixed converted to the price's currency", rules: []*models.TaxRule{security}, base: 10000, discount: 0, taxes: []int64{2350}, total: 12350},
+		{name: "percent and fixed", rules: []*models.TaxRule{vat, airport}, base: 10000, discount: 0, taxes: []int64{600, 1000}, total: 11600},
+		{name: "zero net is not taxed", rules: []*models.TaxRule{vat, airport}, base: 5000, discount: 5000, taxes: []int64{}, total: 0},
+		{name: "free seat is not taxed", rules: []*models.TaxRule{airport}, base: 0, discount: 0, taxes: []int64{}, total: 0},
+		{name: "fixed without an amount", rules: []*models.TaxRule{{ID: "bad", Type: models.TaxTypeFixed}}, base: 10000, wantErr: true},
+		{name: "invalid rate", rules: []*models.TaxRule{{ID: "bad", Type: models.TaxTypePercent, Rate: "six"}}, base: 10000, wantErr: true},
+		{name: "unknown type", rules: []*models.TaxRule{{ID: "bad", Type: "levy"}}, base: 10000, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calculator := &taxCalculator{
+				rules:     tt.rules,
+				converter: &currencyConverter{rates: map[string]*big.Rat{"USDMYR": big.NewRat(47, 10)}},
+			}
+
+			got, err := calculator.breakdown(models.NewMoney(tt.base, "MYR"), models.NewMoney(tt.discount, "MYR"))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("breakdown = %+v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("breakdown returned error: %v", err)
+			}
+
+			if len(got.Taxes) != len(tt.taxes) {
+				t.Fatalf("breakdown taxes = %+v, want %v", got.Taxes, tt.taxes)
+			}
+			for i, tax := range got.Taxes {
+				if tax.Amount.Amount != tt.taxes[i] || tax.Amount.Currency != "MYR" {
+					t.Errorf("tax %s = %d %s, want %d MYR", tax.Code, tax.Amount.Amount, tax.Amount.Currency, tt.taxes[i])
+				}
+			}
+			if got.Total.Amount != tt.total || got.Total.Currency != "MYR" {
+				t.Errorf("total = %d %s, want %d MYR", got.Total.Amount, got.Total.Currency, tt.total)
+			}
+		})
+	}
+}
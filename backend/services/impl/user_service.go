@@ -0,0 +1,254 @@
+package impl
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evaizee/seat-arrangements/backend/models"
+	"github.com/evaizee/seat-arrangements/backend/repositories"
+	"github.com/evaizee/seat-arrangements/backend/services"
+	"go.uber.org/zap"
+)
+
+// UserService is an implementation of the UserService interface
+type UserService struct {
+	userRepository          repositories.UserRepository
+	passengerRepository     repositories.PassengerRepository
+	frequentFlyerRepository repositories.FrequentFlyerRepository
+}
+
+// NewUserService creates a new UserService
+func NewUserService(
+	userRepository repositories.UserRepository,
+	passengerRepository repositories.PassengerRepository,
+	frequentFlyerRepository repositories.FrequentFlyerRepository,
+) services.UserService {
+	return &UserService{
+		userRepository:          userRepository,
+		passengerRepository:     passengerRepository,
+		frequentFlyerRepository: frequentFlyerRepository,
+	}
+}
+
+// GetByID retrieves a user by ID
+func (s *UserService) GetByID(id string) (*models.User, error) {
+	user, err := s.userRepository.GetByID(id)
+	if err != nil {
+		zap.L().Error("Failed to get user", zap.Error(err), zap.String("user_id", id))
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, services.ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+// GetByEmail retrieves a user by email
+func (s *UserService) GetByEmail(email string) (*models.User, error) {
+	user, err := s.userRepository.GetByEmail(normalizeEmail(email))
+	if err != nil {
+		zap.L().Error("Failed to get user by email", zap.Error(err))
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, services.ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+// UpdateUser updates a user
+func (s *UserService) UpdateUser(user *models.User) error {
+	user.UpdatedAt = time.Now()
+	return s.userRepository.Update(user)
+}
+
+// ChangePassword changes a user's password after checking the current one
+func (s *UserService) ChangePassword(userID, currentPassword, newPassword string) error {
+	user, err := s.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.CheckPassword(currentPassword) {
+		return services.ErrIncorrectPassword
+	}
+
+	if len(newPassword) < minPasswordLength {
+		return services.ErrWeakPassword
+	}
+
+	if err := user.UpdatePassword(newPassword); err != nil {
+		return fmt.Errorf("error updating password: %w", err)
+	}
+
+	err = s.userRepository.Update(user)
+	if err != nil {
+		zap.L().Error("Failed to update user", zap.Error(err), zap.String("user_id", userID))
+		return err
+	}
+
+	return nil
+}
+
+// GetProfile retrieves a user with the passengers they manage and their frequent flyer accounts
+func (s *UserService) GetProfile(userID string) (*models.UserProfile, error) {
+	user, err := s.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	passengers, err := s.passengerRepository.GetByUserID(userID)
+	if err != nil {
+		zap.L().Error("Failed to get passengers for user", zap.Error(err), zap.String("user_id", userID))
+		return nil, err
+	}
+
+	profile := &models.UserProfile{
+		User:       user,
+		Passengers: make([]*models.PassengerWithDetails, 0, len(passengers)),
+	}
+
+	for _, passenger := range passengers {
+		frequentFlyers, err := s.frequentFlyerRepository.GetByPassengerID(strconv.Itoa(passenger.ID))
+		if err != nil {
+			return nil, fmt.Errorf("error getting frequent flyers: %w", err)
+		}
+
+		profile.Passengers = append(profile.Passengers, &models.PassengerWithDetails{
+			Passenger:      *passenger,
+			FrequentFlyers: frequentFlyers,
+		})
+	}
+
+	return profile, nil
+}
+
+// UpdateName updates a user's first and last name
+func (s *UserService) UpdateName(userID, firstName, lastName string) (*models.User, error) {
+	user, err := s.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.FirstName = strings.TrimSpace(firstName)
+	user.LastName = strings.TrimSpace(lastName)
+
+	if err := s.UpdateUser(user); err != nil {
+		zap.L().Error("Failed to update user", zap.Error(err), zap.String("user_id", userID))
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// DeleteAccount deletes a user after confirming their password.
+// Passengers and bookings are kept and simply unlinked from the account.
+func (s *UserService) DeleteAccount(userID, password string) error {
+	user, err := s.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.CheckPassword(password) {
+		return services.ErrIncorrectPassword
+	}
+
+	err = s.userRepository.Delete(userID)
+	if err != nil {
+		zap.L().Error("Failed to delete user", zap.Error(err), zap.String("user_id", userID))
+		return err
+	}
+
+	return nil
+}
+
+// LinkPassenger adds a passenger to the passengers a user manages. Passenger IDs
+// are sequential and last names easy to guess, so the passenger's contact email
+// must also be the account's verified email address to prevent claiming
+// arbitrary passengers.
+func (s *UserService) LinkPassenger(userID, passengerID, lastName string) (*models.Passenger, error) {
+	user, err := s.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.EmailVerifiedAt == nil {
+		return nil, services.ErrEmailNotVerified
+	}
+
+	passenger, err := s.getPassenger(passengerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if passenger.Email == nil || normalizeEmail(*passenger.Email) != normalizeEmail(user.Email) {
+		return nil, services.ErrContactMismatch
+	}
+
+	if !strings.EqualFold(strings.TrimSpace(passenger.LastName), strings.TrimSpace(lastName)) {
+		return nil, services.ErrNameMismatch
+	}
+
+	if passenger.UserID != nil {
+		if *passenger.UserID == userID {
+			return passenger, nil
+		}
+		return nil, services.ErrPassengerLinked
+	}
+
+	passenger.UserID = &userID
+	err = s.passengerRepository.Update(passenger)
+	if err != nil {
+		zap.L().Error("Failed to link passenger", zap.Error(err), zap.String("passenger_id", passengerID))
+		return nil, err
+	}
+
+	return passenger, nil
+}
+
+// UnlinkPassenger removes a passenger from the passengers a user manages
+func (s *UserService) UnlinkPassenger(userID, passengerID string) error {
+	passenger, err := s.getPassenger(passengerID)
+	if err != nil {
+		return err
+	}
+
+	if passenger.UserID == nil || *passenger.UserID != userID {
+		return services.ErrPassengerNotFound
+	}
+
+	passenger.UserID = nil
+	err = s.passengerRepository.Update(passenger)
+	if err != nil {
+		zap.L().Error("Failed to unlink passenger", zap.Error(err), zap.String("passenger_id", passengerID))
+		return err
+	}
+
+	return nil
+}
+
+// getPassenger retrieves a passenger, mapping missing passengers to ErrPassengerNotFound
+func (s *UserService) getPassenger(passengerID string) (*models.Passenger, error) {
+	if _, err := strconv.Atoi(passengerID); err != nil {
+		return nil, services.ErrPassengerNotFound
+	}
+
+	passenger, err := s.passengerRepository.GetByID(passengerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, services.ErrPassengerNotFound
+		}
+		zap.L().Error("Failed to get passenger", zap.Error(err), zap.String("passenger_id", passengerID))
+		return nil, err
+	}
+
+	return passenger, nil
+}
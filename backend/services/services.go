@@ -1,16 +1,22 @@
 package services
 
 import (
+	"time"
+
 	"github.com/evaizee/seat-arrangements/backend/models"
 )
 
 // UserService defines the interface for user business logic
 type UserService interface {
-	Register(email, password, firstName, lastName string) (*models.User, error)
 	GetByID(id string) (*models.User, error)
 	GetByEmail(email string) (*models.User, error)
 	UpdateUser(user *models.User) error
 	ChangePassword(userID, currentPassword, newPassword string) error
+	GetProfile(userID string) (*models.UserProfile, error)
+	UpdateName(userID, firstName, lastName string) (*models.User, error)
+	DeleteAccount(userID, password string) error
+	LinkPassenger(userID, passengerID, lastName string) (*models.Passenger, error)
+	UnlinkPassenger(userID, passengerID string) error
 }
 
 // FlightService defines the interface for flight business logic
@@ -18,12 +24,16 @@ type FlightService interface {
 	GetByID(id string) (*models.Flight, error)
 	GetAll() ([]*models.Flight, error)
 	GetWithDetails(id string) (*models.FlightWithDetails, error)
+	Search(filter models.FlightFilter, page, pageSize int) (*models.FlightSearchResult, error)
 }
 
 // AircraftService defines the interface for aircraft business logic
 type AircraftService interface {
 	GetByID(id string) (*models.Aircraft, error)
 	GetAll() ([]*models.Aircraft, error)
+	CreateAircraft(code, name string) (*models.Aircraft, error)
+	UpdateAircraft(id, code, name string) (*models.Aircraft, error)
+	DeleteAircraft(id string) error
 }
 
 // CabinService defines the interface for cabin business logic
@@ -31,6 +41,16 @@ type CabinService interface {
 	GetByID(id string) (*models.Cabin, error)
 	GetByAircraftID(aircraftID string) ([]*models.Cabin, error)
 	GetWithSeats(id string) (*models.CabinWithSeats, error)
+	CreateCabin(cabin *models.Cabin) (*models.Cabin, error)
+	UpdateCabin(cabin *models.Cabin) (*models.Cabin, error)
+	DeleteCabin(id string) error
+	GetRows(cabinID string) ([]*models.SeatRow, error)
+	CreateRow(cabinID string, rowNumber int, seatCodes string) (*models.SeatRow, error)
+	UpdateRow(id string, rowNumber int, seatCodes string) (*models.SeatRow, error)
+	DeleteRow(id string) error
+	GetFacilities(cabinID string) ([]*models.CabinFacility, error)
+	AddFacility(cabinID, facilityType string, rowNumber int, position, columns string) (*models.CabinFacility, error)
+	DeleteFacility(id string) error
 }
 
 // SeatService defines the interface for seat business logic
@@ -38,37 +58,93 @@ type SeatService interface {
 	GetByID(id string) (*models.SeatWithPrice, error)
 	GetByRowID(rowID string) ([]*models.SeatWithPrice, error)
 	GetByFlightID(flightID string) ([]*models.SeatWithPrice, error)
-	GetSeatMap(flightID string, passengerID string) (*models.SeatMapResponse, error)
+	GetSeatMap(flightID, passengerID, deck, currency, promoCode string) (*models.SeatMapResponse, error)
+	GetBundleOffers(flightID, passengerID, currency string) (*models.BundleOffers, error)
 	UpdateAvailability(seatID string, available bool) error
+	UpdateRowAvailability(rowID string, available bool) error
+	CreateSeat(seat *models.Seat) (*models.Seat, error)
+	UpdateSeat(seat *models.Seat) (*models.Seat, error)
+	DeleteSeat(id string) error
+	SetPrice(seatID, priceType, amount, currency string) (*models.SeatPrice, error)
+}
+
+// LayoutService defines the interface for checking the consistency of seat layout data
+type LayoutService interface {
+	Check(segmentID string, fix bool) (*models.LayoutReport, error)
 }
 
 // BookingService defines the interface for booking business logic
 type BookingService interface {
-	CreateBooking(userID, flightID string, seatIDs []string) (*models.BookingWithDetails, error)
+	CreateBooking(userID, flightID string, seatIDs []string, currency, promoCode, paymentMethod string) (*models.BookingWithDetails, error)
+	HoldSeats(userID, flightID string, seatIDs []string, currency, promoCode string) (*models.BookingWithDetails, error)
+	ConfirmHold(bookingID, userID, paymentMethod string) (*models.BookingWithDetails, error)
+	ReleaseExpiredHolds() (int, error)
 	GetByID(id string) (*models.BookingWithDetails, error)
 	GetByUserID(userID string) ([]*models.BookingWithDetails, error)
-	CancelBooking(id string) error
+	CancelBooking(id, cancelledBy string) (*models.RefundBreakdown, error)
+	UpdateStatus(bookingID string, status models.BookingStatus, changedBy, reason string) (*models.Booking, error)
+	GetStatusHistory(bookingID string) ([]*models.BookingStatusChange, error)
+	GetRefund(bookingID string) (*models.RefundBreakdown, error)
+	ChangeSeat(bookingID, bookingSeatID, newSeatID, changedBy, paymentMethod string) (*models.SeatChange, error)
+	SwapSeats(firstBookingSeatID, secondBookingSeatID, firstPaymentMethod, secondPaymentMethod, changedBy string) ([]*models.SeatChange, error)
+	GetSeatChanges(bookingID string) ([]*models.SeatChange, error)
+	AddAncillary(bookingID, bookingSeatID, productID string) (*models.BookingWithDetails, error)
+	AddBundle(bookingID, bookingSeatID, bundleID string) (*models.BookingWithDetails, error)
+}
+
+// CurrencyService defines the interface for exchange rates and currency conversion
+type CurrencyService interface {
+	Convert(amount models.Money, currency string, at time.Time) (models.Money, error)
+	GetRates() ([]*models.ExchangeRate, error)
+	CreateRate(baseCurrency, quoteCurrency, rate string, effectiveFrom time.Time) (*models.ExchangeRate, error)
+}
+
+// PromotionService defines the interface for managing promotional codes
+type PromotionService interface {
+	GetAll() ([]*models.Promotion, error)
+	CreatePromotion(promotion *models.Promotion) (*models.Promotion, error)
+	SetActive(code string, active bool) (*models.Promotion, error)
+}
+
+// AncillaryService defines the interface for the ancillary product and bundle catalogue
+type AncillaryService interface {
+	GetProducts() ([]*models.AncillaryProduct, error)
+	GetBundles() ([]*models.Bundle, error)
+	CreateProduct(product *models.AncillaryProduct) (*models.AncillaryProduct, error)
+	CreateBundle(code, name, description string, seatCharacteristics []string, discountPercent string, productIDs []string) (*models.Bundle, error)
 }
 
 // AuthService defines the interface for authentication business logic
 type AuthService interface {
 	Register(email, password, firstName, lastName string) (*models.User, error)
-	Login(email, password string) (string, error) // Returns JWT token
-	ValidateToken(token string) (string, error)   // Returns user ID if valid
+	Login(email, password, ipAddress string) (string, error) // Returns JWT token
+	ValidateToken(token string) (string, error)              // Returns user ID if valid
+	RequestPasswordReset(email string) error
+	ResetPassword(token, newPassword string) error
+	RequestEmailVerification(userID string) error
+	VerifyEmail(token string) error
 }
 
 // PassengerService defines the interface for passenger business logic
 type PassengerService interface {
-	CreatePassenger(segmentID int, passengerIndex int, passengerNameNumber, firstName, lastName string) (*models.Passenger, error)
+	CreatePassenger(passenger *models.Passenger) (*models.Passenger, error)
 	GetByID(id string) (*models.Passenger, error)
 	GetBySegmentID(segmentID string) ([]*models.Passenger, error)
 	GetWithFrequentFlyers(id string) (*models.PassengerWithDetails, error)
 	UpdatePassenger(passenger *models.Passenger) error
+	CheckAPIS(id string) (*models.APISCheck, error)
+}
+
+// PNRService defines the interface for passenger name record business logic
+type PNRService interface {
+	CreatePNR(userID, itineraryID string, passengerIDs []int) (*models.PNRWithDetails, error)
+	GetByRecordLocator(locator, lastName string) (*models.PNRWithDetails, error)
+	LinkBooking(bookingID, locator, lastName string, assignments map[string]int) (*models.PNRWithDetails, error)
 }
 
 // FrequentFlyerService defines the interface for frequent flyer business logic
 type FrequentFlyerService interface {
-	CreateFrequentFlyer(passengerID int, airline, number string) (*models.FrequentFlyer, error)
+	CreateFrequentFlyer(frequentFlyer *models.FrequentFlyer) (*models.FrequentFlyer, error)
 	GetByID(id string) (*models.FrequentFlyer, error)
 	GetByPassengerID(passengerID string) ([]*models.FrequentFlyer, error)
 	UpdateFrequentFlyer(frequentFlyer *models.FrequentFlyer) error